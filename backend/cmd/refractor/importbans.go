@@ -0,0 +1,82 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/refractor"
+	"os"
+)
+
+const importBansUsage = "usage: refractor import-bans -format <minecraft|mordhau> -server <server ID> [-dry-run] <ban list file>"
+
+// runImportBans imports a ban list file from the command line. With -dry-run, the ban list is only checked and the
+// bans which would be imported and any conflicts are reported.
+func runImportBans(args []string, importService refractor.BanImportService) error {
+	flags := flag.NewFlagSet("import-bans", flag.ContinueOnError)
+	format := flags.String("format", "", "format of the ban list (minecraft or mordhau)")
+	serverID := flags.Int64("server", 0, "ID of the server the bans are imported to")
+	dryRun := flags.Bool("dry-run", false, "report conflicts without importing anything")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() != 1 {
+		return errors.New(importBansUsage)
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("could not open ban list: %v", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("could not read ban list: %v", err)
+	}
+
+	body := params.ImportBansParams{
+		Format:   *format,
+		ServerID: *serverID,
+		DryRun:   *dryRun,
+		Size:     info.Size(),
+	}
+
+	if ok, errors := body.Validate(); !ok {
+		return fmt.Errorf("invalid arguments: %v\n%s", errors, importBansUsage)
+	}
+
+	result, res := importService.ImportBans(file, body)
+	if !res.Success {
+		return fmt.Errorf("could not import bans: %s %v", res.Message, res.ValidationErrors)
+	}
+
+	for _, conflict := range result.Conflicts {
+		fmt.Printf("Line %d: %s %s - %s\n", conflict.Line, conflict.PlayerGameID, conflict.PlayerName,
+			conflict.Reason)
+	}
+
+	fmt.Println(res.Message)
+
+	return nil
+}
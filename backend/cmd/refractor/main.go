@@ -18,32 +18,50 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package main
 
 import (
+	"crypto/rand"
 	"database/sql"
 	"fmt"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/joho/godotenv"
+	"github.com/sniddunc/refractor/internal/appeal"
+	"github.com/sniddunc/refractor/internal/attachment"
 	"github.com/sniddunc/refractor/internal/auth"
+	"github.com/sniddunc/refractor/internal/banimport"
 	"github.com/sniddunc/refractor/internal/chat"
+	"github.com/sniddunc/refractor/internal/chatfilter"
+	"github.com/sniddunc/refractor/internal/comment"
+	"github.com/sniddunc/refractor/internal/console"
+	"github.com/sniddunc/refractor/internal/discord"
+	"github.com/sniddunc/refractor/internal/escalation"
 	"github.com/sniddunc/refractor/internal/game"
 	"github.com/sniddunc/refractor/internal/game/minecraft"
 	"github.com/sniddunc/refractor/internal/game/mordhau"
 	"github.com/sniddunc/refractor/internal/gameserver"
 	"github.com/sniddunc/refractor/internal/http/api"
 	"github.com/sniddunc/refractor/internal/infraction"
+	"github.com/sniddunc/refractor/internal/note"
 	"github.com/sniddunc/refractor/internal/params"
 	"github.com/sniddunc/refractor/internal/player"
 	"github.com/sniddunc/refractor/internal/rcon"
+	"github.com/sniddunc/refractor/internal/schedule"
 	"github.com/sniddunc/refractor/internal/search"
 	"github.com/sniddunc/refractor/internal/server"
+	"github.com/sniddunc/refractor/internal/session"
+	"github.com/sniddunc/refractor/internal/spam"
+	"github.com/sniddunc/refractor/internal/storage/filesystem"
 	"github.com/sniddunc/refractor/internal/storage/mysql"
 	"github.com/sniddunc/refractor/internal/summary"
 	"github.com/sniddunc/refractor/internal/user"
 	"github.com/sniddunc/refractor/internal/watchdog"
+	"github.com/sniddunc/refractor/internal/watchlist"
+	"github.com/sniddunc/refractor/internal/webhook"
 	"github.com/sniddunc/refractor/internal/websocket"
+	"github.com/sniddunc/refractor/pkg/config"
 	"github.com/sniddunc/refractor/pkg/env"
 	logger "github.com/sniddunc/refractor/pkg/log"
 	"github.com/sniddunc/refractor/pkg/perms"
 	"github.com/sniddunc/refractor/refractor"
+	"golang.org/x/crypto/bcrypt"
 	"log"
 	"os"
 )
@@ -89,6 +107,22 @@ func main() {
 	userService := user.NewUserService(userRepo, loggerInst)
 	userHandler := api.NewUserHandler(userService)
 
+	// Set up initial user if no users currently exist
+	if count := userRepo.GetCount(); count == 0 {
+		if err := setupInitialUser(userService); err != nil {
+			log.Fatalf("Could not create initial user. Error: %v", err)
+		}
+
+		loggerInst.Info("Initial user created from environment variables")
+	}
+
+	// Set up the system user. This must happen after the initial user is set up since the initial user is only created
+	// if no users exist.
+	systemUser, err := setupSystemUser(userRepo)
+	if err != nil {
+		log.Fatalf("Could not set up system user. Error: %v", err)
+	}
+
 	authService := auth.NewAuthService(userRepo, loggerInst, os.Getenv("JWT_SECRET"))
 	authHandler := api.NewAuthHandler(authService, secureMode)
 
@@ -120,29 +154,141 @@ func main() {
 	rconService.SubscribeOffline(websocketService.OnServerOffline)
 	rconService.SubscribePlayerListPoll(serverService.OnPlayerListUpdate)
 
-	chatService := chat.NewChatService(websocketService, rconService, loggerInst)
+	sessionRepo := mysql.NewSessionRepository(db)
+	sessionService := session.NewSessionService(sessionRepo, playerService, loggerInst)
+	sessionHandler := api.NewSessionHandler(sessionService)
+	rconService.SubscribeJoin(sessionService.OnPlayerJoin)
+	rconService.SubscribeQuit(sessionService.OnPlayerQuit)
+	rconService.SubscribeOffline(sessionService.OnServerOffline)
+	rconService.SubscribePlayerListPoll(sessionService.OnPlayerListUpdate)
+
+	infractionRepo := mysql.NewInfractionRepository(db)
+	infractionService := infraction.NewInfractionService(infractionRepo, playerService, serverService, userService,
+		rconService, gameService, loggerInst)
+	infractionHandler := api.NewInfractionHandler(infractionService, playerService, loggerInst)
+	rconService.SubscribeJoin(infractionHandler.OnPlayerJoin)
+
+	banImportService := banimport.NewBanImportService(infractionRepo, playerService, serverService, gameService,
+		systemUser.UserID, loggerInst)
+	banImportHandler := api.NewBanImportHandler(banImportService)
+
+	// Import a ban list instead of starting Refractor if the import-bans subcommand was used
+	if len(os.Args) > 1 && os.Args[1] == "import-bans" {
+		if err := runImportBans(os.Args[2:], banImportService); err != nil {
+			log.Fatal(err)
+		}
+
+		return
+	}
+
+	chatRepo := mysql.NewChatRepository(db)
+	chatSnapshotRepo := mysql.NewChatSnapshotRepository(db)
+	chatService := chat.NewChatService(chatRepo, chatSnapshotRepo, websocketService, rconService, playerService,
+		serverService, userService, infractionService, loggerInst)
+	chatHandler := api.NewChatHandler(chatService)
 	rconService.SubscribeChat(chatService.OnChatReceive)
 	websocketService.SubscribeChatSend(rconService.SendChatMessage)
 	websocketService.SubscribeChatSend(chatService.OnUserSendChat)
+	websocketService.SubscribeWhisperSend(chatService.OnUserWhisper)
+	infractionService.SubscribeCreate(chatService.OnInfractionCreate)
+
+	escalationPolicyRepo := mysql.NewEscalationPolicyRepository(db)
+	escalationService := escalation.NewEscalationService(escalationPolicyRepo, infractionService, systemUser.UserID,
+		loggerInst)
+	escalationHandler := api.NewEscalationHandler(escalationService)
+	infractionService.SubscribeCreate(escalationService.OnInfractionCreate)
+
+	chatFilterRuleRepo := mysql.NewChatFilterRuleRepository(db)
+	chatFilterService := chatfilter.NewChatFilterService(chatFilterRuleRepo, infractionService, playerService,
+		websocketService, systemUser.UserID, loggerInst)
+	chatFilterHandler := api.NewChatFilterHandler(chatFilterService)
+	rconService.SubscribeChat(chatFilterService.OnChatReceive)
+
+	spamRepo := mysql.NewSpamSettingsRepository(db)
+	spamService := spam.NewSpamService(spamRepo, serverService, infractionService, playerService, websocketService,
+		systemUser.UserID, loggerInst)
+	spamHandler := api.NewSpamHandler(spamService)
+	rconService.SubscribeChat(spamService.OnChatReceive)
+	rconService.SubscribeQuit(spamService.OnPlayerQuit)
+	rconService.SubscribeOffline(spamService.OnServerOffline)
+
+	// Staff are alerted when a watched player joins
+	watchlistService := watchlist.NewWatchlistService(playerService, serverService, userService, websocketService,
+		loggerInst)
+	rconService.SubscribeJoin(watchlistService.OnPlayerJoin)
+
+	webhookRepo := mysql.NewWebhookRepository(db)
+	webhookDeliveryRepo := mysql.NewWebhookDeliveryRepository(db)
+	webhookService := webhook.NewWebhookService(webhookRepo, webhookDeliveryRepo, playerService, loggerInst)
+	webhookHandler := api.NewWebhookHandler(webhookService)
+	infractionService.SubscribeCreate(webhookService.OnInfractionCreate)
+	infractionService.SubscribeUpdate(webhookService.OnInfractionUpdate)
+	infractionService.SubscribeDelete(webhookService.OnInfractionDelete)
+	rconService.SubscribeJoin(webhookService.OnPlayerJoin)
+	rconService.SubscribeQuit(webhookService.OnPlayerQuit)
+	rconService.SubscribeOnline(webhookService.OnServerOnline)
+	rconService.SubscribeOffline(webhookService.OnServerOffline)
+	rconService.SubscribeChat(webhookService.OnChatReceive)
+	playerService.SubscribeUpdate(webhookService.OnPlayerUpdate)
+	watchlistService.SubscribeWatchedPlayerJoin(webhookService.OnWatchedPlayerJoin)
+
+	// Infractions are posted to Discord as embeds linking back to the dashboard at DASHBOARD_URL
+	discordChannelRepo := mysql.NewDiscordChannelRepository(db)
+	discordService := discord.NewDiscordService(discordChannelRepo, playerService, serverService, userService,
+		os.Getenv("DASHBOARD_URL"), loggerInst)
+	discordHandler := api.NewDiscordHandler(discordService)
+	infractionService.SubscribeCreate(discordService.OnInfractionCreate)
+
+	scheduleRepo := mysql.NewScheduleRepository(db)
+	scheduleRunRepo := mysql.NewScheduleRunRepository(db)
+	scheduleService := schedule.NewScheduleService(scheduleRepo, scheduleRunRepo, serverService, gameService,
+		rconService, loggerInst)
+	scheduleHandler := api.NewScheduleHandler(scheduleService)
+
+	rconCommandRuleRepo := mysql.NewRCONCommandRuleRepository(db)
+	rconCommandLogRepo := mysql.NewRCONCommandLogRepository(db)
+	consoleService := console.NewConsoleService(rconCommandRuleRepo, rconCommandLogRepo, serverService, rconService,
+		loggerInst)
+	consoleHandler := api.NewConsoleHandler(consoleService)
+
+	appealRepo := mysql.NewAppealRepository(db)
+	appealService := appeal.NewAppealService(appealRepo, infractionService, userService, loggerInst)
+	appealHandler := api.NewAppealHandler(appealService)
+
+	// Evidence attachments are stored on the local filesystem
+	attachmentDir := "./attachments"
+	if dirVal := os.Getenv("ATTACHMENT_DIR"); dirVal != "" {
+		attachmentDir = dirVal
+	}
 
-	infractionRepo := mysql.NewInfractionRepository(db)
-	infractionService := infraction.NewInfractionService(infractionRepo, playerService, serverService, userService, loggerInst)
-	infractionHandler := api.NewInfractionHandler(infractionService)
+	blobStore, err := filesystem.NewBlobStore(attachmentDir)
+	if err != nil {
+		log.Fatalf("Could not set up attachment storage. Error: %v", err)
+	}
+
+	attachmentRepo := mysql.NewAttachmentRepository(db)
+	attachmentService := attachment.NewAttachmentService(attachmentRepo, blobStore, infractionService, loggerInst)
+	attachmentHandler := api.NewAttachmentHandler(attachmentService)
+	infractionService.SubscribePurge(attachmentService.OnInfractionPurge)
+
+	commentRepo := mysql.NewCommentRepository(db)
+	commentService := comment.NewCommentService(commentRepo, infractionService, userService, websocketService, loggerInst)
+	commentHandler := api.NewCommentHandler(commentService)
+
+	noteRepo := mysql.NewNoteRepository(db)
+	noteService := note.NewNoteService(noteRepo, playerService, userService, loggerInst)
+	noteHandler := api.NewNoteHandler(noteService)
 
-	summaryService := summary.NewSummaryService(playerService, infractionService, loggerInst)
+	summaryService := summary.NewSummaryService(playerService, infractionService, attachmentService, noteService,
+		loggerInst)
 	summaryHandler := api.NewSummaryHandler(summaryService)
 
-	searchService := search.NewSearchService(playerRepo, infractionRepo, loggerInst)
+	searchService := search.NewSearchService(playerRepo, infractionRepo, appealRepo, chatRepo, loggerInst)
 	searchHandler := api.NewSearchHandler(searchService)
 
-	// Set up initial user if no users currently exist
-	if count := userRepo.GetCount(); count == 0 {
-		if err := setupInitialUser(userService); err != nil {
-			log.Fatalf("Could not create initial user. Error: %v", err)
-		}
-
-		loggerInst.Info("Initial user created from environment variables")
-	}
+	// Close any player sessions left open the last time Refractor stopped. This must happen before the RCON clients
+	// are set up since setting them up opens sessions for players who are already online.
+	sessionService.CloseAbandonedSessions()
 
 	// Set up RCON clients for all existing servers
 	if err := setupServerClients(rconService, serverService, loggerInst); err != nil {
@@ -152,6 +298,12 @@ func main() {
 	// Start RCON client watchdog
 	go watchdog.StartRCONServerWatchdog(rconService, serverService, loggerInst)
 
+	// Start infraction expiry watchdog
+	go watchdog.StartInfractionExpiryWatchdog(infractionService, websocketService, loggerInst)
+
+	// Start schedule watchdog
+	go watchdog.StartScheduleWatchdog(scheduleService, loggerInst)
+
 	// API Setup
 	apiHandlers := &api.Handlers{
 		AuthHandler:       authHandler,
@@ -162,6 +314,20 @@ func main() {
 		InfractionHandler: infractionHandler,
 		SummaryHandler:    summaryHandler,
 		SearchHandler:     searchHandler,
+		EscalationHandler: escalationHandler,
+		AppealHandler:     appealHandler,
+		AttachmentHandler: attachmentHandler,
+		CommentHandler:    commentHandler,
+		NoteHandler:       noteHandler,
+		SessionHandler:    sessionHandler,
+		ChatFilterHandler: chatFilterHandler,
+		SpamHandler:       spamHandler,
+		ChatHandler:       chatHandler,
+		WebhookHandler:    webhookHandler,
+		DiscordHandler:    discordHandler,
+		ScheduleHandler:   scheduleHandler,
+		ConsoleHandler:    consoleHandler,
+		BanImportHandler:  banImportHandler,
 	}
 
 	// Done. Begin serving.
@@ -233,6 +399,48 @@ func setupInitialUser(userService refractor.UserService) error {
 	return nil
 }
 
+// setupSystemUser gets the user which actions taken automatically by Refractor are attributed to, creating it if it
+// does not exist yet. The system user is deactivated and has an unusable password so nobody can log in as it.
+func setupSystemUser(userRepo refractor.UserRepository) (*refractor.User, error) {
+	systemUser, err := userRepo.FindOne(refractor.FindArgs{
+		"Email": config.SystemUserEmail,
+	})
+	if err == nil {
+		return systemUser, nil
+	}
+
+	if err != refractor.ErrNotFound {
+		return nil, err
+	}
+
+	// Hash a random password which is immediately thrown away
+	password := make([]byte, 32)
+	if _, err := rand.Read(password); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword(password, bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	systemUser = &refractor.User{
+		Username:    config.SystemUserUsername,
+		Email:       config.SystemUserEmail,
+		Password:    string(hashedPassword),
+		Permissions: 0,
+	}
+
+	if err := userRepo.Create(systemUser); err != nil {
+		return nil, err
+	}
+
+	return userRepo.Update(systemUser.UserID, refractor.UpdateArgs{
+		"Activated":           false,
+		"NeedsPasswordChange": false,
+	})
+}
+
 func setupServerClients(rconService refractor.RCONService, serverService refractor.ServerService, log logger.Logger) error {
 	allServers, res := serverService.GetAllServers()
 	if !res.Success {
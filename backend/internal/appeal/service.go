@@ -0,0 +1,345 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package appeal
+
+import (
+	"fmt"
+	"github.com/sniddunc/bitperms"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/pkg/perms"
+	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"time"
+)
+
+type appealService struct {
+	repo              refractor.AppealRepository
+	infractionService refractor.InfractionService
+	userService       refractor.UserService
+	log               log.Logger
+}
+
+func NewAppealService(repo refractor.AppealRepository, infractionService refractor.InfractionService,
+	userService refractor.UserService, log log.Logger) refractor.AppealService {
+	return &appealService{
+		repo:              repo,
+		infractionService: infractionService,
+		userService:       userService,
+		log:               log,
+	}
+}
+
+func (s *appealService) CreateAppeal(body params.CreateAppealParams) (*refractor.Appeal, *refractor.ServiceResponse) {
+	infraction, res := s.infractionService.GetInfractionByID(body.InfractionID)
+	if !res.Success {
+		return nil, res
+	}
+
+	if infraction.Type != refractor.INFRACTION_TYPE_MUTE && infraction.Type != refractor.INFRACTION_TYPE_BAN {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    "Only bans and mutes can be appealed",
+		}
+	}
+
+	if infraction.Revoked {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    "This infraction has already been revoked",
+		}
+	}
+
+	// Only one appeal can be pending against an infraction at a time
+	existingAppeals, err := s.repo.FindMany(refractor.FindArgs{
+		"InfractionID": infraction.InfractionID,
+	})
+	if err != nil && err != refractor.ErrNotFound {
+		s.log.Error("Could not get appeals for infraction ID %d. Error: %v", infraction.InfractionID, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	for _, existing := range existingAppeals {
+		if !existing.IsClosed() {
+			return nil, &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    "An appeal is already pending for this infraction",
+			}
+		}
+	}
+
+	now := time.Now().Unix()
+
+	newAppeal, err := s.repo.Create(&refractor.DBAppeal{
+		InfractionID: infraction.InfractionID,
+		PlayerID:     infraction.PlayerID,
+		UserID:       body.UserMeta.UserID,
+		Status:       refractor.APPEAL_STATUS_OPEN,
+		Statement:    body.Statement,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	})
+	if err != nil {
+		s.log.Error("Could not insert new appeal into repository. Error: %v", err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	if res := s.recordEvent(newAppeal, body.UserMeta.UserID, refractor.APPEAL_ACTION_OPENED, body.Statement); !res.Success {
+		return nil, res
+	}
+
+	return newAppeal, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Appeal opened",
+	}
+}
+
+func (s *appealService) GetAppealByID(id int64) (*refractor.Appeal, *refractor.ServiceResponse) {
+	appeal, res := s.getAppeal(id)
+	if !res.Success {
+		return nil, res
+	}
+
+	history, err := s.repo.FindEvents(appeal.AppealID)
+	if err != nil && err != refractor.ErrNotFound {
+		s.log.Error("Could not get history of appeal ID %d. Error: %v", appeal.AppealID, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	appeal.History = history
+
+	return appeal, res
+}
+
+func (s *appealService) AssignReviewer(id int64, body params.AssignAppealReviewerParams) (*refractor.Appeal, *refractor.ServiceResponse) {
+	appeal, res := s.getOpenAppeal(id)
+	if !res.Success {
+		return nil, res
+	}
+
+	reviewer, res := s.userService.GetUserByID(body.ReviewerID)
+	if res != nil && !res.Success {
+		return nil, res
+	}
+
+	if !canReviewAppeals(reviewer.Permissions) {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    "This user is not allowed to review appeals",
+		}
+	}
+
+	updatedAppeal, err := s.repo.Update(appeal.AppealID, refractor.UpdateArgs{
+		"ReviewerID": reviewer.UserID,
+		"Status":     refractor.APPEAL_STATUS_UNDER_REVIEW,
+	})
+	if err != nil {
+		s.log.Error("Could not assign reviewer to appeal ID %d. Error: %v", appeal.AppealID, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	notes := fmt.Sprintf("Assigned to %s", reviewer.Username)
+	if res := s.recordEvent(updatedAppeal, body.UserMeta.UserID, refractor.APPEAL_ACTION_ASSIGNED, notes); !res.Success {
+		return nil, res
+	}
+
+	return updatedAppeal, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Reviewer assigned",
+	}
+}
+
+func (s *appealService) AddNote(id int64, body params.AppealNoteParams) (*refractor.Appeal, *refractor.ServiceResponse) {
+	appeal, res := s.getAppeal(id)
+	if !res.Success {
+		return nil, res
+	}
+
+	if res := s.recordEvent(appeal, body.UserMeta.UserID, refractor.APPEAL_ACTION_NOTE, body.Notes); !res.Success {
+		return nil, res
+	}
+
+	return appeal, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Note added",
+	}
+}
+
+// DecideAppeal closes an appeal as either accepted or denied. Accepting an appeal revokes the appealed infraction. The
+// infraction is revoked before the decision is stored so that an appeal is never marked as accepted while the ban or
+// mute it was made against is still in force.
+func (s *appealService) DecideAppeal(id int64, status string, body params.AppealNoteParams) (*refractor.Appeal, *refractor.ServiceResponse) {
+	var action string
+
+	switch status {
+	case refractor.APPEAL_STATUS_ACCEPTED:
+		action = refractor.APPEAL_ACTION_ACCEPTED
+	case refractor.APPEAL_STATUS_DENIED:
+		action = refractor.APPEAL_ACTION_DENIED
+	default:
+		s.log.Error("DecideAppeal called with invalid status %s", status)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	appeal, res := s.getOpenAppeal(id)
+	if !res.Success {
+		return nil, res
+	}
+
+	decisionRes := &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Appeal denied",
+	}
+
+	if status == refractor.APPEAL_STATUS_ACCEPTED {
+		decisionRes = s.revokeAppealedInfraction(appeal, body)
+		if !decisionRes.Success {
+			return nil, decisionRes
+		}
+	}
+
+	updateArgs := refractor.UpdateArgs{
+		"Status":        status,
+		"DecisionNotes": body.Notes,
+	}
+
+	// Whoever makes the decision becomes the reviewer if nobody was assigned
+	if appeal.ReviewerID == 0 {
+		updateArgs["ReviewerID"] = body.UserMeta.UserID
+	}
+
+	updatedAppeal, err := s.repo.Update(appeal.AppealID, updateArgs)
+	if err != nil {
+		s.log.Error("Could not update status of appeal ID %d. Error: %v", appeal.AppealID, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	if res := s.recordEvent(updatedAppeal, body.UserMeta.UserID, action, body.Notes); !res.Success {
+		return nil, res
+	}
+
+	return updatedAppeal, decisionRes
+}
+
+// revokeAppealedInfraction revokes the infraction an appeal was made against. The revoke is carried out by the system
+// on the reviewer's behalf since reviewers may not have permission to edit infractions created by other staff members.
+// If the infraction was already revoked since the appeal was made, there is nothing left to do.
+func (s *appealService) revokeAppealedInfraction(appeal *refractor.Appeal, body params.AppealNoteParams) *refractor.ServiceResponse {
+	infraction, res := s.infractionService.GetInfractionByID(appeal.InfractionID)
+	if !res.Success {
+		return res
+	}
+
+	if infraction.Revoked {
+		return &refractor.ServiceResponse{
+			Success:    true,
+			StatusCode: http.StatusOK,
+			Message:    "Appeal accepted. The infraction had already been revoked",
+		}
+	}
+
+	_, res = s.infractionService.RevokeInfractionAsSystem(appeal.InfractionID, body.UserMeta.UserID,
+		fmt.Sprintf("Appeal #%d accepted. %s", appeal.AppealID, body.Notes))
+	if !res.Success {
+		s.log.Warn("Appeal ID %d could not be accepted since infraction ID %d could not be revoked: %s",
+			appeal.AppealID, appeal.InfractionID, res.Message)
+		return res
+	}
+
+	return &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Appeal accepted. " + res.Message,
+	}
+}
+
+func (s *appealService) getAppeal(id int64) (*refractor.Appeal, *refractor.ServiceResponse) {
+	appeal, err := s.repo.FindByID(id)
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return nil, &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			}
+		}
+
+		s.log.Error("Could not get appeal by id %d. Error: %v", id, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	return appeal, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Appeal fetched",
+	}
+}
+
+// getOpenAppeal gets an appeal which has not been decided on yet.
+func (s *appealService) getOpenAppeal(id int64) (*refractor.Appeal, *refractor.ServiceResponse) {
+	appeal, res := s.getAppeal(id)
+	if !res.Success {
+		return nil, res
+	}
+
+	if appeal.IsClosed() {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    "A decision has already been made on this appeal",
+		}
+	}
+
+	return appeal, res
+}
+
+func (s *appealService) recordEvent(appeal *refractor.Appeal, userID int64, action, notes string) *refractor.ServiceResponse {
+	event := &refractor.AppealEvent{
+		AppealID:  appeal.AppealID,
+		UserID:    userID,
+		Action:    action,
+		Status:    appeal.Status,
+		Notes:     notes,
+		Timestamp: time.Now().Unix(),
+	}
+
+	if err := s.repo.CreateEvent(event); err != nil {
+		s.log.Error("Could not record %s event for appeal ID %d. Error: %v", action, appeal.AppealID, err)
+		return refractor.InternalErrorResponse
+	}
+
+	return &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+	}
+}
+
+func canReviewAppeals(userPerms int64) bool {
+	permValue := bitperms.PermissionValue(userPerms)
+
+	return perms.UserHasFullAccess(permValue) || permValue.HasFlag(perms.REVIEW_APPEALS)
+}
@@ -0,0 +1,549 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package appeal
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/internal/game"
+	"github.com/sniddunc/refractor/internal/infraction"
+	"github.com/sniddunc/refractor/internal/mock"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/internal/player"
+	"github.com/sniddunc/refractor/internal/server"
+	"github.com/sniddunc/refractor/internal/user"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test_appealService_CreateAppeal(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	type fields struct {
+		mockInfractions map[int64]*refractor.DBInfraction
+		mockAppeals     map[int64]*refractor.DBAppeal
+	}
+	type args struct {
+		body params.CreateAppealParams
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		args           args
+		wantStatusCode int
+	}{
+		{
+			name: "appeal.createappeal.1",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+						Duration:     sql.NullInt32{Int32: 0, Valid: true},
+						Timestamp:    time.Now().Unix(),
+					},
+				},
+				mockAppeals: map[int64]*refractor.DBAppeal{},
+			},
+			args: args{
+				body: params.CreateAppealParams{
+					InfractionID: 1,
+					Statement:    "Test statement",
+					UserMeta:     &params.UserMeta{UserID: 1},
+				},
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "appeal.createappeal.2",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_KICK,
+						Reason:       sql.NullString{String: "Test kick reason", Valid: true},
+						Timestamp:    time.Now().Unix(),
+					},
+				},
+				mockAppeals: map[int64]*refractor.DBAppeal{},
+			},
+			args: args{
+				body: params.CreateAppealParams{
+					InfractionID: 1,
+					Statement:    "Test statement",
+					UserMeta:     &params.UserMeta{UserID: 1},
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "appeal.createappeal.3",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_MUTE,
+						Reason:       sql.NullString{String: "Test mute reason", Valid: true},
+						Duration:     sql.NullInt32{Int32: 60, Valid: true},
+						Timestamp:    time.Now().Unix(),
+						RevokedBy:    sql.NullInt64{Int64: 1, Valid: true},
+						RevokedAt:    sql.NullInt64{Int64: time.Now().Unix(), Valid: true},
+						RevokeReason: sql.NullString{String: "Test revoke reason", Valid: true},
+					},
+				},
+				mockAppeals: map[int64]*refractor.DBAppeal{},
+			},
+			args: args{
+				body: params.CreateAppealParams{
+					InfractionID: 1,
+					Statement:    "Test statement",
+					UserMeta:     &params.UserMeta{UserID: 1},
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "appeal.createappeal.4",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+						Duration:     sql.NullInt32{Int32: 0, Valid: true},
+						Timestamp:    time.Now().Unix(),
+					},
+				},
+				mockAppeals: map[int64]*refractor.DBAppeal{
+					1: {
+						AppealID:     1,
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						Status:       refractor.APPEAL_STATUS_UNDER_REVIEW,
+						Statement:    "Test statement",
+					},
+				},
+			},
+			args: args{
+				body: params.CreateAppealParams{
+					InfractionID: 1,
+					Statement:    "Test statement",
+					UserMeta:     &params.UserMeta{UserID: 1},
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "appeal.createappeal.5",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+						Duration:     sql.NullInt32{Int32: 0, Valid: true},
+						Timestamp:    time.Now().Unix(),
+					},
+				},
+				mockAppeals: map[int64]*refractor.DBAppeal{
+					1: {
+						AppealID:     1,
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						Status:       refractor.APPEAL_STATUS_DENIED,
+						Statement:    "Test statement",
+					},
+				},
+			},
+			args: args{
+				body: params.CreateAppealParams{
+					InfractionID: 1,
+					Statement:    "Test statement",
+					UserMeta:     &params.UserMeta{UserID: 1},
+				},
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name: "appeal.createappeal.6",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+						Duration:     sql.NullInt32{Int32: 0, Valid: true},
+						Timestamp:    time.Now().Unix(),
+						DeletedBy:    sql.NullInt64{Int64: 1, Valid: true},
+						DeletedAt:    sql.NullInt64{Int64: time.Now().Unix(), Valid: true},
+					},
+				},
+				mockAppeals: map[int64]*refractor.DBAppeal{},
+			},
+			args: args{
+				body: params.CreateAppealParams{
+					InfractionID: 1,
+					Statement:    "Test statement",
+					UserMeta:     &params.UserMeta{UserID: 1},
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInfractionRepo := mock.NewMockInfractionRepository(tt.fields.mockInfractions)
+			infractionService := infraction.NewInfractionService(mockInfractionRepo, nil, nil, nil, nil, nil, testLogger)
+			userService := user.NewUserService(mock.NewMockUserRepository(mock.GetMockUsers()), testLogger)
+			mockAppealRepo := mock.NewMockAppealRepository(tt.fields.mockAppeals)
+			appealService := NewAppealService(mockAppealRepo, infractionService, userService, testLogger)
+
+			appeal, res := appealService.CreateAppeal(tt.args.body)
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode, "Status codes should be equal. Message: %s", res.Message)
+
+			if res.Success {
+				assert.Equal(t, refractor.APPEAL_STATUS_OPEN, appeal.Status, "New appeals should be open")
+
+				appeal, _ = appealService.GetAppealByID(appeal.AppealID)
+				assert.Equal(t, 1, len(appeal.History), "An opened event should have been recorded")
+			}
+		})
+	}
+}
+
+func Test_appealService_DecideAppeal(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	type fields struct {
+		mockInfractions map[int64]*refractor.DBInfraction
+		mockAppeals     map[int64]*refractor.DBAppeal
+		mockPlayers     map[int64]*refractor.DBPlayer
+		mockServers     map[int64]*refractor.Server
+	}
+	type args struct {
+		id     int64
+		status string
+	}
+	tests := []struct {
+		name             string
+		fields           fields
+		args             args
+		wantStatusCode   int
+		wantRevoked      bool
+		wantGameCommand  []string
+		wantAppealStatus string
+	}{
+		{
+			name: "appeal.decideappeal.1",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+						Duration:     sql.NullInt32{Int32: 0, Valid: true},
+						Timestamp:    time.Now().Unix(),
+					},
+				},
+				mockAppeals: map[int64]*refractor.DBAppeal{
+					1: {
+						AppealID:     1,
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						Status:       refractor.APPEAL_STATUS_OPEN,
+						Statement:    "Test statement",
+					},
+				},
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+				},
+			},
+			args: args{
+				id:     1,
+				status: refractor.APPEAL_STATUS_ACCEPTED,
+			},
+			wantStatusCode:   http.StatusOK,
+			wantRevoked:      true,
+			wantGameCommand:  []string{"mockunban"},
+			wantAppealStatus: refractor.APPEAL_STATUS_ACCEPTED,
+		},
+		{
+			name: "appeal.decideappeal.2",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+						Duration:     sql.NullInt32{Int32: 0, Valid: true},
+						Timestamp:    time.Now().Unix(),
+					},
+				},
+				mockAppeals: map[int64]*refractor.DBAppeal{
+					1: {
+						AppealID:     1,
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						Status:       refractor.APPEAL_STATUS_OPEN,
+						Statement:    "Test statement",
+					},
+				},
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+				},
+			},
+			args: args{
+				id:     1,
+				status: refractor.APPEAL_STATUS_DENIED,
+			},
+			wantStatusCode:   http.StatusOK,
+			wantRevoked:      false,
+			wantGameCommand:  nil,
+			wantAppealStatus: refractor.APPEAL_STATUS_DENIED,
+		},
+		{
+			name: "appeal.decideappeal.3",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+						Duration:     sql.NullInt32{Int32: 0, Valid: true},
+						Timestamp:    time.Now().Unix(),
+					},
+				},
+				mockAppeals: map[int64]*refractor.DBAppeal{
+					1: {
+						AppealID:     1,
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						Status:       refractor.APPEAL_STATUS_DENIED,
+						Statement:    "Test statement",
+					},
+				},
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+				},
+			},
+			args: args{
+				id:     1,
+				status: refractor.APPEAL_STATUS_ACCEPTED,
+			},
+			wantStatusCode:   http.StatusBadRequest,
+			wantRevoked:      false,
+			wantGameCommand:  nil,
+			wantAppealStatus: refractor.APPEAL_STATUS_DENIED,
+		},
+		{
+			name: "appeal.decideappeal.4",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+						Duration:     sql.NullInt32{Int32: 0, Valid: true},
+						Timestamp:    time.Now().Unix(),
+						DeletedBy:    sql.NullInt64{Int64: 1, Valid: true},
+						DeletedAt:    sql.NullInt64{Int64: time.Now().Unix(), Valid: true},
+					},
+				},
+				mockAppeals: map[int64]*refractor.DBAppeal{
+					1: {
+						AppealID:     1,
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						Status:       refractor.APPEAL_STATUS_OPEN,
+						Statement:    "Test statement",
+					},
+				},
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+				},
+			},
+			args: args{
+				id:     1,
+				status: refractor.APPEAL_STATUS_ACCEPTED,
+			},
+			wantStatusCode:   http.StatusBadRequest,
+			wantRevoked:      false,
+			wantGameCommand:  nil,
+			wantAppealStatus: refractor.APPEAL_STATUS_OPEN,
+		},
+		{
+			name: "appeal.decideappeal.5",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_MUTE,
+						Reason:       sql.NullString{String: "Test mute reason", Valid: true},
+						Duration:     sql.NullInt32{Int32: 60, Valid: true},
+						Timestamp:    time.Now().Unix(),
+						RevokedBy:    sql.NullInt64{Int64: 1, Valid: true},
+						RevokedAt:    sql.NullInt64{Int64: time.Now().Unix(), Valid: true},
+						RevokeReason: sql.NullString{String: "Test revoke reason", Valid: true},
+					},
+				},
+				mockAppeals: map[int64]*refractor.DBAppeal{
+					1: {
+						AppealID:     1,
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						Status:       refractor.APPEAL_STATUS_OPEN,
+						Statement:    "Test statement",
+					},
+				},
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+				},
+			},
+			args: args{
+				id:     1,
+				status: refractor.APPEAL_STATUS_ACCEPTED,
+			},
+			wantStatusCode:   http.StatusOK,
+			wantRevoked:      true,
+			wantGameCommand:  nil,
+			wantAppealStatus: refractor.APPEAL_STATUS_ACCEPTED,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPlayerRepo := mock.NewMockPlayerRepository(tt.fields.mockPlayers)
+			playerService := player.NewPlayerService(mockPlayerRepo, testLogger)
+			mockServerRepo := mock.NewMockServerRepository(tt.fields.mockServers)
+			serverService := server.NewServerService(mockServerRepo, nil, testLogger)
+			userService := user.NewUserService(mock.NewMockUserRepository(mock.GetMockUsers()), testLogger)
+			gameService := game.NewGameService()
+			gameService.AddGame(mock.NewMockGame())
+			rconService := mock.NewMockRCONService(1)
+			mockInfractionRepo := mock.NewMockInfractionRepository(tt.fields.mockInfractions)
+			infractionService := infraction.NewInfractionService(mockInfractionRepo, playerService, serverService,
+				userService, rconService, gameService, testLogger)
+			mockAppealRepo := mock.NewMockAppealRepository(tt.fields.mockAppeals)
+			appealService := NewAppealService(mockAppealRepo, infractionService, userService, testLogger)
+
+			appeal, res := appealService.DecideAppeal(tt.args.id, tt.args.status, params.AppealNoteParams{
+				Notes:    "Test decision notes",
+				UserMeta: &params.UserMeta{UserID: 1},
+			})
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode, "Status codes should be equal. Message: %s", res.Message)
+			assert.Equal(t, tt.wantRevoked, tt.fields.mockInfractions[1].RevokedAt.Valid,
+				"Infraction revoked state should be equal")
+			assert.Equal(t, tt.wantGameCommand, rconService.Commands[1], "Game commands should be equal")
+			assert.Equal(t, tt.wantAppealStatus, tt.fields.mockAppeals[tt.args.id].Status,
+				"Stored appeal status should be equal")
+
+			if res.Success {
+				assert.Equal(t, tt.args.status, appeal.Status, "Appeal status should be equal")
+				assert.Equal(t, int64(1), appeal.ReviewerID, "Deciding user should have become the reviewer")
+			}
+		})
+	}
+}
@@ -0,0 +1,278 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package attachment
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type attachmentService struct {
+	repo              refractor.AttachmentRepository
+	blobStore         refractor.BlobStore
+	infractionService refractor.InfractionService
+	log               log.Logger
+}
+
+func NewAttachmentService(repo refractor.AttachmentRepository, blobStore refractor.BlobStore,
+	infractionService refractor.InfractionService, log log.Logger) refractor.AttachmentService {
+	return &attachmentService{
+		repo:              repo,
+		blobStore:         blobStore,
+		infractionService: infractionService,
+		log:               log,
+	}
+}
+
+func (s *attachmentService) UploadAttachment(infractionID int64, file io.Reader, body params.UploadAttachmentParams) (*refractor.Attachment, *refractor.ServiceResponse) {
+	infraction, res := s.infractionService.GetInfractionByID(infractionID)
+	if !res.Success {
+		return nil, res
+	}
+
+	if !body.UserMeta.CanEditInfraction(infraction.UserID) {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    config.MessageNoPermission,
+		}
+	}
+
+	storageKey, err := generateStorageKey(body.FileName)
+	if err != nil {
+		s.log.Error("Could not generate attachment storage key. Error: %v", err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	// Read at most one byte past the size limit so that we can tell if the file was larger than the client claimed
+	size, err := s.blobStore.Put(storageKey, io.LimitReader(file, config.AttachmentMaxSize+1))
+	if err != nil {
+		s.log.Error("Could not store attachment for infraction ID %d. Error: %v", infractionID, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	if size > config.AttachmentMaxSize {
+		s.deleteBlob(storageKey)
+
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    fmt.Sprintf("File must be no larger than %d MB", config.AttachmentMaxSize/1024/1024),
+		}
+	}
+
+	newAttachment := &refractor.Attachment{
+		InfractionID: infractionID,
+		UserID:       body.UserMeta.UserID,
+		FileName:     body.FileName,
+		ContentType:  body.ContentType,
+		Size:         size,
+		StorageKey:   storageKey,
+		Timestamp:    time.Now().Unix(),
+	}
+
+	if err := s.repo.Create(newAttachment); err != nil {
+		s.log.Error("Could not insert new attachment into repository. Error: %v", err)
+		s.deleteBlob(storageKey)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	return newAttachment, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Attachment uploaded",
+	}
+}
+
+func (s *attachmentService) GetInfractionAttachments(infractionID int64) ([]*refractor.Attachment, *refractor.ServiceResponse) {
+	// Make sure infraction exists and hasn't been deleted
+	if _, res := s.infractionService.GetInfractionByID(infractionID); !res.Success {
+		return nil, res
+	}
+
+	attachments, err := s.repo.FindManyByInfractionID(infractionID)
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return []*refractor.Attachment{}, &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Fetched 0 attachments",
+			}
+		}
+
+		s.log.Error("Could not get attachments for infraction ID %d. Error: %v", infractionID, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	if attachments == nil {
+		attachments = []*refractor.Attachment{}
+	}
+
+	return attachments, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    fmt.Sprintf("Fetched %d attachments", len(attachments)),
+	}
+}
+
+// GetAttachmentFile returns an attachment along with a reader for its contents. The caller must close the reader.
+func (s *attachmentService) GetAttachmentFile(infractionID int64, attachmentID int64) (*refractor.Attachment, io.ReadCloser, *refractor.ServiceResponse) {
+	attachment, res := s.getAttachment(infractionID, attachmentID)
+	if !res.Success {
+		return nil, nil, res
+	}
+
+	file, err := s.blobStore.Get(attachment.StorageKey)
+	if err != nil {
+		s.log.Error("Could not get file of attachment ID %d from blob store. Error: %v", attachmentID, err)
+		return nil, nil, refractor.InternalErrorResponse
+	}
+
+	return attachment, file, res
+}
+
+func (s *attachmentService) DeleteAttachment(infractionID int64, attachmentID int64, user params.UserMeta) *refractor.ServiceResponse {
+	attachment, res := s.getAttachment(infractionID, attachmentID)
+	if !res.Success {
+		return res
+	}
+
+	if !user.CanEditInfraction(attachment.UserID) {
+		return &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    config.MessageNoPermission,
+		}
+	}
+
+	if err := s.repo.Delete(attachmentID); err != nil {
+		s.log.Error("Could not delete attachment ID %d. Error: %v", attachmentID, err)
+		return refractor.InternalErrorResponse
+	}
+
+	s.deleteBlob(attachment.StorageKey)
+
+	return &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Attachment deleted",
+	}
+}
+
+func (s *attachmentService) GetPlayerAttachmentCounts(playerID int64) (map[int64]int, *refractor.ServiceResponse) {
+	counts, err := s.repo.CountByPlayerID(playerID)
+	if err != nil && err != refractor.ErrNotFound {
+		s.log.Error("Could not count attachments for player ID %d. Error: %v", playerID, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	if counts == nil {
+		counts = map[int64]int{}
+	}
+
+	return counts, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Attachment counts fetched",
+	}
+}
+
+// OnInfractionPurge removes the stored files of a permanently deleted infraction. The attachment rows themselves are
+// removed by the database when the infraction is deleted, so the blobs would otherwise be orphaned.
+func (s *attachmentService) OnInfractionPurge(infraction *refractor.Infraction) {
+	attachments, err := s.repo.FindManyByInfractionID(infraction.InfractionID)
+	if err != nil {
+		if err != refractor.ErrNotFound {
+			s.log.Error("Could not get attachments of purged infraction ID %d. Error: %v", infraction.InfractionID, err)
+		}
+
+		return
+	}
+
+	for _, attachment := range attachments {
+		if err := s.repo.Delete(attachment.AttachmentID); err != nil {
+			s.log.Error("Could not delete attachment ID %d. Error: %v", attachment.AttachmentID, err)
+			continue
+		}
+
+		s.deleteBlob(attachment.StorageKey)
+	}
+}
+
+// getAttachment gets an attachment by ID and makes sure it belongs to the given infraction.
+func (s *attachmentService) getAttachment(infractionID int64, attachmentID int64) (*refractor.Attachment, *refractor.ServiceResponse) {
+	// Attachments of deleted infractions are hidden until the infraction is restored
+	if _, res := s.infractionService.GetInfractionByID(infractionID); !res.Success {
+		return nil, res
+	}
+
+	attachment, err := s.repo.FindByID(attachmentID)
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return nil, &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			}
+		}
+
+		s.log.Error("Could not get attachment by id %d. Error: %v", attachmentID, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	if attachment.InfractionID != infractionID {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    config.MessageInvalidIDProvided,
+		}
+	}
+
+	return attachment, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Attachment fetched",
+	}
+}
+
+// deleteBlob removes a file from the blob store. Failures are only logged since the file is no longer referenced.
+func (s *attachmentService) deleteBlob(key string) {
+	if err := s.blobStore.Delete(key); err != nil {
+		s.log.Warn("Could not delete blob %s. Error: %v", key, err)
+	}
+}
+
+// generateStorageKey generates a random blob key which keeps the extension of the original file name.
+func generateStorageKey(fileName string) (string, error) {
+	randomBytes := make([]byte, 16)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(randomBytes) + strings.ToLower(filepath.Ext(fileName)), nil
+}
@@ -0,0 +1,427 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package attachment
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/internal/infraction"
+	"github.com/sniddunc/refractor/internal/mock"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/pkg/perms"
+	"github.com/sniddunc/refractor/refractor"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func Test_attachmentService_UploadAttachment(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	type fields struct {
+		mockInfractions map[int64]*refractor.DBInfraction
+	}
+	type args struct {
+		infractionID int64
+		contents     string
+		body         params.UploadAttachmentParams
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		args           args
+		wantStatusCode int
+		wantBlobs      int
+	}{
+		{
+			name: "attachment.uploadattachment.1",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+					},
+				},
+			},
+			args: args{
+				infractionID: 1,
+				contents:     "test screenshot",
+				body: params.UploadAttachmentParams{
+					FileName:    "screenshot.png",
+					ContentType: "image/png",
+					UserMeta:    &params.UserMeta{UserID: 1, Permissions: perms.EDIT_OWN_INFRACTIONS},
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBlobs:      1,
+		},
+		{
+			name: "attachment.uploadattachment.2",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+					},
+				},
+			},
+			args: args{
+				infractionID: 1,
+				contents:     "test screenshot",
+				body: params.UploadAttachmentParams{
+					FileName:    "screenshot.png",
+					ContentType: "image/png",
+					UserMeta:    &params.UserMeta{UserID: 2, Permissions: perms.EDIT_OWN_INFRACTIONS},
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBlobs:      0,
+		},
+		{
+			name: "attachment.uploadattachment.3",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+					},
+				},
+			},
+			args: args{
+				infractionID: 1,
+				contents:     strings.Repeat("a", int(config.AttachmentMaxSize)+1),
+				body: params.UploadAttachmentParams{
+					FileName:    "chat.log",
+					ContentType: "text/plain",
+					UserMeta:    &params.UserMeta{UserID: 2, Permissions: perms.EDIT_ANY_INFRACTION},
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBlobs:      0,
+		},
+		{
+			name: "attachment.uploadattachment.4",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+					},
+				},
+			},
+			args: args{
+				infractionID: 2,
+				contents:     "test screenshot",
+				body: params.UploadAttachmentParams{
+					FileName:    "screenshot.png",
+					ContentType: "image/png",
+					UserMeta:    &params.UserMeta{UserID: 1, Permissions: perms.FULL_ACCESS},
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBlobs:      0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blobStore := mock.NewMockBlobStore()
+			mockInfractionRepo := mock.NewMockInfractionRepository(tt.fields.mockInfractions)
+			infractionService := infraction.NewInfractionService(mockInfractionRepo, nil, nil, nil, nil, nil, testLogger)
+			mockAttachmentRepo := mock.NewMockAttachmentRepository(map[int64]*refractor.Attachment{})
+			attachmentService := NewAttachmentService(mockAttachmentRepo, blobStore, infractionService, testLogger)
+
+			attachment, res := attachmentService.UploadAttachment(tt.args.infractionID,
+				strings.NewReader(tt.args.contents), tt.args.body)
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode, "Status codes should be equal. Message: %s", res.Message)
+			assert.Equal(t, tt.wantBlobs, len(blobStore.Blobs), "Stored blob counts should be equal")
+
+			if res.Success {
+				assert.Equal(t, int64(len(tt.args.contents)), attachment.Size, "Attachment size should be equal")
+				assert.True(t, strings.HasSuffix(attachment.StorageKey, ".png"), "Storage key should keep the file extension")
+			}
+		})
+	}
+}
+
+func Test_attachmentService_DeleteAttachment(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	type fields struct {
+		mockInfractions map[int64]*refractor.DBInfraction
+		mockAttachments map[int64]*refractor.Attachment
+	}
+	type args struct {
+		infractionID int64
+		attachmentID int64
+		user         params.UserMeta
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		args           args
+		wantStatusCode int
+		wantBlobs      int
+	}{
+		{
+			name: "attachment.deleteattachment.1",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+					},
+				},
+				mockAttachments: map[int64]*refractor.Attachment{
+					1: {
+						AttachmentID: 1,
+						InfractionID: 1,
+						UserID:       2,
+						FileName:     "screenshot.png",
+						ContentType:  "image/png",
+						Size:         15,
+						StorageKey:   "abc.png",
+					},
+				},
+			},
+			args: args{
+				infractionID: 1,
+				attachmentID: 1,
+				user:         params.UserMeta{UserID: 2, Permissions: perms.EDIT_OWN_INFRACTIONS},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBlobs:      0,
+		},
+		{
+			name: "attachment.deleteattachment.2",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+					},
+				},
+				mockAttachments: map[int64]*refractor.Attachment{
+					1: {
+						AttachmentID: 1,
+						InfractionID: 1,
+						UserID:       2,
+						FileName:     "screenshot.png",
+						ContentType:  "image/png",
+						Size:         15,
+						StorageKey:   "abc.png",
+					},
+				},
+			},
+			args: args{
+				infractionID: 1,
+				attachmentID: 1,
+				user:         params.UserMeta{UserID: 1, Permissions: perms.EDIT_OWN_INFRACTIONS},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBlobs:      1,
+		},
+		{
+			name: "attachment.deleteattachment.3",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+					},
+				},
+				mockAttachments: map[int64]*refractor.Attachment{
+					1: {
+						AttachmentID: 1,
+						InfractionID: 1,
+						UserID:       2,
+						FileName:     "screenshot.png",
+						ContentType:  "image/png",
+						Size:         15,
+						StorageKey:   "abc.png",
+					},
+				},
+			},
+			args: args{
+				infractionID: 2,
+				attachmentID: 1,
+				user:         params.UserMeta{UserID: 1, Permissions: perms.EDIT_ANY_INFRACTION},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBlobs:      1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blobStore := mock.NewMockBlobStore()
+			blobStore.Blobs["abc.png"] = []byte("test screenshot")
+
+			mockInfractionRepo := mock.NewMockInfractionRepository(tt.fields.mockInfractions)
+			infractionService := infraction.NewInfractionService(mockInfractionRepo, nil, nil, nil, nil, nil, testLogger)
+			mockAttachmentRepo := mock.NewMockAttachmentRepository(tt.fields.mockAttachments)
+			attachmentService := NewAttachmentService(mockAttachmentRepo, blobStore, infractionService, testLogger)
+
+			res := attachmentService.DeleteAttachment(tt.args.infractionID, tt.args.attachmentID, tt.args.user)
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode, "Status codes should be equal. Message: %s", res.Message)
+			assert.Equal(t, tt.wantBlobs, len(blobStore.Blobs), "Stored blob counts should be equal")
+		})
+	}
+}
+
+func Test_attachmentService_GetInfractionAttachments(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	type fields struct {
+		mockInfractions map[int64]*refractor.DBInfraction
+		mockAttachments map[int64]*refractor.Attachment
+	}
+	tests := []struct {
+		name            string
+		fields          fields
+		infractionID    int64
+		wantStatusCode  int
+		wantAttachments int
+	}{
+		{
+			name: "attachment.getinfractionattachments.1",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+					},
+				},
+				mockAttachments: map[int64]*refractor.Attachment{
+					1: {
+						AttachmentID: 1,
+						InfractionID: 1,
+						UserID:       1,
+						FileName:     "screenshot.png",
+						StorageKey:   "abc.png",
+					},
+				},
+			},
+			infractionID:    1,
+			wantStatusCode:  http.StatusOK,
+			wantAttachments: 1,
+		},
+		{
+			name: "attachment.getinfractionattachments.2",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+						DeletedBy:    sql.NullInt64{Int64: 1, Valid: true},
+						DeletedAt:    sql.NullInt64{Int64: 1000, Valid: true},
+					},
+				},
+				mockAttachments: map[int64]*refractor.Attachment{
+					1: {
+						AttachmentID: 1,
+						InfractionID: 1,
+						UserID:       1,
+						FileName:     "screenshot.png",
+						StorageKey:   "abc.png",
+					},
+				},
+			},
+			infractionID:    1,
+			wantStatusCode:  http.StatusBadRequest,
+			wantAttachments: 0,
+		},
+		{
+			name: "attachment.getinfractionattachments.3",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{},
+				mockAttachments: map[int64]*refractor.Attachment{
+					1: {
+						AttachmentID: 1,
+						InfractionID: 1,
+						UserID:       1,
+						FileName:     "screenshot.png",
+						StorageKey:   "abc.png",
+					},
+				},
+			},
+			infractionID:    1,
+			wantStatusCode:  http.StatusBadRequest,
+			wantAttachments: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blobStore := mock.NewMockBlobStore()
+			blobStore.Blobs["abc.png"] = []byte("test screenshot")
+
+			mockInfractionRepo := mock.NewMockInfractionRepository(tt.fields.mockInfractions)
+			infractionService := infraction.NewInfractionService(mockInfractionRepo, nil, nil, nil, nil, nil, testLogger)
+			mockAttachmentRepo := mock.NewMockAttachmentRepository(tt.fields.mockAttachments)
+			attachmentService := NewAttachmentService(mockAttachmentRepo, blobStore, infractionService, testLogger)
+
+			attachments, res := attachmentService.GetInfractionAttachments(tt.infractionID)
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode, "Status codes should be equal. Message: %s", res.Message)
+			assert.Equal(t, tt.wantAttachments, len(attachments), "Attachment counts should be equal")
+
+			// Attachments which aren't listed must not be downloadable either
+			_, file, res := attachmentService.GetAttachmentFile(tt.infractionID, 1)
+			assert.Equal(t, tt.wantStatusCode, res.StatusCode, "Download status codes should be equal. Message: %s", res.Message)
+
+			if file != nil {
+				file.Close()
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package banimport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/validation"
+	"github.com/sniddunc/refractor/refractor"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultImportReason is used for imported bans which don't have a reason of their own
+const defaultImportReason = "Imported ban"
+
+// minecraftDateLayout is the layout of the created and expires dates in banned-players.json
+const minecraftDateLayout = "2006-01-02 15:04:05 -0700"
+
+// mordhauBanPattern matches a line of the BanList RCON command's output. Each line holds a player's PlayFabID, the
+// ban duration in minutes and the ban reason. The field labels are optional since they differ between game versions.
+var mordhauBanPattern = regexp.MustCompile("^(?:PlayFabID:\\s*)?([0-9A-Fa-f]{16})\\s*,\\s*(?:Duration:\\s*)?(-?\\d+)\\s*(?:,\\s*(?:Reason:\\s*)?(.*))?$")
+
+type minecraftBan struct {
+	UUID    string `json:"uuid"`
+	Name    string `json:"name"`
+	Created string `json:"created"`
+	Source  string `json:"source"`
+	Expires string `json:"expires"`
+	Reason  string `json:"reason"`
+}
+
+// parseMinecraftBanList reads the bans in a Minecraft server's banned-players.json file. Bans keep the date they were
+// created on and their duration is the time between that date and their expiry date. Entries which can't be read are
+// returned as conflicts.
+func parseMinecraftBanList(data []byte, now time.Time) ([]*refractor.BanImportEntry, []*refractor.BanImportConflict, error) {
+	var bans []*minecraftBan
+
+	if err := json.Unmarshal(data, &bans); err != nil {
+		return nil, nil, fmt.Errorf("the ban list is not a valid banned-players.json file")
+	}
+
+	var entries []*refractor.BanImportEntry
+	var conflicts []*refractor.BanImportConflict
+
+	for i, ban := range bans {
+		if ban == nil {
+			continue
+		}
+
+		line := i + 1
+		uuid, valid := validation.NormaliseMinecraftUUID(ban.UUID)
+		if !valid {
+			conflicts = append(conflicts, &refractor.BanImportConflict{
+				Line:         line,
+				PlayerGameID: ban.UUID,
+				PlayerName:   ban.Name,
+				Reason:       "Invalid player UUID",
+			})
+			continue
+		}
+
+		timestamp := now.Unix()
+		if created, err := time.Parse(minecraftDateLayout, ban.Created); err == nil {
+			timestamp = created.Unix()
+		}
+
+		duration := 0
+		if ban.Expires != "" && ban.Expires != "forever" {
+			expires, err := time.Parse(minecraftDateLayout, ban.Expires)
+			if err != nil {
+				conflicts = append(conflicts, &refractor.BanImportConflict{
+					Line:         line,
+					PlayerGameID: uuid,
+					PlayerName:   ban.Name,
+					Reason:       "Invalid expiry date",
+				})
+				continue
+			}
+
+			duration = getDurationMinutes(expires.Unix() - timestamp)
+		}
+
+		entries = append(entries, &refractor.BanImportEntry{
+			Line:         line,
+			PlayerGameID: uuid,
+			PlayerName:   strings.TrimSpace(ban.Name),
+			Reason:       getImportReason(ban.Reason),
+			Duration:     duration,
+			Timestamp:    timestamp,
+		})
+	}
+
+	return entries, conflicts, nil
+}
+
+// parseMordhauBanList reads the bans in the output of Mordhau's BanList RCON command. The output doesn't include when
+// the bans were created, so they are recorded as being created now. Bans with a duration of 0 or less are permanent.
+// Lines which can't be read are returned as conflicts.
+func parseMordhauBanList(data []byte, now time.Time) ([]*refractor.BanImportEntry, []*refractor.BanImportConflict, error) {
+	var entries []*refractor.BanImportEntry
+	var conflicts []*refractor.BanImportConflict
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	line := 0
+
+	for scanner.Scan() {
+		line++
+
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		match := mordhauBanPattern.FindStringSubmatch(text)
+		if match == nil {
+			conflicts = append(conflicts, &refractor.BanImportConflict{
+				Line:   line,
+				Reason: "Unrecognised ban list line",
+			})
+			continue
+		}
+
+		duration, err := strconv.Atoi(match[2])
+		if err != nil || duration < 0 {
+			duration = 0
+		}
+
+		if duration > config.InfractionDurationMax {
+			duration = config.InfractionDurationMax
+		}
+
+		// The pattern only matches valid PlayFabIDs, so only the case needs normalising
+		playFabID, _ := validation.NormalisePlayFabID(match[1])
+
+		entries = append(entries, &refractor.BanImportEntry{
+			Line:         line,
+			PlayerGameID: playFabID,
+			Reason:       getImportReason(match[3]),
+			Duration:     duration,
+			Timestamp:    now.Unix(),
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("the ban list could not be read")
+	}
+
+	return entries, conflicts, nil
+}
+
+// getDurationMinutes converts a ban length in seconds to minutes, rounding up so that a ban never ends early. Bans
+// always last at least a minute since a duration of 0 would make them permanent.
+func getDurationMinutes(seconds int64) int {
+	minutes := int64(math.Ceil(float64(seconds) / 60))
+
+	if minutes < 1 {
+		return 1
+	}
+
+	if minutes > int64(config.InfractionDurationMax) {
+		return config.InfractionDurationMax
+	}
+
+	return int(minutes)
+}
+
+// getImportReason returns the reason an imported ban is stored with
+func getImportReason(reason string) string {
+	reason = strings.TrimSpace(reason)
+
+	if reason == "" {
+		return defaultImportReason
+	}
+
+	// Truncate by runes rather than bytes so that multi-byte characters aren't split
+	runes := []rune(reason)
+	if len(runes) > config.InfractionReasonMaxLen {
+		return string(runes[:config.InfractionReasonMaxLen])
+	}
+
+	return reason
+}
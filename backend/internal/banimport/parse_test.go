@@ -0,0 +1,180 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package banimport
+
+import (
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/refractor"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func Test_parseMinecraftBanList(t *testing.T) {
+	now := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		data          string
+		wantEntries   []*refractor.BanImportEntry
+		wantConflicts []*refractor.BanImportConflict
+		wantErr       bool
+	}{
+		{
+			name: "banimport.parseminecraft.1",
+			data: `[
+				{"uuid": "C7A4DC4E-5D5C-4A0B-9F3B-2F1A1C9B8E7D", "name": "Cheater", "created": "2021-01-01 00:00:00 +0000", "source": "Server", "expires": "forever", "reason": "Flying"},
+				{"uuid": "0f8fad5b-d9cb-469f-a165-70867728950e", "name": "Griefer", "created": "2021-01-01 00:00:00 +0000", "source": "Admin", "expires": "2021-01-02 00:00:30 +0000", "reason": ""},
+				{"uuid": "not-a-uuid", "name": "Broken", "created": "2021-01-01 00:00:00 +0000", "source": "Server", "expires": "forever", "reason": "Test"},
+				{"uuid": "7c9e6679-7425-40de-944b-e07fc1f90ae7", "name": "BadDate", "created": "2021-01-01 00:00:00 +0000", "source": "Server", "expires": "tomorrow", "reason": "Test"}
+			]`,
+			wantEntries: []*refractor.BanImportEntry{
+				{
+					Line:         1,
+					PlayerGameID: "c7a4dc4e-5d5c-4a0b-9f3b-2f1a1c9b8e7d",
+					PlayerName:   "Cheater",
+					Reason:       "Flying",
+					Duration:     0,
+					Timestamp:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+				},
+				{
+					Line:         2,
+					PlayerGameID: "0f8fad5b-d9cb-469f-a165-70867728950e",
+					PlayerName:   "Griefer",
+					Reason:       defaultImportReason,
+					Duration:     1441,
+					Timestamp:    time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+				},
+			},
+			wantConflicts: []*refractor.BanImportConflict{
+				{
+					Line:         3,
+					PlayerGameID: "not-a-uuid",
+					PlayerName:   "Broken",
+					Reason:       "Invalid player UUID",
+				},
+				{
+					Line:         4,
+					PlayerGameID: "7c9e6679-7425-40de-944b-e07fc1f90ae7",
+					PlayerName:   "BadDate",
+					Reason:       "Invalid expiry date",
+				},
+			},
+		},
+		{
+			name:    "banimport.parseminecraft.2",
+			data:    "Cheater,Flying",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, conflicts, err := parseMinecraftBanList([]byte(tt.data), now)
+
+			assert.Equal(t, tt.wantErr, err != nil, "Unexpected error value. Error: %v", err)
+			assert.Equal(t, tt.wantEntries, entries, "Entries should be equal")
+			assert.Equal(t, tt.wantConflicts, conflicts, "Conflicts should be equal")
+		})
+	}
+}
+
+func Test_parseMordhauBanList(t *testing.T) {
+	now := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	data := "1a2b3c4d5e6f7a8b, 0, Cheating\n" +
+		"\n" +
+		"PlayFabID: 8B7A6F5E4D3C2B1A, Duration: 1440, Reason: Teamkilling, repeatedly\n" +
+		"AAAABBBBCCCCDDDD, 60\n" +
+		"This line is not a ban\n"
+
+	wantEntries := []*refractor.BanImportEntry{
+		{
+			Line:         1,
+			PlayerGameID: "1A2B3C4D5E6F7A8B",
+			Reason:       "Cheating",
+			Duration:     0,
+			Timestamp:    now.Unix(),
+		},
+		{
+			Line:         3,
+			PlayerGameID: "8B7A6F5E4D3C2B1A",
+			Reason:       "Teamkilling, repeatedly",
+			Duration:     1440,
+			Timestamp:    now.Unix(),
+		},
+		{
+			Line:         4,
+			PlayerGameID: "AAAABBBBCCCCDDDD",
+			Reason:       defaultImportReason,
+			Duration:     60,
+			Timestamp:    now.Unix(),
+		},
+	}
+
+	wantConflicts := []*refractor.BanImportConflict{
+		{
+			Line:   5,
+			Reason: "Unrecognised ban list line",
+		},
+	}
+
+	entries, conflicts, err := parseMordhauBanList([]byte(data), now)
+
+	assert.Nil(t, err, "Error should be nil")
+	assert.Equal(t, wantEntries, entries, "Entries should be equal")
+	assert.Equal(t, wantConflicts, conflicts, "Conflicts should be equal")
+}
+
+func Test_getImportReason(t *testing.T) {
+	tests := []struct {
+		name   string
+		reason string
+		want   string
+	}{
+		{
+			name:   "banimport.getimportreason.1",
+			reason: "  Cheating  ",
+			want:   "Cheating",
+		},
+		{
+			name:   "banimport.getimportreason.2",
+			reason: " ",
+			want:   defaultImportReason,
+		},
+		{
+			name:   "banimport.getimportreason.3",
+			reason: strings.Repeat("a", config.InfractionReasonMaxLen+10),
+			want:   strings.Repeat("a", config.InfractionReasonMaxLen),
+		},
+		{
+			name:   "banimport.getimportreason.4",
+			reason: strings.Repeat("é", config.InfractionReasonMaxLen+10),
+			want:   strings.Repeat("é", config.InfractionReasonMaxLen),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getImportReason(tt.reason)
+
+			assert.Equal(t, tt.want, got, "Reasons should be equal")
+			assert.True(t, utf8.ValidString(got), "Reason should be valid UTF-8")
+		})
+	}
+}
@@ -0,0 +1,285 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package banimport
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// banListParser reads the bans in a ban list. Entries which can't be read are returned as conflicts rather than
+// failing the whole import.
+type banListParser func(data []byte, now time.Time) ([]*refractor.BanImportEntry, []*refractor.BanImportConflict, error)
+
+// banListFormat describes how a ban list format is read and which game's servers it can be imported to
+type banListFormat struct {
+	game  string
+	parse banListParser
+}
+
+var banListFormats = map[string]banListFormat{
+	refractor.BAN_IMPORT_FORMAT_MINECRAFT: {game: "Minecraft", parse: parseMinecraftBanList},
+	refractor.BAN_IMPORT_FORMAT_MORDHAU:   {game: "Mordhau", parse: parseMordhauBanList},
+}
+
+type banImportService struct {
+	infractionRepo refractor.InfractionRepository
+	playerService  refractor.PlayerService
+	serverService  refractor.ServerService
+	gameService    refractor.GameService
+	systemUserID   int64
+	log            log.Logger
+}
+
+// NewBanImportService creates a new ban import service. Imported bans are attributed to the user with systemUserID.
+func NewBanImportService(infractionRepo refractor.InfractionRepository, playerService refractor.PlayerService,
+	serverService refractor.ServerService, gameService refractor.GameService, systemUserID int64,
+	log log.Logger) refractor.BanImportService {
+	return &banImportService{
+		infractionRepo: infractionRepo,
+		playerService:  playerService,
+		serverService:  serverService,
+		gameService:    gameService,
+		systemUserID:   systemUserID,
+		log:            log,
+	}
+}
+
+// ImportBans reads a ban list and records each ban in it as a BAN infraction on the provided server. Players who
+// aren't stored yet get a placeholder player which is reconciled when they join.
+//
+// Bans are not imported if the player already has an active ban or an identical ban was imported before, which makes
+// it safe to run the same import more than once. These are reported as conflicts along with any entries which could
+// not be read. If body.DryRun is true, nothing is stored and the result describes what the import would do.
+//
+// The imported bans are stored directly rather than through the infraction service. This means subscribers such as
+// webhooks and Discord are not notified, since an import can contain years of bans.
+func (s *banImportService) ImportBans(banList io.Reader, body params.ImportBansParams) (*refractor.BanImportResult, *refractor.ServiceResponse) {
+	format, ok := banListFormats[body.Format]
+	if !ok {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			ValidationErrors: url.Values{
+				"format": []string{"Invalid format"},
+			},
+		}
+	}
+
+	server, _ := s.serverService.GetServerByID(body.ServerID)
+	if server == nil {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			ValidationErrors: url.Values{
+				"serverId": []string{"Invalid server ID"},
+			},
+		}
+	}
+
+	if server.Game != format.game {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			ValidationErrors: url.Values{
+				"serverId": []string{fmt.Sprintf("This server does not run %s", format.game)},
+			},
+		}
+	}
+
+	game, _ := s.gameService.GetGame(server.Game)
+	if game == nil {
+		s.log.Error("Could not import bans. Game %s of server ID %d was not found", server.Game, server.ServerID)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(banList, config.BanImportMaxSize+1))
+	if err != nil {
+		s.log.Error("Could not read ban list. Error: %v", err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	// The size reported with the upload can't be trusted, so check how much was actually read
+	if int64(len(data)) > config.BanImportMaxSize {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			ValidationErrors: url.Values{
+				"file": []string{fmt.Sprintf("File must be no larger than %d MB", config.BanImportMaxSize/1024/1024)},
+			},
+		}
+	}
+
+	entries, conflicts, err := format.parse(data, time.Now())
+	if err != nil {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    "The ban list could not be read",
+			ValidationErrors: url.Values{
+				"file": []string{err.Error()},
+			},
+		}
+	}
+
+	result := &refractor.BanImportResult{
+		Format:    body.Format,
+		ServerID:  server.ServerID,
+		DryRun:    body.DryRun,
+		Total:     len(entries) + len(conflicts),
+		Conflicts: conflicts,
+	}
+
+	seen := map[string]bool{}
+
+	for _, entry := range entries {
+		if seen[entry.PlayerGameID] {
+			result.Conflicts = append(result.Conflicts, newConflict(entry, 0, "Duplicate entry for this player"))
+			continue
+		}
+
+		seen[entry.PlayerGameID] = true
+
+		conflict, err := s.importBan(entry, server, game.GetConfig(), body.DryRun)
+		if err != nil {
+			s.log.Error("Could not import ban on line %d. %d bans were imported before the error. Error: %v",
+				entry.Line, result.Imported, err)
+			return nil, refractor.InternalErrorResponse
+		}
+
+		if conflict != nil {
+			result.Conflicts = append(result.Conflicts, conflict)
+			continue
+		}
+
+		result.Imported++
+	}
+
+	sort.Slice(result.Conflicts, func(i, j int) bool {
+		return result.Conflicts[i].Line < result.Conflicts[j].Line
+	})
+
+	if result.Conflicts == nil {
+		result.Conflicts = []*refractor.BanImportConflict{}
+	}
+
+	message := fmt.Sprintf("Imported %d of %d bans with %d conflicts", result.Imported, result.Total,
+		len(result.Conflicts))
+
+	if body.DryRun {
+		message = fmt.Sprintf("Dry run complete. %d of %d bans would be imported with %d conflicts", result.Imported,
+			result.Total, len(result.Conflicts))
+	} else {
+		s.log.Info("Imported %d %s bans to server ID %d", result.Imported, body.Format, server.ServerID)
+	}
+
+	return result, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    message,
+	}
+}
+
+// importBan records a single imported ban. If the ban conflicts with the player's existing bans, the conflict is
+// returned and nothing is stored. Nothing is stored on a dry run either.
+func (s *banImportService) importBan(entry *refractor.BanImportEntry, server *refractor.Server,
+	gameConfig *refractor.GameConfig, dryRun bool) (*refractor.BanImportConflict, error) {
+	player, res := s.playerService.GetPlayer(refractor.FindArgs{
+		gameConfig.PlayerGameIDField: entry.PlayerGameID,
+	})
+	if !res.Success {
+		return nil, fmt.Errorf("could not get player with %s %s", gameConfig.PlayerGameIDField, entry.PlayerGameID)
+	}
+
+	if player != nil {
+		conflict, err := s.checkExistingBans(entry, player)
+		if err != nil || conflict != nil {
+			return conflict, err
+		}
+	}
+
+	if dryRun {
+		return nil, nil
+	}
+
+	if player == nil {
+		player, _ = s.playerService.GetOrCreatePlaceholder(entry.PlayerGameID, entry.PlayerName, gameConfig)
+		if player == nil {
+			return nil, fmt.Errorf("could not create placeholder player for %s %s", gameConfig.PlayerGameIDField,
+				entry.PlayerGameID)
+		}
+	}
+
+	_, err := s.infractionRepo.Create(&refractor.DBInfraction{
+		PlayerID:     player.PlayerID,
+		UserID:       s.systemUserID,
+		ServerID:     server.ServerID,
+		Type:         refractor.INFRACTION_TYPE_BAN,
+		Reason:       sql.NullString{String: entry.Reason, Valid: true},
+		Duration:     sql.NullInt32{Int32: int32(entry.Duration), Valid: true},
+		Timestamp:    entry.Timestamp,
+		SystemAction: true,
+	})
+
+	return nil, err
+}
+
+// checkExistingBans returns a conflict if the player already has an active ban or a ban identical to the entry
+func (s *banImportService) checkExistingBans(entry *refractor.BanImportEntry, player *refractor.Player) (*refractor.BanImportConflict, error) {
+	bans, err := s.infractionRepo.FindMany(refractor.FindArgs{
+		"PlayerID": player.PlayerID,
+		"Type":     refractor.INFRACTION_TYPE_BAN,
+	})
+	if err != nil && err != refractor.ErrNotFound {
+		return nil, err
+	}
+
+	for _, ban := range bans {
+		if ban.Timestamp == entry.Timestamp && ban.Duration == entry.Duration && ban.Reason == entry.Reason {
+			return newConflict(entry, player.PlayerID,
+				fmt.Sprintf("This ban has already been imported (infraction ID %d)", ban.InfractionID)), nil
+		}
+	}
+
+	if activeBan := refractor.GetLongestActive(bans); activeBan != nil {
+		return newConflict(entry, player.PlayerID,
+			fmt.Sprintf("Player already has an active ban (infraction ID %d)", activeBan.InfractionID)), nil
+	}
+
+	return nil, nil
+}
+
+func newConflict(entry *refractor.BanImportEntry, playerID int64, reason string) *refractor.BanImportConflict {
+	return &refractor.BanImportConflict{
+		Line:         entry.Line,
+		PlayerGameID: entry.PlayerGameID,
+		PlayerName:   entry.PlayerName,
+		PlayerID:     playerID,
+		Reason:       reason,
+	}
+}
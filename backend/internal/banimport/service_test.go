@@ -0,0 +1,205 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package banimport
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/internal/game"
+	"github.com/sniddunc/refractor/internal/game/mordhau"
+	"github.com/sniddunc/refractor/internal/mock"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/internal/player"
+	"github.com/sniddunc/refractor/internal/server"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_banImportService_ImportBans(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	banList := "1A2B3C4D5E6F7A8B, 0, Cheating\n" +
+		"AAAABBBBCCCCDDDD, 0, Spamming\n" +
+		"1A2B3C4D5E6F7A8B, 60, Cheating again\n" +
+		"This line is not a ban\n"
+
+	tests := []struct {
+		name            string
+		banList         string
+		body            params.ImportBansParams
+		wantRes         *refractor.ServiceResponse
+		wantImported    int
+		wantConflicts   []int // lines of the expected conflicts
+		wantPlaceholder bool
+	}{
+		{
+			name:    "banimport.importbans.1",
+			banList: banList,
+			body: params.ImportBansParams{
+				Format:   refractor.BAN_IMPORT_FORMAT_MORDHAU,
+				ServerID: 1,
+				DryRun:   true,
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Dry run complete. 1 of 4 bans would be imported with 3 conflicts",
+			},
+			wantImported:    1,
+			wantConflicts:   []int{2, 3, 4},
+			wantPlaceholder: false,
+		},
+		{
+			name:    "banimport.importbans.2",
+			banList: banList,
+			body: params.ImportBansParams{
+				Format:   refractor.BAN_IMPORT_FORMAT_MORDHAU,
+				ServerID: 1,
+				DryRun:   false,
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Imported 1 of 4 bans with 3 conflicts",
+			},
+			wantImported:    1,
+			wantConflicts:   []int{2, 3, 4},
+			wantPlaceholder: true,
+		},
+		{
+			name:    "banimport.importbans.3",
+			banList: banList,
+			body: params.ImportBansParams{
+				Format:   refractor.BAN_IMPORT_FORMAT_MINECRAFT,
+				ServerID: 1,
+				DryRun:   false,
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name:    "banimport.importbans.4",
+			banList: banList,
+			body: params.ImportBansParams{
+				Format:   refractor.BAN_IMPORT_FORMAT_MORDHAU,
+				ServerID: 2,
+				DryRun:   false,
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name:    "banimport.importbans.5",
+			banList: banList + strings.Repeat("#", int(config.BanImportMaxSize)),
+			body: params.ImportBansParams{
+				Format:   refractor.BAN_IMPORT_FORMAT_MORDHAU,
+				ServerID: 1,
+				DryRun:   false,
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPlayers := map[int64]*refractor.DBPlayer{
+				1: {
+					PlayerID:    1,
+					PlayFabID:   sql.NullString{String: "AAAABBBBCCCCDDDD", Valid: true},
+					CurrentName: "Spammer",
+				},
+			}
+			mockInfractions := map[int64]*refractor.DBInfraction{
+				1: {
+					InfractionID: 1,
+					PlayerID:     1,
+					UserID:       1,
+					ServerID:     1,
+					Type:         refractor.INFRACTION_TYPE_BAN,
+					Reason:       sql.NullString{String: "Spamming", Valid: true},
+					Duration:     sql.NullInt32{Int32: 0, Valid: true},
+					Timestamp:    time.Now().Unix(),
+				},
+			}
+
+			playerService := player.NewPlayerService(mock.NewMockPlayerRepository(mockPlayers), testLogger)
+			gameService := game.NewGameService()
+			gameService.AddGame(mordhau.NewMordhauGame())
+			serverService := server.NewServerService(mock.NewMockServerRepository(map[int64]*refractor.Server{
+				1: {
+					ServerID: 1,
+					Game:     "Mordhau",
+				},
+				2: {
+					ServerID: 2,
+					Game:     "Minecraft",
+				},
+			}), gameService, testLogger)
+			importService := NewBanImportService(mock.NewMockInfractionRepository(mockInfractions), playerService,
+				serverService, gameService, 99, testLogger)
+
+			result, res := importService.ImportBans(strings.NewReader(tt.banList), tt.body)
+
+			assert.True(t, tt.wantRes.Equals(res), "tt.wantRes = %v and res = %v should be equal", tt.wantRes, res)
+
+			if !tt.wantRes.Success {
+				assert.Nil(t, result, "Result should be nil")
+				assert.Equal(t, 1, len(mockInfractions), "No infractions should have been imported")
+				return
+			}
+
+			assert.Equal(t, tt.wantImported, result.Imported, "Imported counts should be equal")
+
+			var conflictLines []int
+			for _, conflict := range result.Conflicts {
+				conflictLines = append(conflictLines, conflict.Line)
+			}
+
+			assert.Equal(t, tt.wantConflicts, conflictLines, "Conflict lines should be equal")
+
+			if !tt.wantPlaceholder {
+				assert.Equal(t, 1, len(mockPlayers), "No placeholder players should have been created")
+				assert.Equal(t, 1, len(mockInfractions), "No infractions should have been imported")
+				return
+			}
+
+			placeholder := mockPlayers[2]
+			assert.NotNil(t, placeholder, "A placeholder player should have been created")
+			assert.Equal(t, "1A2B3C4D5E6F7A8B", placeholder.PlayFabID.String, "Placeholder game IDs should be equal")
+			assert.True(t, placeholder.Placeholder, "Player should be a placeholder")
+
+			imported := mockInfractions[2]
+			assert.NotNil(t, imported, "The ban should have been imported")
+			assert.Equal(t, int64(2), imported.PlayerID, "The ban should belong to the placeholder player")
+			assert.Equal(t, int64(99), imported.UserID, "The ban should be attributed to the system user")
+			assert.Equal(t, refractor.INFRACTION_TYPE_BAN, imported.Type, "Infraction should be a ban")
+			assert.True(t, imported.SystemAction, "The ban should be a system action")
+		})
+	}
+}
@@ -19,21 +19,41 @@ package chat
 
 import (
 	"fmt"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/config"
 	"github.com/sniddunc/refractor/pkg/log"
 	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"net/url"
+	"time"
 )
 
 type chatService struct {
-	log              log.Logger
-	websocketService refractor.WebsocketService
-	rconService      refractor.RCONService
+	repo              refractor.ChatRepository
+	snapshotRepo      refractor.ChatSnapshotRepository
+	log               log.Logger
+	websocketService  refractor.WebsocketService
+	rconService       refractor.RCONService
+	playerService     refractor.PlayerService
+	serverService     refractor.ServerService
+	userService       refractor.UserService
+	infractionService refractor.InfractionService
 }
 
-func NewChatService(websocketService refractor.WebsocketService, rconService refractor.RCONService, log log.Logger) refractor.ChatService {
+func NewChatService(repo refractor.ChatRepository, snapshotRepo refractor.ChatSnapshotRepository,
+	websocketService refractor.WebsocketService, rconService refractor.RCONService,
+	playerService refractor.PlayerService, serverService refractor.ServerService, userService refractor.UserService,
+	infractionService refractor.InfractionService, log log.Logger) refractor.ChatService {
 	return &chatService{
-		websocketService: websocketService,
-		rconService:      rconService,
-		log:              log,
+		repo:              repo,
+		snapshotRepo:      snapshotRepo,
+		websocketService:  websocketService,
+		rconService:       rconService,
+		playerService:     playerService,
+		serverService:     serverService,
+		userService:       userService,
+		infractionService: infractionService,
+		log:               log,
 	}
 }
 
@@ -42,6 +62,28 @@ func (s *chatService) OnChatReceive(message *refractor.ChatReceiveBody, serverID
 		Type: "chat",
 		Body: message,
 	})
+
+	newMessage := &refractor.ChatMessage{
+		ServerID:  serverID,
+		Name:      message.Name,
+		Channel:   message.Channel,
+		Message:   message.Message,
+		Timestamp: time.Now().Unix(),
+	}
+
+	// Link the message to the player who sent it. If they can't be found the message is still stored by name.
+	player, _ := s.playerService.GetPlayer(refractor.FindArgs{
+		gameConfig.PlayerGameIDField: message.PlayerGameID,
+	})
+
+	if player != nil {
+		newMessage.PlayerID = player.PlayerID
+	} else {
+		s.log.Warn("Could not find player with %s of %s to link their chat message to", gameConfig.PlayerGameIDField,
+			message.PlayerGameID)
+	}
+
+	s.storeMessage(newMessage)
 }
 
 func (s *chatService) OnUserSendChat(msgBody *refractor.ChatSendBody) {
@@ -61,4 +103,166 @@ func (s *chatService) OnUserSendChat(msgBody *refractor.ChatSendBody) {
 			SentByUser:   msgBody.SentByUser,
 		},
 	})
+
+	s.storeMessage(&refractor.ChatMessage{
+		ServerID:   msgBody.ServerID,
+		UserID:     msgBody.UserID,
+		Name:       msgBody.Sender,
+		Message:    msgBody.Message,
+		Timestamp:  time.Now().Unix(),
+		SentByUser: true,
+	})
+}
+
+// OnUserWhisper sends a private message a user sent over the websocket. Since websocket messages have no response,
+// failures are only logged.
+func (s *chatService) OnUserWhisper(msgBody *refractor.WhisperSendBody) {
+	body := params.WhisperPlayerParams{
+		ServerID: msgBody.ServerID,
+		Message:  msgBody.Message,
+		UserMeta: &params.UserMeta{
+			UserID: msgBody.UserID,
+		},
+	}
+
+	if ok, errors := body.Validate(); !ok {
+		s.log.Warn("User ID %d sent an invalid whisper. Errors: %v", msgBody.UserID, errors)
+		return
+	}
+
+	if res := s.WhisperPlayer(msgBody.PlayerID, body); !res.Success {
+		s.log.Warn("Could not send whisper from user ID %d to player ID %d. Message: %s", msgBody.UserID,
+			msgBody.PlayerID, res.Message)
+	}
+}
+
+// WhisperPlayer sends a private message from a user to a player who is online on the given server
+func (s *chatService) WhisperPlayer(playerID int64, body params.WhisperPlayerParams) *refractor.ServiceResponse {
+	serverData, _ := s.serverService.GetServerData(body.ServerID)
+	if serverData == nil {
+		errors := url.Values{}
+		errors.Set("serverId", "Server does not exist")
+
+		return &refractor.ServiceResponse{
+			Success:          false,
+			StatusCode:       http.StatusBadRequest,
+			ValidationErrors: errors,
+		}
+	}
+
+	// Online players are keyed by their game ID which is what the game's private message command expects
+	var playerGameID string
+	for gameID, player := range serverData.OnlinePlayers {
+		if player.PlayerID == playerID {
+			playerGameID = gameID
+			break
+		}
+	}
+
+	if playerGameID == "" {
+		return &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    "That player is not online on this server",
+		}
+	}
+
+	user, res := s.userService.GetUserByID(body.UserMeta.UserID)
+	if user == nil {
+		return res
+	}
+
+	message := fmt.Sprintf("[%s]: %s", user.Username, body.Message)
+
+	if err := s.rconService.SendPrivateMessage(body.ServerID, playerGameID, message); err != nil {
+		switch err {
+		case refractor.ErrUnsupportedCommand:
+			return &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    "This server's game does not support private messages",
+			}
+		case refractor.ErrNoRCONClient:
+			return &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    "The server is not connected",
+			}
+		}
+
+		s.log.Error("Could not send whisper to player ID %d on server ID %d. Error: %v", playerID, body.ServerID, err)
+		return refractor.InternalErrorResponse
+	}
+
+	return &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Message sent",
+	}
+}
+
+func (s *chatService) storeMessage(message *refractor.ChatMessage) {
+	if err := s.repo.Create(message); err != nil {
+		s.log.Error("Could not store chat message on server ID %d. Error: %v", message.ServerID, err)
+	}
+}
+
+// OnInfractionCreate takes a snapshot of the chat which led to a warning or mute created by a staff member. The
+// snapshot holds the player's last messages on the infraction's server and is stored with the infraction so reviewers
+// can later see what prompted it. Only messages sent within config.ChatSnapshotMaxAge of the infraction are included.
+func (s *chatService) OnInfractionCreate(infraction *refractor.Infraction) {
+	if infraction.SystemAction {
+		return
+	}
+
+	if infraction.Type != refractor.INFRACTION_TYPE_WARNING && infraction.Type != refractor.INFRACTION_TYPE_MUTE {
+		return
+	}
+
+	_, messages, err := s.repo.Search(refractor.FindArgs{
+		"PlayerID":  infraction.PlayerID,
+		"ServerID":  infraction.ServerID,
+		"StartDate": infraction.Timestamp - int64(config.ChatSnapshotMaxAge.Seconds()),
+		"EndDate":   infraction.Timestamp,
+	}, config.ChatSnapshotSize, 0)
+	if err != nil && err != refractor.ErrNotFound {
+		s.log.Error("Could not get chat messages for the snapshot of infraction ID %d. Error: %v",
+			infraction.InfractionID, err)
+		return
+	}
+
+	if len(messages) < 1 {
+		return
+	}
+
+	// Messages are searched newest first but snapshots are stored in the order they were sent
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+
+	if err := s.snapshotRepo.Create(infraction.InfractionID, messages); err != nil {
+		s.log.Error("Could not store chat snapshot of infraction ID %d. Error: %v", infraction.InfractionID, err)
+	}
+}
+
+func (s *chatService) GetInfractionChatSnapshot(infractionID int64) ([]*refractor.ChatMessage, *refractor.ServiceResponse) {
+	if _, res := s.infractionService.GetInfractionByID(infractionID); !res.Success {
+		return nil, res
+	}
+
+	messages, err := s.snapshotRepo.FindByInfractionID(infractionID)
+	if err != nil && err != refractor.ErrNotFound {
+		s.log.Error("Could not get chat snapshot of infraction ID %d. Error: %v", infractionID, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	if messages == nil {
+		messages = []*refractor.ChatMessage{}
+	}
+
+	return messages, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    fmt.Sprintf("Fetched %d chat messages", len(messages)),
+	}
 }
@@ -0,0 +1,240 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package chat
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/internal/game"
+	"github.com/sniddunc/refractor/internal/infraction"
+	"github.com/sniddunc/refractor/internal/mock"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/internal/player"
+	"github.com/sniddunc/refractor/internal/server"
+	"github.com/sniddunc/refractor/internal/user"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func Test_chatService_OnInfractionCreate(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+	now := time.Now().Unix()
+
+	message := func(id int64, playerID int64, serverID int64, age int64) *refractor.ChatMessage {
+		return &refractor.ChatMessage{
+			MessageID: id,
+			ServerID:  serverID,
+			PlayerID:  playerID,
+			Name:      "Player",
+			Message:   "Test message",
+			Timestamp: now - age,
+		}
+	}
+
+	tests := []struct {
+		name            string
+		mockMessages    map[int64]*refractor.ChatMessage
+		create          func(infractionService refractor.InfractionService) (*refractor.Infraction, *refractor.ServiceResponse)
+		wantSnapshotIDs []int64
+	}{
+		{
+			name: "chat.oninfractioncreate.1",
+			mockMessages: map[int64]*refractor.ChatMessage{
+				1: message(1, 1, 1, 120),
+				2: message(2, 2, 1, 90),
+				3: message(3, 1, 2, 60),
+				4: message(4, 1, 1, 30),
+				5: message(5, 1, 1, 60*60*24),
+			},
+			create: func(infractionService refractor.InfractionService) (*refractor.Infraction, *refractor.ServiceResponse) {
+				return infractionService.CreateWarning(1, params.CreateWarningParams{
+					PlayerID: 1,
+					ServerID: 1,
+					Reason:   "Test reason",
+				})
+			},
+			wantSnapshotIDs: []int64{1, 4},
+		},
+		{
+			name: "chat.oninfractioncreate.2",
+			mockMessages: map[int64]*refractor.ChatMessage{
+				1: message(1, 1, 1, 30),
+			},
+			create: func(infractionService refractor.InfractionService) (*refractor.Infraction, *refractor.ServiceResponse) {
+				return infractionService.CreateKick(1, params.CreateKickParams{
+					PlayerID: 1,
+					ServerID: 1,
+					Reason:   "Test reason",
+				})
+			},
+			wantSnapshotIDs: nil,
+		},
+		{
+			name: "chat.oninfractioncreate.3",
+			mockMessages: map[int64]*refractor.ChatMessage{
+				1: message(1, 1, 1, 30),
+			},
+			create: func(infractionService refractor.InfractionService) (*refractor.Infraction, *refractor.ServiceResponse) {
+				return infractionService.CreateSystemInfraction(&refractor.DBInfraction{
+					PlayerID: 1,
+					UserID:   1,
+					ServerID: 1,
+					Type:     refractor.INFRACTION_TYPE_MUTE,
+					Reason:   sql.NullString{String: "Test reason", Valid: true},
+					Duration: sql.NullInt32{Int32: 10, Valid: true},
+				}, false)
+			},
+			wantSnapshotIDs: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPlayerRepo := mock.NewMockPlayerRepository(map[int64]*refractor.DBPlayer{
+				1: {
+					PlayerID:  1,
+					PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+				},
+			})
+			playerService := player.NewPlayerService(mockPlayerRepo, testLogger)
+			mockServerRepo := mock.NewMockServerRepository(map[int64]*refractor.Server{
+				1: {
+					ServerID: 1,
+					Game:     "TestGame",
+				},
+			})
+			serverService := server.NewServerService(mockServerRepo, nil, testLogger)
+			userService := user.NewUserService(mock.NewMockUserRepository(mock.GetMockUsers()), testLogger)
+			gameService := game.NewGameService()
+			gameService.AddGame(mock.NewMockGame())
+			rconService := mock.NewMockRCONService(1)
+			infractionService := infraction.NewInfractionService(
+				mock.NewMockInfractionRepository(map[int64]*refractor.DBInfraction{}), playerService, serverService,
+				userService, rconService, gameService, testLogger)
+			snapshotRepo := mock.NewMockChatSnapshotRepository(map[int64][]*refractor.ChatMessage{})
+			chatService := NewChatService(mock.NewMockChatRepository(tt.mockMessages), snapshotRepo,
+				mock.NewMockWebsocketService(), rconService, playerService, serverService, userService, infractionService,
+				testLogger)
+			infractionService.SubscribeCreate(chatService.OnInfractionCreate)
+
+			created, res := tt.create(infractionService)
+			assert.True(t, res.Success, "Infraction should have been created. Message: %s", res.Message)
+
+			var snapshotIDs []int64
+			for _, message := range snapshotRepo.Snapshots[created.InfractionID] {
+				snapshotIDs = append(snapshotIDs, message.MessageID)
+			}
+
+			assert.Equal(t, tt.wantSnapshotIDs, snapshotIDs, "Snapshot messages should match")
+
+			snapshot, res := chatService.GetInfractionChatSnapshot(created.InfractionID)
+			assert.True(t, res.Success, "Snapshot should have been fetched")
+			assert.Equal(t, len(tt.wantSnapshotIDs), len(snapshot), "Fetched snapshot length should match")
+		})
+	}
+}
+
+func Test_chatService_WhisperPlayer(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	tests := []struct {
+		name         string
+		playerID     int64
+		serverID     int64
+		unsupported  bool
+		wantStatus   int
+		wantCommands map[int64][]string
+	}{
+		{
+			name:         "chat.whisperplayer.1",
+			playerID:     1,
+			serverID:     1,
+			wantStatus:   http.StatusOK,
+			wantCommands: map[int64][]string{1: {"whisper ABCDEF [tester]: Please stop spawn camping"}},
+		},
+		{
+			name:         "chat.whisperplayer.2",
+			playerID:     2,
+			serverID:     1,
+			wantStatus:   http.StatusBadRequest,
+			wantCommands: map[int64][]string{},
+		},
+		{
+			name:         "chat.whisperplayer.3",
+			playerID:     1,
+			serverID:     1,
+			unsupported:  true,
+			wantStatus:   http.StatusBadRequest,
+			wantCommands: map[int64][]string{},
+		},
+		{
+			name:         "chat.whisperplayer.4",
+			playerID:     1,
+			serverID:     2,
+			wantStatus:   http.StatusBadRequest,
+			wantCommands: map[int64][]string{},
+		},
+		{
+			name:         "chat.whisperplayer.5",
+			playerID:     1,
+			serverID:     5,
+			wantStatus:   http.StatusBadRequest,
+			wantCommands: map[int64][]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			playerService := player.NewPlayerService(mock.NewMockPlayerRepository(map[int64]*refractor.DBPlayer{
+				1: {PlayerID: 1, PlayFabID: sql.NullString{String: "ABCDEF", Valid: true}},
+				2: {PlayerID: 2, PlayFabID: sql.NullString{String: "GHIJKL", Valid: true}},
+			}), testLogger)
+			gameService := game.NewGameService()
+			gameService.AddGame(mock.NewMockGame())
+			serverService := server.NewServerService(mock.NewMockServerRepository(map[int64]*refractor.Server{
+				1: {ServerID: 1, Game: "TestGame"},
+				2: {ServerID: 2, Game: "TestGame"},
+			}), gameService, testLogger)
+			userService := user.NewUserService(mock.NewMockUserRepository(mock.GetMockUsers()), testLogger)
+
+			// Player 1 is online on both servers but only server 1 has a connected RCON client
+			onlinePlayer := &refractor.Player{PlayerID: 1, PlayFabID: "ABCDEF"}
+			for _, serverID := range []int64{1, 2} {
+				serverService.CreateServerData(serverID, "TestGame")
+				serverService.OnPlayerJoin(serverID, onlinePlayer)
+			}
+
+			rconService := mock.NewMockRCONService(1)
+			rconService.UnsupportedPrivateMessages = tt.unsupported
+
+			chatService := NewChatService(mock.NewMockChatRepository(map[int64]*refractor.ChatMessage{}),
+				mock.NewMockChatSnapshotRepository(map[int64][]*refractor.ChatMessage{}), mock.NewMockWebsocketService(),
+				rconService, playerService, serverService, userService, nil, testLogger)
+
+			res := chatService.WhisperPlayer(tt.playerID, params.WhisperPlayerParams{
+				ServerID: tt.serverID,
+				Message:  "Please stop spawn camping",
+				UserMeta: &params.UserMeta{UserID: 1},
+			})
+
+			assert.Equal(t, tt.wantStatus, res.StatusCode, "Status codes should match. Message: %s", res.Message)
+			assert.Equal(t, tt.wantCommands, rconService.Commands, "Sent commands should match")
+		})
+	}
+}
@@ -0,0 +1,151 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package chatfilter
+
+import (
+	"github.com/sniddunc/refractor/refractor"
+	"regexp"
+	"strings"
+)
+
+// compiledRule holds a chat filter rule along with its compiled pattern
+type compiledRule struct {
+	rule    *refractor.ChatFilterRule
+	pattern *regexp.Regexp
+}
+
+// actionSeverity ranks filter actions so that the most severe action can be applied when multiple rules match
+var actionSeverity = map[string]int{
+	refractor.FILTER_ACTION_ALERT:     0,
+	refractor.INFRACTION_TYPE_WARNING: 1,
+	refractor.INFRACTION_TYPE_MUTE:    2,
+	refractor.INFRACTION_TYPE_KICK:    3,
+}
+
+// leetspeakReplacer undoes common character substitutions. It should be run on lowercase text.
+var leetspeakReplacer = strings.NewReplacer(
+	"0", "o",
+	"1", "i",
+	"3", "e",
+	"4", "a",
+	"5", "s",
+	"7", "t",
+	"8", "b",
+	"@", "a",
+	"$", "s",
+	"|", "l",
+	"+", "t",
+)
+
+// normaliseLeetspeak returns a lowercase copy of message with common leetspeak substitutions undone.
+func normaliseLeetspeak(message string) string {
+	return leetspeakReplacer.Replace(strings.ToLower(message))
+}
+
+// compileRule compiles a rule's pattern. WORD patterns only match whole words or phrases so that a filtered word
+// inside an unrelated word doesn't trigger the rule. All patterns are matched case insensitively.
+func compileRule(rule *refractor.ChatFilterRule) (*compiledRule, error) {
+	var expr string
+
+	switch rule.MatchType {
+	case refractor.FILTER_MATCH_REGEX:
+		expr = "(?i)" + rule.Pattern
+	default:
+		expr = `(?i)(?:^|\W)` + regexp.QuoteMeta(rule.Pattern) + `(?:\W|$)`
+	}
+
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &compiledRule{
+		rule:    rule,
+		pattern: pattern,
+	}, nil
+}
+
+// matches checks if the rule matches a message. Leetspeak rules also check the message once normalised.
+func (r *compiledRule) matches(message string, normalised string) bool {
+	if r.pattern.MatchString(message) {
+		return true
+	}
+
+	return r.rule.Leetspeak && r.pattern.MatchString(normalised)
+}
+
+// loadRules compiles all enabled rules and replaces the cached rules with them. Rules which fail to compile are
+// skipped and logged.
+func (s *chatFilterService) loadRules() {
+	rules, err := s.repo.FindAll()
+	if err != nil && err != refractor.ErrNotFound {
+		s.log.Error("Could not load chat filter rules. Error: %v", err)
+		return
+	}
+
+	var compiled []*compiledRule
+
+	for _, rule := range rules {
+		if !rule.Enabled {
+			continue
+		}
+
+		matcher, err := compileRule(rule)
+		if err != nil {
+			s.log.Error("Could not compile chat filter rule ID %d. Error: %v", rule.RuleID, err)
+			continue
+		}
+
+		compiled = append(compiled, matcher)
+	}
+
+	s.rulesLock.Lock()
+	s.rules = compiled
+	s.rulesLoaded = true
+	s.rulesLock.Unlock()
+}
+
+// matchMessage returns the matching rule with the most severe action, or nil if no rules match.
+func (s *chatFilterService) matchMessage(message string) *refractor.ChatFilterRule {
+	s.rulesLock.RLock()
+	loaded := s.rulesLoaded
+	s.rulesLock.RUnlock()
+
+	if !loaded {
+		s.loadRules()
+	}
+
+	s.rulesLock.RLock()
+	defer s.rulesLock.RUnlock()
+
+	normalised := normaliseLeetspeak(message)
+
+	var matched *refractor.ChatFilterRule
+
+	for _, rule := range s.rules {
+		if !rule.matches(message, normalised) {
+			continue
+		}
+
+		if matched == nil || actionSeverity[rule.rule.ActionType] > actionSeverity[matched.ActionType] {
+			matched = rule.rule
+		}
+	}
+
+	return matched
+}
@@ -0,0 +1,299 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package chatfilter
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"sync"
+)
+
+type chatFilterService struct {
+	repo              refractor.ChatFilterRuleRepository
+	infractionService refractor.InfractionService
+	playerService     refractor.PlayerService
+	websocketService  refractor.WebsocketService
+	systemUserID      int64
+	log               log.Logger
+
+	// Rules are compiled once and cached since every chat message is checked against them. The cache is reloaded
+	// whenever a rule is changed.
+	rules       []*compiledRule
+	rulesLoaded bool
+	rulesLock   sync.RWMutex
+}
+
+// NewChatFilterService creates a new chat filter service. Infractions created by chat filter rules are attributed to
+// the user with the ID systemUserID.
+func NewChatFilterService(repo refractor.ChatFilterRuleRepository, infractionService refractor.InfractionService,
+	playerService refractor.PlayerService, websocketService refractor.WebsocketService, systemUserID int64,
+	log log.Logger) refractor.ChatFilterService {
+	return &chatFilterService{
+		repo:              repo,
+		infractionService: infractionService,
+		playerService:     playerService,
+		websocketService:  websocketService,
+		systemUserID:      systemUserID,
+		log:               log,
+	}
+}
+
+func (s *chatFilterService) CreateRule(body params.CreateChatFilterRuleParams) (*refractor.ChatFilterRule, *refractor.ServiceResponse) {
+	newRule := &refractor.ChatFilterRule{
+		Pattern:        body.Pattern,
+		MatchType:      body.MatchType,
+		Leetspeak:      body.Leetspeak,
+		ActionType:     body.ActionType,
+		ActionDuration: body.ActionDuration,
+		ActionReason:   body.ActionReason,
+		Enabled:        true,
+	}
+
+	if err := s.repo.Create(newRule); err != nil {
+		s.log.Error("Could not insert new chat filter rule into repository. Error: %v", err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	s.loadRules()
+
+	return newRule, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Chat filter rule created",
+	}
+}
+
+func (s *chatFilterService) GetAllRules() ([]*refractor.ChatFilterRule, *refractor.ServiceResponse) {
+	rules, err := s.repo.FindAll()
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return []*refractor.ChatFilterRule{}, &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Fetched 0 chat filter rules",
+			}
+		}
+
+		s.log.Error("Could not FindAll chat filter rules from repository. Error: %v", err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	if rules == nil {
+		rules = []*refractor.ChatFilterRule{}
+	}
+
+	return rules, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    fmt.Sprintf("Fetched %d chat filter rules", len(rules)),
+	}
+}
+
+func (s *chatFilterService) UpdateRule(id int64, body params.UpdateChatFilterRuleParams) (*refractor.ChatFilterRule, *refractor.ServiceResponse) {
+	rule, err := s.repo.FindByID(id)
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return nil, &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			}
+		}
+
+		s.log.Error("Could not get chat filter rule of ID %d from repo. Error: %v", id, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	// Work out what the rule will look like once updated so it can be checked as a whole
+	updated := *rule
+	updateArgs := refractor.UpdateArgs{}
+
+	if body.Pattern != nil {
+		updateArgs["Pattern"] = *body.Pattern
+		updated.Pattern = *body.Pattern
+	}
+
+	if body.MatchType != nil {
+		updateArgs["MatchType"] = *body.MatchType
+		updated.MatchType = *body.MatchType
+	}
+
+	if body.Leetspeak != nil {
+		updateArgs["Leetspeak"] = *body.Leetspeak
+	}
+
+	if body.ActionType != nil {
+		updateArgs["ActionType"] = *body.ActionType
+		updated.ActionType = *body.ActionType
+	}
+
+	if body.ActionDuration != nil {
+		updateArgs["ActionDuration"] = *body.ActionDuration
+		updated.ActionDuration = *body.ActionDuration
+	}
+
+	if body.ActionReason != nil {
+		updateArgs["ActionReason"] = *body.ActionReason
+	}
+
+	if body.Enabled != nil {
+		updateArgs["Enabled"] = *body.Enabled
+	}
+
+	if len(updateArgs) < 1 {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    "No updated values provided",
+		}
+	}
+
+	if ok, errors := params.ValidateChatFilterRule(updated.Pattern, updated.MatchType, updated.ActionType,
+		updated.ActionDuration); !ok {
+		return nil, &refractor.ServiceResponse{
+			Success:          false,
+			StatusCode:       http.StatusBadRequest,
+			ValidationErrors: errors,
+		}
+	}
+
+	updatedRule, err := s.repo.Update(id, updateArgs)
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return nil, &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			}
+		}
+
+		s.log.Error("Could not update chat filter rule of ID %d in repo. Error: %v", id, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	s.loadRules()
+
+	return updatedRule, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Chat filter rule updated",
+	}
+}
+
+func (s *chatFilterService) DeleteRule(id int64) *refractor.ServiceResponse {
+	if err := s.repo.Delete(id); err != nil {
+		if err == refractor.ErrNotFound {
+			return &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			}
+		}
+
+		s.log.Error("Could not delete chat filter rule with ID %d. Error: %v", id, err)
+		return refractor.InternalErrorResponse
+	}
+
+	s.loadRules()
+
+	return &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Chat filter rule deleted",
+	}
+}
+
+// OnChatReceive checks a chat message sent by a player against all enabled chat filter rules. If more than one rule
+// matches, only the rule with the most severe action is applied so that a single message can't result in a warning,
+// mute and kick all at once.
+//
+// Staff are always alerted of a match over websocket. Rules with an action other than FILTER_ACTION_ALERT also create a
+// system infraction for the player which is enforced through RCON.
+func (s *chatFilterService) OnChatReceive(msgBody *refractor.ChatReceiveBody, serverID int64, gameConfig *refractor.GameConfig) {
+	if msgBody.SentByUser {
+		return
+	}
+
+	rule := s.matchMessage(msgBody.Message)
+	if rule == nil {
+		return
+	}
+
+	alert := &refractor.ChatFilterAlert{
+		RuleID:     rule.RuleID,
+		ServerID:   serverID,
+		Name:       msgBody.Name,
+		Message:    msgBody.Message,
+		ActionType: rule.ActionType,
+	}
+
+	player, _ := s.playerService.GetPlayer(refractor.FindArgs{
+		gameConfig.PlayerGameIDField: msgBody.PlayerGameID,
+	})
+
+	if player != nil {
+		alert.PlayerID = player.PlayerID
+	}
+
+	if rule.ActionType != refractor.FILTER_ACTION_ALERT {
+		if player != nil {
+			alert.InfractionID = s.createInfraction(rule, player.PlayerID, serverID)
+		} else {
+			s.log.Warn("Chat filter rule ID %d matched a message but the player with %s of %s could not be found",
+				rule.RuleID, gameConfig.PlayerGameIDField, msgBody.PlayerGameID)
+		}
+	}
+
+	s.websocketService.Broadcast(&refractor.WebsocketMessage{
+		Type: "chat-filter-alert",
+		Body: alert,
+	})
+}
+
+// createInfraction creates and enforces the infraction for a matched rule. It returns the ID of the new infraction or
+// 0 if it could not be created.
+func (s *chatFilterService) createInfraction(rule *refractor.ChatFilterRule, playerID int64, serverID int64) int64 {
+	newInfraction := &refractor.DBInfraction{
+		PlayerID: playerID,
+		UserID:   s.systemUserID,
+		ServerID: serverID,
+		Type:     rule.ActionType,
+		Reason:   sql.NullString{String: rule.ActionReason, Valid: true},
+	}
+
+	if rule.ActionType == refractor.INFRACTION_TYPE_MUTE {
+		newInfraction.Duration = sql.NullInt32{Int32: int32(rule.ActionDuration), Valid: true}
+	}
+
+	infraction, res := s.infractionService.CreateSystemInfraction(newInfraction, true)
+	if !res.Success {
+		s.log.Error("Chat filter rule ID %d could not create an infraction for player ID %d. Message: %s",
+			rule.RuleID, playerID, res.Message)
+		return 0
+	}
+
+	s.log.Info("Chat filter rule ID %d created infraction ID %d for player ID %d", rule.RuleID,
+		infraction.InfractionID, playerID)
+
+	return infraction.InfractionID
+}
@@ -0,0 +1,444 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package chatfilter
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/internal/game"
+	"github.com/sniddunc/refractor/internal/infraction"
+	"github.com/sniddunc/refractor/internal/mock"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/internal/player"
+	"github.com/sniddunc/refractor/internal/server"
+	"github.com/sniddunc/refractor/internal/user"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_chatFilterService_OnChatReceive(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	type fields struct {
+		mockRules   map[int64]*refractor.ChatFilterRule
+		mockPlayers map[int64]*refractor.DBPlayer
+		mockServers map[int64]*refractor.Server
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		message        *refractor.ChatReceiveBody
+		wantAlert      bool
+		wantInfraction string
+		wantCommand    bool
+	}{
+		{
+			name: "chatfilter.onchatreceive.1",
+			fields: fields{
+				mockRules: map[int64]*refractor.ChatFilterRule{
+					1: {
+						RuleID:       1,
+						Pattern:      "darn",
+						MatchType:    refractor.FILTER_MATCH_WORD,
+						Leetspeak:    true,
+						ActionType:   refractor.INFRACTION_TYPE_WARNING,
+						ActionReason: "Inappropriate language",
+						Enabled:      true,
+					},
+				},
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+				},
+			},
+			message: &refractor.ChatReceiveBody{
+				PlayerGameID: "ABCDEF",
+				Name:         "Player",
+				Message:      "well DARN it",
+			},
+			wantAlert:      true,
+			wantInfraction: refractor.INFRACTION_TYPE_WARNING,
+			wantCommand:    true,
+		},
+		{
+			name: "chatfilter.onchatreceive.2",
+			fields: fields{
+				mockRules: map[int64]*refractor.ChatFilterRule{
+					1: {
+						RuleID:       1,
+						Pattern:      "darn",
+						MatchType:    refractor.FILTER_MATCH_WORD,
+						Leetspeak:    true,
+						ActionType:   refractor.INFRACTION_TYPE_WARNING,
+						ActionReason: "Inappropriate language",
+						Enabled:      true,
+					},
+				},
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+				},
+			},
+			message: &refractor.ChatReceiveBody{
+				PlayerGameID: "ABCDEF",
+				Name:         "Player",
+				Message:      "d4rn!",
+			},
+			wantAlert:      true,
+			wantInfraction: refractor.INFRACTION_TYPE_WARNING,
+			wantCommand:    true,
+		},
+		{
+			name: "chatfilter.onchatreceive.3",
+			fields: fields{
+				mockRules: map[int64]*refractor.ChatFilterRule{
+					1: {
+						RuleID:       1,
+						Pattern:      "darn",
+						MatchType:    refractor.FILTER_MATCH_WORD,
+						Leetspeak:    true,
+						ActionType:   refractor.INFRACTION_TYPE_WARNING,
+						ActionReason: "Inappropriate language",
+						Enabled:      true,
+					},
+				},
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+				},
+			},
+			message: &refractor.ChatReceiveBody{
+				PlayerGameID: "ABCDEF",
+				Name:         "Player",
+				Message:      "darned and darnation should not match",
+			},
+			wantAlert: false,
+		},
+		{
+			name: "chatfilter.onchatreceive.4",
+			fields: fields{
+				mockRules: map[int64]*refractor.ChatFilterRule{
+					1: {
+						RuleID:       1,
+						Pattern:      "darn",
+						MatchType:    refractor.FILTER_MATCH_WORD,
+						Leetspeak:    true,
+						ActionType:   refractor.INFRACTION_TYPE_WARNING,
+						ActionReason: "Inappropriate language",
+						Enabled:      true,
+					},
+					2: {
+						RuleID:         2,
+						Pattern:        `free\s+(robux|vbucks)`,
+						MatchType:      refractor.FILTER_MATCH_REGEX,
+						ActionType:     refractor.INFRACTION_TYPE_MUTE,
+						ActionDuration: 30,
+						ActionReason:   "Scam message",
+						Enabled:        true,
+					},
+				},
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+				},
+			},
+			message: &refractor.ChatReceiveBody{
+				PlayerGameID: "ABCDEF",
+				Name:         "Player",
+				Message:      "darn, FREE   Robux at my site",
+			},
+			wantAlert:      true,
+			wantInfraction: refractor.INFRACTION_TYPE_MUTE,
+			wantCommand:    true,
+		},
+		{
+			name: "chatfilter.onchatreceive.5",
+			fields: fields{
+				mockRules: map[int64]*refractor.ChatFilterRule{
+					3: {
+						RuleID:       3,
+						Pattern:      "admin abuse",
+						MatchType:    refractor.FILTER_MATCH_WORD,
+						ActionType:   refractor.FILTER_ACTION_ALERT,
+						ActionReason: "Possible admin complaint",
+						Enabled:      true,
+					},
+				},
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+				},
+			},
+			message: &refractor.ChatReceiveBody{
+				PlayerGameID: "ABCDEF",
+				Name:         "Player",
+				Message:      "this is admin abuse",
+			},
+			wantAlert: true,
+		},
+		{
+			name: "chatfilter.onchatreceive.6",
+			fields: fields{
+				mockRules: map[int64]*refractor.ChatFilterRule{
+					4: {
+						RuleID:       4,
+						Pattern:      "heck",
+						MatchType:    refractor.FILTER_MATCH_WORD,
+						ActionType:   refractor.INFRACTION_TYPE_KICK,
+						ActionReason: "Disabled rule",
+						Enabled:      false,
+					},
+				},
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+				},
+			},
+			message: &refractor.ChatReceiveBody{
+				PlayerGameID: "ABCDEF",
+				Name:         "Player",
+				Message:      "what the heck",
+			},
+			wantAlert: false,
+		},
+		{
+			name: "chatfilter.onchatreceive.7",
+			fields: fields{
+				mockRules: map[int64]*refractor.ChatFilterRule{
+					1: {
+						RuleID:       1,
+						Pattern:      "darn",
+						MatchType:    refractor.FILTER_MATCH_WORD,
+						Leetspeak:    true,
+						ActionType:   refractor.INFRACTION_TYPE_WARNING,
+						ActionReason: "Inappropriate language",
+						Enabled:      true,
+					},
+				},
+				mockPlayers: map[int64]*refractor.DBPlayer{},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+				},
+			},
+			message: &refractor.ChatReceiveBody{
+				PlayerGameID: "UNKNOWN",
+				Name:         "Stranger",
+				Message:      "darn",
+			},
+			wantAlert: true,
+		},
+		{
+			name: "chatfilter.onchatreceive.8",
+			fields: fields{
+				mockRules: map[int64]*refractor.ChatFilterRule{
+					1: {
+						RuleID:       1,
+						Pattern:      "darn",
+						MatchType:    refractor.FILTER_MATCH_WORD,
+						Leetspeak:    true,
+						ActionType:   refractor.INFRACTION_TYPE_WARNING,
+						ActionReason: "Inappropriate language",
+						Enabled:      true,
+					},
+				},
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+				},
+			},
+			message: &refractor.ChatReceiveBody{
+				Name:       "Staff",
+				Message:    "darn",
+				SentByUser: true,
+			},
+			wantAlert: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPlayerRepo := mock.NewMockPlayerRepository(tt.fields.mockPlayers)
+			playerService := player.NewPlayerService(mockPlayerRepo, testLogger)
+			mockServerRepo := mock.NewMockServerRepository(tt.fields.mockServers)
+			serverService := server.NewServerService(mockServerRepo, nil, testLogger)
+			userService := user.NewUserService(mock.NewMockUserRepository(mock.GetMockUsers()), testLogger)
+			gameService := game.NewGameService()
+			gameService.AddGame(mock.NewMockGame())
+			rconService := mock.NewMockRCONService(1)
+			mockInfractions := map[int64]*refractor.DBInfraction{}
+			mockInfractionRepo := mock.NewMockInfractionRepository(mockInfractions)
+			infractionService := infraction.NewInfractionService(mockInfractionRepo, playerService, serverService,
+				userService, rconService, gameService, testLogger)
+			websocketService := mock.NewMockWebsocketService()
+			mockRuleRepo := mock.NewMockChatFilterRuleRepository(tt.fields.mockRules)
+			chatFilterService := NewChatFilterService(mockRuleRepo, infractionService, playerService, websocketService,
+				1, testLogger)
+
+			chatFilterService.OnChatReceive(tt.message, 1, mock.NewMockGame().GetConfig())
+
+			if !tt.wantAlert {
+				assert.Empty(t, websocketService.Messages, "No alert should have been broadcast")
+				assert.Empty(t, mockInfractions, "No infraction should have been created")
+				return
+			}
+
+			assert.Equal(t, 1, len(websocketService.Messages), "One alert should have been broadcast")
+			assert.Equal(t, "chat-filter-alert", websocketService.Messages[0].Type, "Alert type should match")
+
+			if tt.wantInfraction == "" {
+				assert.Empty(t, mockInfractions, "No infraction should have been created")
+				return
+			}
+
+			assert.Equal(t, 1, len(mockInfractions), "One infraction should have been created")
+			created := mockInfractions[1]
+			assert.Equal(t, tt.wantInfraction, created.Type, "Infraction type should match the rule's action")
+			assert.True(t, created.SystemAction, "Infraction should be a system action")
+
+			alert := websocketService.Messages[0].Body.(*refractor.ChatFilterAlert)
+			assert.Equal(t, created.InfractionID, alert.InfractionID, "Alert should reference the created infraction")
+
+			if tt.wantCommand {
+				assert.Equal(t, 1, len(rconService.Commands[1]), "Infraction should have been enforced")
+			}
+		})
+	}
+}
+
+func Test_chatFilterService_UpdateRule(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+	stringPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name        string
+		id          int64
+		body        params.UpdateChatFilterRuleParams
+		wantSuccess bool
+	}{
+		{
+			name: "chatfilter.updaterule.1",
+			id:   1,
+			body: params.UpdateChatFilterRuleParams{
+				Pattern: stringPtr("gosh"),
+			},
+			wantSuccess: true,
+		},
+		{
+			name: "chatfilter.updaterule.2",
+			id:   1,
+			body: params.UpdateChatFilterRuleParams{
+				Pattern:   stringPtr("gosh(darn"),
+				MatchType: stringPtr(refractor.FILTER_MATCH_REGEX),
+			},
+			wantSuccess: false,
+		},
+		{
+			name: "chatfilter.updaterule.3",
+			id:   1,
+			body: params.UpdateChatFilterRuleParams{
+				ActionType: stringPtr(refractor.INFRACTION_TYPE_MUTE),
+			},
+			wantSuccess: false,
+		},
+		{
+			name: "chatfilter.updaterule.4",
+			id:   2,
+			body: params.UpdateChatFilterRuleParams{
+				Pattern: stringPtr("gosh"),
+			},
+			wantSuccess: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRuleRepo := mock.NewMockChatFilterRuleRepository(map[int64]*refractor.ChatFilterRule{
+				1: {
+					RuleID:       1,
+					Pattern:      "darn",
+					MatchType:    refractor.FILTER_MATCH_WORD,
+					ActionType:   refractor.INFRACTION_TYPE_WARNING,
+					ActionReason: "Inappropriate language",
+					Enabled:      true,
+				},
+			})
+			chatFilterService := NewChatFilterService(mockRuleRepo, nil, nil, nil, 1, testLogger)
+
+			_, res := chatFilterService.UpdateRule(tt.id, tt.body)
+			assert.Equal(t, tt.wantSuccess, res.Success, "Update success should match. Message: %s", res.Message)
+		})
+	}
+}
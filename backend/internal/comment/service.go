@@ -0,0 +1,269 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package comment
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type commentService struct {
+	repo              refractor.CommentRepository
+	infractionService refractor.InfractionService
+	userService       refractor.UserService
+	websocketService  refractor.WebsocketService
+	log               log.Logger
+}
+
+func NewCommentService(repo refractor.CommentRepository, infractionService refractor.InfractionService,
+	userService refractor.UserService, websocketService refractor.WebsocketService, log log.Logger) refractor.CommentService {
+	return &commentService{
+		repo:              repo,
+		infractionService: infractionService,
+		userService:       userService,
+		websocketService:  websocketService,
+		log:               log,
+	}
+}
+
+func (s *commentService) CreateComment(infractionID int64, body params.CreateCommentParams) (*refractor.Comment, *refractor.ServiceResponse) {
+	// Deleted infractions are rejected here too, so they can't be commented on
+	if _, res := s.infractionService.GetInfractionByID(infractionID); !res.Success {
+		return nil, res
+	}
+
+	// If this comment is a reply, make sure the comment being replied to belongs to the same infraction
+	if body.ParentID > 0 {
+		if _, res := s.getComment(infractionID, body.ParentID); !res.Success {
+			return nil, &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				ValidationErrors: url.Values{
+					"parentId": []string{config.MessageInvalidIDProvided},
+				},
+			}
+		}
+	}
+
+	newComment := &refractor.Comment{
+		InfractionID: infractionID,
+		ParentID:     body.ParentID,
+		UserID:       body.UserMeta.UserID,
+		Body:         body.Body,
+		Timestamp:    time.Now().Unix(),
+		Replies:      []*refractor.Comment{},
+	}
+
+	if err := s.repo.Create(newComment); err != nil {
+		s.log.Error("Could not insert new comment into repository. Error: %v", err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	s.setAuthorName(newComment)
+
+	// Push the new comment to connected dashboards
+	s.websocketService.Broadcast(&refractor.WebsocketMessage{
+		Type: "infraction-comment",
+		Body: newComment,
+	})
+
+	return newComment, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Comment created",
+	}
+}
+
+// GetInfractionComments gets the comments on an infraction as threads. Top level comments are returned oldest first,
+// with replies nested under the comment they reply to.
+func (s *commentService) GetInfractionComments(infractionID int64) ([]*refractor.Comment, *refractor.ServiceResponse) {
+	// Make sure infraction exists
+	if _, res := s.infractionService.GetInfractionByID(infractionID); !res.Success {
+		return nil, res
+	}
+
+	comments, err := s.repo.FindManyByInfractionID(infractionID)
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return []*refractor.Comment{}, &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Fetched 0 comments",
+			}
+		}
+
+		s.log.Error("Could not get comments of infraction ID %d. Error: %v", infractionID, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	// Get author names. Users usually leave several comments on the same infraction, so names are cached.
+	authorNames := map[int64]string{}
+	for _, comment := range comments {
+		name, ok := authorNames[comment.UserID]
+		if !ok {
+			user, err := s.userService.GetUserByID(comment.UserID)
+			if err != nil {
+				s.log.Error("Could not get comment author by ID. Error: %v", err)
+				continue
+			}
+
+			name = user.Username
+			authorNames[comment.UserID] = name
+		}
+
+		comment.AuthorName = name
+	}
+
+	threads := buildThreads(comments)
+
+	return threads, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    fmt.Sprintf("Fetched %d comments", len(comments)),
+	}
+}
+
+func (s *commentService) UpdateComment(infractionID int64, commentID int64, body params.UpdateCommentParams) (*refractor.Comment, *refractor.ServiceResponse) {
+	comment, res := s.getComment(infractionID, commentID)
+	if !res.Success {
+		return nil, res
+	}
+
+	if !body.UserMeta.CanEditInfraction(comment.UserID) {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    config.MessageNoPermission,
+		}
+	}
+
+	updatedComment, err := s.repo.Update(commentID, refractor.UpdateArgs{
+		"Body":     body.Body,
+		"EditedAt": time.Now().Unix(),
+	})
+	if err != nil {
+		s.log.Error("Could not update comment ID %d. Error: %v", commentID, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	s.setAuthorName(updatedComment)
+
+	return updatedComment, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Comment updated",
+	}
+}
+
+// DeleteComment deletes a comment along with all replies to it.
+func (s *commentService) DeleteComment(infractionID int64, commentID int64, user params.UserMeta) *refractor.ServiceResponse {
+	comment, res := s.getComment(infractionID, commentID)
+	if !res.Success {
+		return res
+	}
+
+	if !user.CanEditInfraction(comment.UserID) {
+		return &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    config.MessageNoPermission,
+		}
+	}
+
+	if err := s.repo.Delete(commentID); err != nil {
+		s.log.Error("Could not delete comment ID %d. Error: %v", commentID, err)
+		return refractor.InternalErrorResponse
+	}
+
+	return &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Comment deleted",
+	}
+}
+
+// getComment gets a comment by ID and makes sure it belongs to the given infraction.
+func (s *commentService) getComment(infractionID int64, commentID int64) (*refractor.Comment, *refractor.ServiceResponse) {
+	comment, err := s.repo.FindByID(commentID)
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return nil, &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			}
+		}
+
+		s.log.Error("Could not get comment by id %d. Error: %v", commentID, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	if comment.InfractionID != infractionID {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    config.MessageInvalidIDProvided,
+		}
+	}
+
+	return comment, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Comment fetched",
+	}
+}
+
+func (s *commentService) setAuthorName(comment *refractor.Comment) {
+	user, err := s.userService.GetUserByID(comment.UserID)
+	if err != nil {
+		s.log.Error("Could not get comment author by ID. Error: %v", err)
+		return
+	}
+
+	comment.AuthorName = user.Username
+}
+
+// buildThreads nests replies under their parent comments. Comments must be ordered oldest first so that replies stay
+// in the order they were made. Replies to comments which aren't in the list are treated as top level comments.
+func buildThreads(comments []*refractor.Comment) []*refractor.Comment {
+	commentsByID := map[int64]*refractor.Comment{}
+	for _, comment := range comments {
+		comment.Replies = []*refractor.Comment{}
+		commentsByID[comment.CommentID] = comment
+	}
+
+	threads := []*refractor.Comment{}
+
+	for _, comment := range comments {
+		parent := commentsByID[comment.ParentID]
+		if comment.ParentID == 0 || parent == nil {
+			threads = append(threads, comment)
+			continue
+		}
+
+		parent.Replies = append(parent.Replies, comment)
+	}
+
+	return threads
+}
@@ -0,0 +1,474 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package comment
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/internal/infraction"
+	"github.com/sniddunc/refractor/internal/mock"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/internal/user"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/pkg/perms"
+	"github.com/sniddunc/refractor/refractor"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func Test_commentService_CreateComment(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	type fields struct {
+		mockInfractions map[int64]*refractor.DBInfraction
+		mockComments    map[int64]*refractor.Comment
+	}
+	type args struct {
+		infractionID int64
+		body         params.CreateCommentParams
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		args          args
+		wantBroadcast bool
+		wantRes       *refractor.ServiceResponse
+	}{
+		{
+			name: "comment.createcomment.1",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+					},
+				},
+				mockComments: map[int64]*refractor.Comment{},
+			},
+			args: args{
+				infractionID: 1,
+				body: params.CreateCommentParams{
+					Body:     "Checked the demo",
+					UserMeta: &params.UserMeta{UserID: 1},
+				},
+			},
+			wantBroadcast: true,
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Comment created",
+			},
+		},
+		{
+			name: "comment.createcomment.2",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+					},
+				},
+				mockComments: map[int64]*refractor.Comment{
+					1: {CommentID: 1, InfractionID: 1, UserID: 1, Body: "Checked the demo"},
+				},
+			},
+			args: args{
+				infractionID: 1,
+				body: params.CreateCommentParams{
+					ParentID: 1,
+					Body:     "Agreed",
+					UserMeta: &params.UserMeta{UserID: 1},
+				},
+			},
+			wantBroadcast: true,
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Comment created",
+			},
+		},
+		{
+			name: "comment.createcomment.3",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+					},
+					3: {
+						InfractionID: 3,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_KICK,
+						Reason:       sql.NullString{String: "Test kick reason", Valid: true},
+					},
+				},
+				mockComments: map[int64]*refractor.Comment{
+					1: {CommentID: 1, InfractionID: 3, UserID: 1, Body: "Comment on another infraction"},
+				},
+			},
+			args: args{
+				infractionID: 1,
+				body: params.CreateCommentParams{
+					ParentID: 1,
+					Body:     "Agreed",
+					UserMeta: &params.UserMeta{UserID: 1},
+				},
+			},
+			wantBroadcast: false,
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "comment.createcomment.4",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					2: {
+						InfractionID: 2,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_WARNING,
+						Reason:       sql.NullString{String: "Test warning reason", Valid: true},
+						DeletedBy:    sql.NullInt64{Int64: 1, Valid: true},
+						DeletedAt:    sql.NullInt64{Int64: 1000, Valid: true},
+					},
+				},
+				mockComments: map[int64]*refractor.Comment{},
+			},
+			args: args{
+				infractionID: 2,
+				body: params.CreateCommentParams{
+					Body:     "Why was this deleted?",
+					UserMeta: &params.UserMeta{UserID: 1},
+				},
+			},
+			wantBroadcast: false,
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			},
+		},
+		{
+			name: "comment.createcomment.5",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{},
+				mockComments:    map[int64]*refractor.Comment{},
+			},
+			args: args{
+				infractionID: 99,
+				body: params.CreateCommentParams{
+					Body:     "Checked the demo",
+					UserMeta: &params.UserMeta{UserID: 1},
+				},
+			},
+			wantBroadcast: false,
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInfractionRepo := mock.NewMockInfractionRepository(tt.fields.mockInfractions)
+			infractionService := infraction.NewInfractionService(mockInfractionRepo, nil, nil, nil, nil, nil, testLogger)
+			userService := user.NewUserService(mock.NewMockUserRepository(mock.GetMockUsers()), testLogger)
+			websocketService := mock.NewMockWebsocketService()
+			mockCommentRepo := mock.NewMockCommentRepository(tt.fields.mockComments)
+			commentService := NewCommentService(mockCommentRepo, infractionService, userService, websocketService,
+				testLogger)
+
+			comment, res := commentService.CreateComment(tt.args.infractionID, tt.args.body)
+
+			assert.True(t, tt.wantRes.Equals(res), "tt.wantRes = %v and res = %v should be equal", tt.wantRes, res)
+
+			if tt.wantBroadcast {
+				assert.Equal(t, "tester", comment.AuthorName, "Author name should be set")
+				assert.Equal(t, tt.args.body.ParentID, comment.ParentID, "Parent IDs should be equal")
+				assert.Len(t, websocketService.Messages, 1, "The new comment should have been broadcast")
+				assert.Equal(t, "infraction-comment", websocketService.Messages[0].Type)
+			} else {
+				assert.Len(t, websocketService.Messages, 0, "Nothing should have been broadcast")
+			}
+		})
+	}
+}
+
+func Test_commentService_GetInfractionComments(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	mockInfractionRepo := mock.NewMockInfractionRepository(map[int64]*refractor.DBInfraction{
+		1: {
+			InfractionID: 1,
+			PlayerID:     1,
+			UserID:       1,
+			ServerID:     1,
+			Type:         refractor.INFRACTION_TYPE_BAN,
+			Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+		},
+		3: {
+			InfractionID: 3,
+			PlayerID:     1,
+			UserID:       1,
+			ServerID:     1,
+			Type:         refractor.INFRACTION_TYPE_KICK,
+			Reason:       sql.NullString{String: "Test kick reason", Valid: true},
+		},
+	})
+	infractionService := infraction.NewInfractionService(mockInfractionRepo, nil, nil, nil, nil, nil, testLogger)
+	userService := user.NewUserService(mock.NewMockUserRepository(mock.GetMockUsers()), testLogger)
+	mockCommentRepo := mock.NewMockCommentRepository(map[int64]*refractor.Comment{
+		1: {CommentID: 1, InfractionID: 1, UserID: 1, Body: "First thread"},
+		2: {CommentID: 2, InfractionID: 1, UserID: 1, Body: "Second thread"},
+		3: {CommentID: 3, InfractionID: 1, ParentID: 1, UserID: 1, Body: "Reply to first"},
+		4: {CommentID: 4, InfractionID: 1, ParentID: 3, UserID: 1, Body: "Reply to reply"},
+		5: {CommentID: 5, InfractionID: 3, UserID: 1, Body: "Other infraction"},
+	})
+	commentService := NewCommentService(mockCommentRepo, infractionService, userService,
+		mock.NewMockWebsocketService(), testLogger)
+
+	threads, res := commentService.GetInfractionComments(1)
+
+	assert.True(t, res.Success, "GetInfractionComments should succeed. Message: %s", res.Message)
+	assert.Equal(t, "Fetched 4 comments", res.Message)
+	assert.Len(t, threads, 2, "There should be two top level comments")
+	assert.Equal(t, int64(1), threads[0].CommentID)
+	assert.Equal(t, int64(2), threads[1].CommentID)
+	assert.Len(t, threads[0].Replies, 1, "The first thread should have one direct reply")
+	assert.Equal(t, int64(3), threads[0].Replies[0].CommentID)
+	assert.Len(t, threads[0].Replies[0].Replies, 1, "The reply should have its own reply")
+	assert.Equal(t, int64(4), threads[0].Replies[0].Replies[0].CommentID)
+	assert.Equal(t, "tester", threads[0].AuthorName)
+}
+
+func Test_commentService_UpdateComment(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	type fields struct {
+		mockInfractions map[int64]*refractor.DBInfraction
+		mockComments    map[int64]*refractor.Comment
+	}
+	type args struct {
+		infractionID int64
+		commentID    int64
+		user         *params.UserMeta
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantRes *refractor.ServiceResponse
+	}{
+		{
+			name: "comment.updatecomment.1",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+					},
+				},
+				mockComments: map[int64]*refractor.Comment{
+					1: {CommentID: 1, InfractionID: 1, UserID: 1, Body: "Checked the demo"},
+				},
+			},
+			args: args{
+				infractionID: 1,
+				commentID:    1,
+				user:         &params.UserMeta{UserID: 1, Permissions: perms.EDIT_OWN_INFRACTIONS},
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Comment updated",
+			},
+		},
+		{
+			name: "comment.updatecomment.2",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+					},
+				},
+				mockComments: map[int64]*refractor.Comment{
+					1: {CommentID: 1, InfractionID: 1, UserID: 1, Body: "Checked the demo"},
+				},
+			},
+			args: args{
+				infractionID: 1,
+				commentID:    1,
+				user:         &params.UserMeta{UserID: 2, Permissions: perms.EDIT_OWN_INFRACTIONS},
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageNoPermission,
+			},
+		},
+		{
+			name: "comment.updatecomment.3",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+					},
+				},
+				mockComments: map[int64]*refractor.Comment{
+					1: {CommentID: 1, InfractionID: 1, UserID: 1, Body: "Checked the demo"},
+				},
+			},
+			args: args{
+				infractionID: 1,
+				commentID:    1,
+				user:         &params.UserMeta{UserID: 2, Permissions: perms.EDIT_ANY_INFRACTION},
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Comment updated",
+			},
+		},
+		{
+			name: "comment.updatecomment.4",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+					},
+					3: {
+						InfractionID: 3,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_KICK,
+						Reason:       sql.NullString{String: "Test kick reason", Valid: true},
+					},
+				},
+				mockComments: map[int64]*refractor.Comment{
+					1: {CommentID: 1, InfractionID: 1, UserID: 1, Body: "Checked the demo"},
+				},
+			},
+			args: args{
+				infractionID: 3,
+				commentID:    1,
+				user:         &params.UserMeta{UserID: 1, Permissions: perms.EDIT_ANY_INFRACTION},
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInfractionRepo := mock.NewMockInfractionRepository(tt.fields.mockInfractions)
+			infractionService := infraction.NewInfractionService(mockInfractionRepo, nil, nil, nil, nil, nil, testLogger)
+			userService := user.NewUserService(mock.NewMockUserRepository(mock.GetMockUsers()), testLogger)
+			mockCommentRepo := mock.NewMockCommentRepository(tt.fields.mockComments)
+			commentService := NewCommentService(mockCommentRepo, infractionService, userService,
+				mock.NewMockWebsocketService(), testLogger)
+
+			comment, res := commentService.UpdateComment(tt.args.infractionID, tt.args.commentID, params.UpdateCommentParams{
+				Body:     "Checked the demo again",
+				UserMeta: tt.args.user,
+			})
+
+			assert.True(t, tt.wantRes.Equals(res), "tt.wantRes = %v and res = %v should be equal", tt.wantRes, res)
+
+			if res.Success {
+				assert.Equal(t, "Checked the demo again", comment.Body, "Comment body should be updated")
+				assert.NotZero(t, comment.EditedAt, "EditedAt should be set")
+			}
+		})
+	}
+}
+
+func Test_commentService_DeleteComment(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	mockInfractionRepo := mock.NewMockInfractionRepository(map[int64]*refractor.DBInfraction{
+		1: {
+			InfractionID: 1,
+			PlayerID:     1,
+			UserID:       1,
+			ServerID:     1,
+			Type:         refractor.INFRACTION_TYPE_BAN,
+			Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+		},
+	})
+	infractionService := infraction.NewInfractionService(mockInfractionRepo, nil, nil, nil, nil, nil, testLogger)
+	userService := user.NewUserService(mock.NewMockUserRepository(mock.GetMockUsers()), testLogger)
+	mockCommentRepo := mock.NewMockCommentRepository(map[int64]*refractor.Comment{
+		1: {CommentID: 1, InfractionID: 1, UserID: 1, Body: "First thread"},
+		2: {CommentID: 2, InfractionID: 1, UserID: 1, Body: "Second thread"},
+		3: {CommentID: 3, InfractionID: 1, ParentID: 1, UserID: 2, Body: "Reply to first"},
+	})
+	commentService := NewCommentService(mockCommentRepo, infractionService, userService,
+		mock.NewMockWebsocketService(), testLogger)
+
+	res := commentService.DeleteComment(1, 1, params.UserMeta{UserID: 2, Permissions: perms.EDIT_OWN_INFRACTIONS})
+	assert.False(t, res.Success, "Users should not be able to delete other users' comments without EDIT_ANY_INFRACTION")
+
+	res = commentService.DeleteComment(1, 1, params.UserMeta{UserID: 1, Permissions: perms.EDIT_OWN_INFRACTIONS})
+	assert.True(t, res.Success, "DeleteComment should succeed. Message: %s", res.Message)
+
+	// Replies should be removed along with the comment they reply to
+	threads, _ := commentService.GetInfractionComments(1)
+	assert.Len(t, threads, 1, "Only the second thread should remain")
+	assert.Equal(t, int64(2), threads[0].CommentID)
+}
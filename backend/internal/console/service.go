@@ -0,0 +1,264 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package console
+
+import (
+	"fmt"
+	"github.com/sniddunc/bitperms"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/pkg/perms"
+	"github.com/sniddunc/refractor/pkg/textutils"
+	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type consoleService struct {
+	ruleRepo      refractor.RCONCommandRuleRepository
+	logRepo       refractor.RCONCommandLogRepository
+	serverService refractor.ServerService
+	rconService   refractor.RCONService
+	log           log.Logger
+}
+
+func NewConsoleService(ruleRepo refractor.RCONCommandRuleRepository, logRepo refractor.RCONCommandLogRepository,
+	serverService refractor.ServerService, rconService refractor.RCONService, log log.Logger) refractor.RCONConsoleService {
+	return &consoleService{
+		ruleRepo:      ruleRepo,
+		logRepo:       logRepo,
+		serverService: serverService,
+		rconService:   rconService,
+		log:           log,
+	}
+}
+
+// ExecCommand runs a command on a server if the user's role is allowed to run it. Every command is recorded in the
+// audit log, including commands which were denied or could not be run.
+func (s *consoleService) ExecCommand(serverID int64, body params.RCONCommandParams) (*refractor.RCONCommandLog, *refractor.ServiceResponse) {
+	server, res := s.serverService.GetServerByID(serverID)
+	if !res.Success {
+		return nil, res
+	}
+
+	if server == nil {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    config.MessageInvalidIDProvided,
+		}
+	}
+
+	allowed, err := s.commandAllowed(body.Command, bitperms.PermissionValue(body.UserMeta.Permissions))
+	if err != nil {
+		s.log.Error("Could not check if command is allowed. Error: %v", err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	commandLog := &refractor.RCONCommandLog{
+		UserID:    body.UserMeta.UserID,
+		ServerID:  serverID,
+		Command:   body.Command,
+		Allowed:   allowed,
+		Timestamp: time.Now().Unix(),
+	}
+
+	var message string
+
+	if !allowed {
+		commandLog.Error = "Command not allowed"
+		message = config.MessageNoPermission
+	} else {
+		output, err := s.rconService.ExecCommand(serverID, body.Command)
+
+		commandLog.Output = textutils.Truncate(output, config.RCONConsoleOutputMaxLen, "")
+		commandLog.Success = err == nil
+
+		if err != nil {
+			commandLog.Error = err.Error()
+
+			if err == refractor.ErrNoRCONClient {
+				message = "The server is not connected"
+			} else {
+				s.log.Warn("Could not run console command on server ID %d. Error: %v", serverID, err)
+				message = "The command could not be run"
+			}
+		}
+	}
+
+	if err := s.logRepo.Create(commandLog); err != nil {
+		s.log.Error("Could not store console command log. Error: %v", err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	if !commandLog.Success {
+		return commandLog, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    message,
+		}
+	}
+
+	return commandLog, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Command executed",
+	}
+}
+
+// commandAllowed checks a command against the rules for the role matching userPerms. Commands and rule prefixes are
+// normalised before they are compared so that rules can't be bypassed with a leading slash, different casing or extra
+// whitespace, and prefixes only match whole words.
+func (s *consoleService) commandAllowed(command string, userPerms bitperms.PermissionValue) (bool, error) {
+	if perms.UserIsSuperAdmin(userPerms) {
+		return true, nil
+	}
+
+	role := refractor.RCON_ROLE_CONSOLE
+	if perms.UserIsAdmin(userPerms) {
+		role = refractor.RCON_ROLE_ADMIN
+	}
+
+	rules, err := s.ruleRepo.FindAll()
+	if err != nil && err != refractor.ErrNotFound {
+		return false, err
+	}
+
+	command = normaliseCommand(command)
+
+	hasAllowRules := false
+	allowMatched := false
+
+	for _, rule := range rules {
+		if rule.Role != role {
+			continue
+		}
+
+		matches := commandHasPrefix(command, normaliseCommand(rule.Prefix))
+
+		switch rule.Type {
+		case refractor.RCON_RULE_DENY:
+			if matches {
+				return false, nil
+			}
+		case refractor.RCON_RULE_ALLOW:
+			hasAllowRules = true
+
+			if matches {
+				allowMatched = true
+			}
+		}
+	}
+
+	return !hasAllowRules || allowMatched, nil
+}
+
+// normaliseCommand lowercases a command, collapses its whitespace and removes any leading slashes. Some games such as
+// Minecraft run "/stop" the same way as "stop".
+func normaliseCommand(command string) string {
+	command = strings.TrimLeft(strings.TrimSpace(command), "/")
+
+	return strings.ToLower(strings.Join(strings.Fields(command), " "))
+}
+
+// commandHasPrefix checks if a normalised command starts with the words in a normalised prefix
+func commandHasPrefix(command string, prefix string) bool {
+	if prefix == "" {
+		return false
+	}
+
+	return command == prefix || strings.HasPrefix(command, prefix+" ")
+}
+
+func (s *consoleService) CreateRule(body params.CreateRCONCommandRuleParams) (*refractor.RCONCommandRule, *refractor.ServiceResponse) {
+	newRule := &refractor.RCONCommandRule{
+		Role:   body.Role,
+		Prefix: body.Prefix,
+		Type:   body.Type,
+	}
+
+	if err := s.ruleRepo.Create(newRule); err != nil {
+		s.log.Error("Could not insert new RCON command rule into repository. Error: %v", err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	return newRule, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Rule created",
+	}
+}
+
+func (s *consoleService) GetAllRules() ([]*refractor.RCONCommandRule, *refractor.ServiceResponse) {
+	rules, err := s.ruleRepo.FindAll()
+	if err != nil && err != refractor.ErrNotFound {
+		s.log.Error("Could not FindAll RCON command rules from repository. Error: %v", err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	if rules == nil {
+		rules = []*refractor.RCONCommandRule{}
+	}
+
+	return rules, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    fmt.Sprintf("Fetched %d rules", len(rules)),
+	}
+}
+
+func (s *consoleService) DeleteRule(id int64) *refractor.ServiceResponse {
+	if err := s.ruleRepo.Delete(id); err != nil {
+		if err == refractor.ErrNotFound {
+			return &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			}
+		}
+
+		s.log.Error("Could not delete RCON command rule with ID %d. Error: %v", id, err)
+		return refractor.InternalErrorResponse
+	}
+
+	return &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Rule deleted",
+	}
+}
+
+func (s *consoleService) GetCommandLogs(serverID int64, body params.SearchParams) (int, []*refractor.RCONCommandLog, *refractor.ServiceResponse) {
+	count, logs, err := s.logRepo.FindManyByServerID(serverID, body.Limit, body.Offset)
+	if err != nil && err != refractor.ErrNotFound {
+		s.log.Error("Could not get console command logs of server ID %d. Error: %v", serverID, err)
+		return 0, nil, refractor.InternalErrorResponse
+	}
+
+	if logs == nil {
+		logs = []*refractor.RCONCommandLog{}
+	}
+
+	return count, logs, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    fmt.Sprintf("Fetched %d command logs", len(logs)),
+	}
+}
@@ -0,0 +1,229 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package console
+
+import (
+	"github.com/sniddunc/refractor/internal/mock"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/internal/server"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/pkg/perms"
+	"github.com/sniddunc/refractor/refractor"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func getMockRules() map[int64]*refractor.RCONCommandRule {
+	return map[int64]*refractor.RCONCommandRule{
+		1: {RuleID: 1, Role: refractor.RCON_ROLE_CONSOLE, Prefix: "kick", Type: refractor.RCON_RULE_ALLOW},
+		2: {RuleID: 2, Role: refractor.RCON_ROLE_CONSOLE, Prefix: "say", Type: refractor.RCON_RULE_ALLOW},
+		3: {RuleID: 3, Role: refractor.RCON_ROLE_CONSOLE, Prefix: "kickall", Type: refractor.RCON_RULE_DENY},
+		4: {RuleID: 4, Role: refractor.RCON_ROLE_ADMIN, Prefix: "stop", Type: refractor.RCON_RULE_DENY},
+		5: {RuleID: 5, Role: refractor.RCON_ROLE_ADMIN, Prefix: "whitelist off", Type: refractor.RCON_RULE_DENY},
+	}
+}
+
+func Test_consoleService_ExecCommand(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	tests := []struct {
+		name        string
+		serverID    int64
+		command     string
+		permissions int64
+		wantStatus  int
+		wantAllowed bool
+		wantLogged  bool
+	}{
+		{
+			name:        "console.exec.1",
+			serverID:    1,
+			command:     "Kick TestPlayer",
+			permissions: perms.RCON_CONSOLE,
+			wantStatus:  http.StatusOK,
+			wantAllowed: true,
+			wantLogged:  true,
+		},
+		{
+			name:        "console.exec.2",
+			serverID:    1,
+			command:     "kickall",
+			permissions: perms.RCON_CONSOLE,
+			wantStatus:  http.StatusBadRequest,
+			wantAllowed: false,
+			wantLogged:  true,
+		},
+		{
+			name:        "console.exec.3",
+			serverID:    1,
+			command:     "ban TestPlayer",
+			permissions: perms.RCON_CONSOLE,
+			wantStatus:  http.StatusBadRequest,
+			wantAllowed: false,
+			wantLogged:  true,
+		},
+		{
+			name:        "console.exec.4",
+			serverID:    1,
+			command:     "ban TestPlayer",
+			permissions: perms.FULL_ACCESS,
+			wantStatus:  http.StatusOK,
+			wantAllowed: true,
+			wantLogged:  true,
+		},
+		{
+			name:        "console.exec.5",
+			serverID:    1,
+			command:     "stop",
+			permissions: perms.FULL_ACCESS,
+			wantStatus:  http.StatusBadRequest,
+			wantAllowed: false,
+			wantLogged:  true,
+		},
+		{
+			name:        "console.exec.6",
+			serverID:    1,
+			command:     "stop",
+			permissions: perms.SUPER_ADMIN,
+			wantStatus:  http.StatusOK,
+			wantAllowed: true,
+			wantLogged:  true,
+		},
+		{
+			name:        "console.exec.7",
+			serverID:    2,
+			command:     "say hello",
+			permissions: perms.RCON_CONSOLE,
+			wantStatus:  http.StatusBadRequest,
+			wantAllowed: true,
+			wantLogged:  true,
+		},
+		{
+			name:        "console.exec.8",
+			serverID:    5,
+			command:     "say hello",
+			permissions: perms.RCON_CONSOLE,
+			wantStatus:  http.StatusBadRequest,
+			wantLogged:  false,
+		},
+		{
+			name:        "console.exec.9",
+			serverID:    1,
+			command:     "/stop",
+			permissions: perms.FULL_ACCESS,
+			wantStatus:  http.StatusBadRequest,
+			wantAllowed: false,
+			wantLogged:  true,
+		},
+		{
+			name:        "console.exec.10",
+			serverID:    1,
+			command:     "  // STOP ",
+			permissions: perms.FULL_ACCESS,
+			wantStatus:  http.StatusBadRequest,
+			wantAllowed: false,
+			wantLogged:  true,
+		},
+		{
+			name:        "console.exec.11",
+			serverID:    1,
+			command:     "whitelist  \t off",
+			permissions: perms.FULL_ACCESS,
+			wantStatus:  http.StatusBadRequest,
+			wantAllowed: false,
+			wantLogged:  true,
+		},
+		{
+			name:        "console.exec.12",
+			serverID:    1,
+			command:     "/whitelist on",
+			permissions: perms.FULL_ACCESS,
+			wantStatus:  http.StatusOK,
+			wantAllowed: true,
+			wantLogged:  true,
+		},
+		{
+			name:        "console.exec.13",
+			serverID:    1,
+			command:     "stopsound @a",
+			permissions: perms.FULL_ACCESS,
+			wantStatus:  http.StatusOK,
+			wantAllowed: true,
+			wantLogged:  true,
+		},
+		{
+			name:        "console.exec.14",
+			serverID:    1,
+			command:     "/say hello",
+			permissions: perms.RCON_CONSOLE,
+			wantStatus:  http.StatusOK,
+			wantAllowed: true,
+			wantLogged:  true,
+		},
+		{
+			name:        "console.exec.15",
+			serverID:    1,
+			command:     "kickTestPlayer",
+			permissions: perms.RCON_CONSOLE,
+			wantStatus:  http.StatusBadRequest,
+			wantAllowed: false,
+			wantLogged:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serverService := server.NewServerService(mock.NewMockServerRepository(map[int64]*refractor.Server{
+				1: {ServerID: 1, Name: "Server 1"},
+				2: {ServerID: 2, Name: "Server 2"},
+			}), nil, testLogger)
+			rconService := mock.NewMockRCONService(1)
+			logRepo := mock.NewMockRCONCommandLogRepository(map[int64]*refractor.RCONCommandLog{})
+
+			service := NewConsoleService(mock.NewMockRCONCommandRuleRepository(getMockRules()), logRepo, serverService,
+				rconService, testLogger)
+
+			_, res := service.ExecCommand(tt.serverID, params.RCONCommandParams{
+				Command: tt.command,
+				UserMeta: &params.UserMeta{
+					UserID:      1,
+					Permissions: tt.permissions,
+				},
+			})
+
+			assert.Equal(t, tt.wantStatus, res.StatusCode, "Status codes should match")
+
+			if !tt.wantLogged {
+				assert.Equal(t, 0, len(logRepo.Logs), "Command should not have been logged")
+				return
+			}
+
+			assert.Equal(t, 1, len(logRepo.Logs), "Command should have been logged")
+
+			commandLog := logRepo.Logs[1]
+			assert.Equal(t, tt.command, commandLog.Command, "Logged command should match")
+			assert.Equal(t, tt.wantAllowed, commandLog.Allowed, "Logged allowed value should match")
+
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, []string{tt.command}, rconService.Commands[tt.serverID], "Command should have been sent")
+			} else {
+				assert.Nil(t, rconService.Commands[tt.serverID], "Command should not have been sent")
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package discord
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/textutils"
+	"github.com/sniddunc/refractor/refractor"
+	"strings"
+	"time"
+)
+
+// Embed colours for each infraction type
+var infractionColors = map[string]int{
+	refractor.INFRACTION_TYPE_WARNING: 0xF1C40F,
+	refractor.INFRACTION_TYPE_MUTE:    0xE67E22,
+	refractor.INFRACTION_TYPE_KICK:    0xE74C3C,
+	refractor.INFRACTION_TYPE_BAN:     0x992D22,
+}
+
+var infractionLabels = map[string]string{
+	refractor.INFRACTION_TYPE_WARNING: "Warning",
+	refractor.INFRACTION_TYPE_MUTE:    "Mute",
+	refractor.INFRACTION_TYPE_KICK:    "Kick",
+	refractor.INFRACTION_TYPE_BAN:     "Ban",
+}
+
+// buildInfractionEmbed renders an infraction as a Discord embed. Names which can't be looked up are shown as Unknown
+// rather than holding up the message.
+func (s *discordService) buildInfractionEmbed(infraction *refractor.Infraction) *refractor.DiscordEmbed {
+	playerName := "Unknown"
+	if player, _ := s.playerService.GetPlayerByID(infraction.PlayerID); player != nil {
+		playerName = player.CurrentName
+	}
+
+	staffName := "Unknown"
+	if user, res := s.userService.GetUserByID(infraction.UserID); res == nil {
+		staffName = user.Username
+	}
+
+	serverName := "Unknown"
+	if server, _ := s.serverService.GetServerByID(infraction.ServerID); server != nil {
+		serverName = server.Name
+	}
+
+	label := infractionLabels[infraction.Type]
+	if label == "" {
+		label = infraction.Type
+	}
+
+	if infraction.SystemAction {
+		staffName += " (automatic)"
+	}
+
+	reason := infraction.Reason
+	if reason == "" {
+		reason = "No reason provided"
+	}
+
+	fields := []*refractor.DiscordEmbedField{
+		{Name: "Player", Value: playerName, Inline: true},
+		{Name: "Staff", Value: staffName, Inline: true},
+		{Name: "Type", Value: label, Inline: true},
+		{Name: "Server", Value: serverName, Inline: true},
+	}
+
+	if infraction.Type == refractor.INFRACTION_TYPE_MUTE || infraction.Type == refractor.INFRACTION_TYPE_BAN {
+		fields = append(fields, &refractor.DiscordEmbedField{
+			Name:   "Duration",
+			Value:  formatDuration(infraction.Duration),
+			Inline: true,
+		})
+	}
+
+	fields = append(fields, &refractor.DiscordEmbedField{
+		Name:  "Reason",
+		Value: textutils.Truncate(reason, config.DiscordFieldValueMaxLen, "..."),
+	})
+
+	embed := &refractor.DiscordEmbed{
+		Title:  fmt.Sprintf("%s issued to %s", label, playerName),
+		Color:  infractionColors[infraction.Type],
+		Fields: fields,
+		Footer: &refractor.DiscordEmbedFooter{
+			Text: fmt.Sprintf("Infraction #%d", infraction.InfractionID),
+		},
+		Timestamp: time.Unix(infraction.Timestamp, 0).UTC().Format(time.RFC3339),
+	}
+
+	if s.dashboardURL != "" {
+		embed.URL = fmt.Sprintf("%s/player/%d?highlight=%d", strings.TrimRight(s.dashboardURL, "/"),
+			infraction.PlayerID, infraction.InfractionID)
+
+		embed.Fields = append(embed.Fields, &refractor.DiscordEmbedField{
+			Name:  "Dashboard",
+			Value: fmt.Sprintf("[View infraction](%s)", embed.URL),
+		})
+	}
+
+	return embed
+}
+
+// send posts a message to a Discord webhook
+func (s *discordService) send(webhookURL string, message *refractor.DiscordMessage) {
+	body, err := json.Marshal(message)
+	if err != nil {
+		s.log.Error("Could not marshal Discord message. Error: %v", err)
+		return
+	}
+
+	res, err := s.client.Post(webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		s.log.Error("Could not send Discord message. Error: %v", err)
+		return
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		s.log.Warn("Discord responded to webhook message with status %d", res.StatusCode)
+	}
+}
+
+// formatDuration formats a mute or ban duration given in minutes, e.g. "1 day, 2 hours". A duration of 0 means the
+// infraction is permanent.
+func formatDuration(minutes int) string {
+	if minutes <= 0 {
+		return "Permanent"
+	}
+
+	units := []struct {
+		name    string
+		minutes int
+	}{
+		{"day", 60 * 24},
+		{"hour", 60},
+		{"minute", 1},
+	}
+
+	var parts []string
+
+	for _, unit := range units {
+		count := minutes / unit.minutes
+		if count < 1 {
+			continue
+		}
+
+		minutes -= count * unit.minutes
+
+		part := fmt.Sprintf("%d %s", count, unit.name)
+		if count != 1 {
+			part += "s"
+		}
+
+		parts = append(parts, part)
+	}
+
+	return strings.Join(parts, ", ")
+}
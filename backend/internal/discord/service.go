@@ -0,0 +1,243 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package discord
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"net/url"
+)
+
+type discordService struct {
+	repo          refractor.DiscordChannelRepository
+	playerService refractor.PlayerService
+	serverService refractor.ServerService
+	userService   refractor.UserService
+	dashboardURL  string
+	client        *http.Client
+	log           log.Logger
+}
+
+// NewDiscordService creates a new Discord service. dashboardURL is the address of the Refractor dashboard and is used
+// to link embeds back to the infraction. If it's empty, embeds are sent without a link.
+func NewDiscordService(repo refractor.DiscordChannelRepository, playerService refractor.PlayerService,
+	serverService refractor.ServerService, userService refractor.UserService, dashboardURL string,
+	log log.Logger) refractor.DiscordService {
+	return &discordService{
+		repo:          repo,
+		playerService: playerService,
+		serverService: serverService,
+		userService:   userService,
+		dashboardURL:  dashboardURL,
+		client:        &http.Client{Timeout: config.DiscordRequestTimeout},
+		log:           log,
+	}
+}
+
+func (s *discordService) CreateChannel(body params.CreateDiscordChannelParams) (*refractor.DiscordChannel, *refractor.ServiceResponse) {
+	if res := s.checkServerExists(body.ServerID); res != nil {
+		return nil, res
+	}
+
+	newChannel := &refractor.DiscordChannel{
+		Name:           body.Name,
+		WebhookURL:     body.WebhookURL,
+		InfractionType: body.InfractionType,
+		ServerID:       body.ServerID,
+		Enabled:        true,
+	}
+
+	if err := s.repo.Create(newChannel); err != nil {
+		s.log.Error("Could not insert new Discord channel into repository. Error: %v", err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	return newChannel, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Discord channel created",
+	}
+}
+
+func (s *discordService) GetAllChannels() ([]*refractor.DiscordChannel, *refractor.ServiceResponse) {
+	channels, err := s.repo.FindAll()
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return []*refractor.DiscordChannel{}, &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Fetched 0 Discord channels",
+			}
+		}
+
+		s.log.Error("Could not FindAll Discord channels from repository. Error: %v", err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	if channels == nil {
+		channels = []*refractor.DiscordChannel{}
+	}
+
+	return channels, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    fmt.Sprintf("Fetched %d Discord channels", len(channels)),
+	}
+}
+
+func (s *discordService) UpdateChannel(id int64, body params.UpdateDiscordChannelParams) (*refractor.DiscordChannel, *refractor.ServiceResponse) {
+	updateArgs := refractor.UpdateArgs{}
+
+	if body.Name != nil {
+		updateArgs["Name"] = *body.Name
+	}
+
+	if body.WebhookURL != nil {
+		updateArgs["WebhookURL"] = *body.WebhookURL
+	}
+
+	if body.InfractionType != nil {
+		updateArgs["InfractionType"] = *body.InfractionType
+	}
+
+	if body.ServerID != nil {
+		if res := s.checkServerExists(*body.ServerID); res != nil {
+			return nil, res
+		}
+
+		updateArgs["ServerID"] = *body.ServerID
+	}
+
+	if body.Enabled != nil {
+		updateArgs["Enabled"] = *body.Enabled
+	}
+
+	if len(updateArgs) < 1 {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    "No updated values provided",
+		}
+	}
+
+	updatedChannel, err := s.repo.Update(id, updateArgs)
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return nil, &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			}
+		}
+
+		s.log.Error("Could not update Discord channel of ID %d in repo. Error: %v", id, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	return updatedChannel, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Discord channel updated",
+	}
+}
+
+func (s *discordService) DeleteChannel(id int64) *refractor.ServiceResponse {
+	if err := s.repo.Delete(id); err != nil {
+		if err == refractor.ErrNotFound {
+			return &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			}
+		}
+
+		s.log.Error("Could not delete Discord channel with ID %d. Error: %v", id, err)
+		return refractor.InternalErrorResponse
+	}
+
+	return &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Discord channel deleted",
+	}
+}
+
+// checkServerExists returns a failed response if serverID is set but doesn't belong to a server. A server ID of 0 is
+// allowed since it means the channel receives infractions from every server.
+func (s *discordService) checkServerExists(serverID int64) *refractor.ServiceResponse {
+	if serverID == 0 {
+		return nil
+	}
+
+	server, res := s.serverService.GetServerByID(serverID)
+	if !res.Success {
+		return res
+	}
+
+	if server == nil {
+		errors := url.Values{}
+		errors.Set("serverId", "Server does not exist")
+
+		return &refractor.ServiceResponse{
+			Success:          false,
+			StatusCode:       http.StatusBadRequest,
+			ValidationErrors: errors,
+		}
+	}
+
+	return nil
+}
+
+// OnInfractionCreate posts a new infraction to every enabled channel which accepts its type and server. If more than
+// one matching channel uses the same webhook, the infraction is only posted to it once.
+func (s *discordService) OnInfractionCreate(infraction *refractor.Infraction) {
+	channels, err := s.repo.FindAll()
+	if err != nil {
+		if err != refractor.ErrNotFound {
+			s.log.Error("Could not get Discord channels. Error: %v", err)
+		}
+		return
+	}
+
+	var webhookURLs []string
+	seen := map[string]bool{}
+
+	for _, channel := range channels {
+		if channel.Matches(infraction.Type, infraction.ServerID) && !seen[channel.WebhookURL] {
+			seen[channel.WebhookURL] = true
+			webhookURLs = append(webhookURLs, channel.WebhookURL)
+		}
+	}
+
+	if len(webhookURLs) < 1 {
+		return
+	}
+
+	message := &refractor.DiscordMessage{
+		Username: config.DiscordUsername,
+		Embeds:   []*refractor.DiscordEmbed{s.buildInfractionEmbed(infraction)},
+	}
+
+	for _, webhookURL := range webhookURLs {
+		go s.send(webhookURL, message)
+	}
+}
@@ -0,0 +1,195 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package discord
+
+import (
+	"database/sql"
+	"encoding/json"
+	"github.com/sniddunc/refractor/internal/mock"
+	"github.com/sniddunc/refractor/internal/player"
+	"github.com/sniddunc/refractor/internal/server"
+	"github.com/sniddunc/refractor/internal/user"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_discordService_OnInfractionCreate(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	tests := []struct {
+		name         string
+		channels     func(url string) map[int64]*refractor.DiscordChannel
+		infraction   *refractor.Infraction
+		wantPaths    []string
+		wantDuration string
+	}{
+		{
+			name: "discord.oninfractioncreate.1",
+			channels: func(url string) map[int64]*refractor.DiscordChannel {
+				return map[int64]*refractor.DiscordChannel{
+					1: {ChannelID: 1, WebhookURL: url + "/all", Enabled: true},
+					2: {ChannelID: 2, WebhookURL: url + "/bans", InfractionType: "BAN", Enabled: true},
+					3: {ChannelID: 3, WebhookURL: url + "/server2", ServerID: 2, Enabled: true},
+					4: {ChannelID: 4, WebhookURL: url + "/disabled", Enabled: false},
+				}
+			},
+			infraction: &refractor.Infraction{
+				InfractionID: 5,
+				PlayerID:     1,
+				UserID:       1,
+				ServerID:     1,
+				Type:         refractor.INFRACTION_TYPE_BAN,
+				Reason:       "Cheating",
+				Duration:     60*24 + 120,
+				Timestamp:    time.Now().Unix(),
+			},
+			wantPaths:    []string{"/all", "/bans"},
+			wantDuration: "1 day, 2 hours",
+		},
+		{
+			name: "discord.oninfractioncreate.2",
+			channels: func(url string) map[int64]*refractor.DiscordChannel {
+				return map[int64]*refractor.DiscordChannel{
+					1: {ChannelID: 1, WebhookURL: url + "/bans", InfractionType: "BAN", Enabled: true},
+					2: {ChannelID: 2, WebhookURL: url + "/server1", ServerID: 1, Enabled: true},
+					3: {ChannelID: 3, WebhookURL: url + "/server1", ServerID: 1, InfractionType: "MUTE", Enabled: true},
+				}
+			},
+			infraction: &refractor.Infraction{
+				InfractionID: 6,
+				PlayerID:     1,
+				UserID:       1,
+				ServerID:     1,
+				Type:         refractor.INFRACTION_TYPE_MUTE,
+				Reason:       "Spamming",
+				Duration:     0,
+				Timestamp:    time.Now().Unix(),
+			},
+			wantPaths:    []string{"/server1"},
+			wantDuration: "Permanent",
+		},
+		{
+			name: "discord.oninfractioncreate.3",
+			channels: func(url string) map[int64]*refractor.DiscordChannel {
+				return map[int64]*refractor.DiscordChannel{
+					1: {ChannelID: 1, WebhookURL: url + "/server2", ServerID: 2, Enabled: true},
+				}
+			},
+			infraction: &refractor.Infraction{
+				InfractionID: 7,
+				PlayerID:     1,
+				UserID:       1,
+				ServerID:     1,
+				Type:         refractor.INFRACTION_TYPE_WARNING,
+				Reason:       "Language",
+				Timestamp:    time.Now().Unix(),
+			},
+			wantPaths: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lock sync.Mutex
+			received := map[string]*refractor.DiscordMessage{}
+			sent := make(chan struct{}, 10)
+
+			discordStandIn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				message := &refractor.DiscordMessage{}
+				if err := json.NewDecoder(r.Body).Decode(message); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+
+				lock.Lock()
+				received[r.URL.Path] = message
+				lock.Unlock()
+
+				w.WriteHeader(http.StatusNoContent)
+				sent <- struct{}{}
+			}))
+			defer discordStandIn.Close()
+
+			playerService := player.NewPlayerService(mock.NewMockPlayerRepository(map[int64]*refractor.DBPlayer{
+				1: {
+					PlayerID:    1,
+					PlayFabID:   sql.NullString{String: "ABCDEF", Valid: true},
+					CurrentName: "TestPlayer",
+				},
+			}), testLogger)
+			serverService := server.NewServerService(mock.NewMockServerRepository(map[int64]*refractor.Server{
+				1: {
+					ServerID: 1,
+					Name:     "Test Server",
+					Game:     "TestGame",
+				},
+			}), nil, testLogger)
+			userService := user.NewUserService(mock.NewMockUserRepository(mock.GetMockUsers()), testLogger)
+
+			service := NewDiscordService(mock.NewMockDiscordChannelRepository(tt.channels(discordStandIn.URL)),
+				playerService, serverService, userService, "https://refractor.example.com/", testLogger).(*discordService)
+
+			service.OnInfractionCreate(tt.infraction)
+
+			// Messages are sent in the background, so wait for the stand-in to receive each of the expected messages
+			for range tt.wantPaths {
+				select {
+				case <-sent:
+				case <-time.After(5 * time.Second):
+					t.Fatal("Timed out waiting for Discord messages")
+				}
+			}
+
+			lock.Lock()
+			defer lock.Unlock()
+
+			var paths []string
+			for _, path := range []string{"/all", "/bans", "/server1", "/server2", "/disabled"} {
+				if received[path] != nil {
+					paths = append(paths, path)
+				}
+			}
+
+			assert.Equal(t, tt.wantPaths, paths, "Messages should have been sent to the matching channels")
+
+			for _, message := range received {
+				assert.Equal(t, 1, len(message.Embeds), "Message should contain one embed")
+
+				embed := message.Embeds[0]
+				fields := map[string]string{}
+				for _, field := range embed.Fields {
+					fields[field.Name] = field.Value
+				}
+
+				assert.Equal(t, "TestPlayer", fields["Player"], "Player field should match")
+				assert.Equal(t, "tester", fields["Staff"], "Staff field should match")
+				assert.Equal(t, "Test Server", fields["Server"], "Server field should match")
+				assert.Equal(t, tt.infraction.Reason, fields["Reason"], "Reason field should match")
+				assert.Equal(t, tt.wantDuration, fields["Duration"], "Duration field should match")
+				assert.Equal(t, "https://refractor.example.com/player/1?highlight="+
+					strconv.FormatInt(tt.infraction.InfractionID, 10), embed.URL, "Embed should link to the dashboard")
+			}
+		})
+	}
+}
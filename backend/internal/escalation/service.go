@@ -0,0 +1,312 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package escalation
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"time"
+)
+
+type escalationService struct {
+	repo              refractor.EscalationPolicyRepository
+	infractionService refractor.InfractionService
+	systemUserID      int64
+	log               log.Logger
+}
+
+// NewEscalationService creates a new escalation service. Infractions created by escalation policies are attributed to
+// the user with the ID systemUserID.
+func NewEscalationService(repo refractor.EscalationPolicyRepository, infractionService refractor.InfractionService,
+	systemUserID int64, log log.Logger) refractor.EscalationService {
+	return &escalationService{
+		repo:              repo,
+		infractionService: infractionService,
+		systemUserID:      systemUserID,
+		log:               log,
+	}
+}
+
+func (s *escalationService) CreatePolicy(body params.CreateEscalationPolicyParams) (*refractor.EscalationPolicy, *refractor.ServiceResponse) {
+	newPolicy := &refractor.EscalationPolicy{
+		Name:           body.Name,
+		TriggerType:    body.TriggerType,
+		TriggerCount:   body.TriggerCount,
+		TimeWindow:     body.TimeWindow,
+		ActionType:     body.ActionType,
+		ActionDuration: body.ActionDuration,
+		ActionReason:   body.ActionReason,
+		Enabled:        true,
+	}
+
+	if err := s.repo.Create(newPolicy); err != nil {
+		s.log.Error("Could not insert new escalation policy into repository. Error: %v", err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	return newPolicy, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Escalation policy created",
+	}
+}
+
+func (s *escalationService) GetAllPolicies() ([]*refractor.EscalationPolicy, *refractor.ServiceResponse) {
+	policies, err := s.repo.FindAll()
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return []*refractor.EscalationPolicy{}, &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Fetched 0 escalation policies",
+			}
+		}
+
+		s.log.Error("Could not FindAll escalation policies from repository. Error: %v", err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	if policies == nil {
+		policies = []*refractor.EscalationPolicy{}
+	}
+
+	return policies, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    fmt.Sprintf("Fetched %d escalation policies", len(policies)),
+	}
+}
+
+func (s *escalationService) UpdatePolicy(id int64, body params.UpdateEscalationPolicyParams) (*refractor.EscalationPolicy, *refractor.ServiceResponse) {
+	updateArgs := refractor.UpdateArgs{}
+
+	if body.Name != nil {
+		updateArgs["Name"] = *body.Name
+	}
+
+	if body.TriggerType != nil {
+		updateArgs["TriggerType"] = *body.TriggerType
+	}
+
+	if body.TriggerCount != nil {
+		updateArgs["TriggerCount"] = *body.TriggerCount
+	}
+
+	if body.TimeWindow != nil {
+		updateArgs["TimeWindow"] = *body.TimeWindow
+	}
+
+	if body.ActionType != nil {
+		updateArgs["ActionType"] = *body.ActionType
+	}
+
+	if body.ActionDuration != nil {
+		updateArgs["ActionDuration"] = *body.ActionDuration
+	}
+
+	if body.ActionReason != nil {
+		updateArgs["ActionReason"] = *body.ActionReason
+	}
+
+	if body.Enabled != nil {
+		updateArgs["Enabled"] = *body.Enabled
+	}
+
+	if len(updateArgs) < 1 {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    "No updated values provided",
+		}
+	}
+
+	updatedPolicy, err := s.repo.Update(id, updateArgs)
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return nil, &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			}
+		}
+
+		s.log.Error("Could not update escalation policy of ID %d in repo. Error: %v", id, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	return updatedPolicy, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Escalation policy updated",
+	}
+}
+
+func (s *escalationService) DeletePolicy(id int64) *refractor.ServiceResponse {
+	if err := s.repo.Delete(id); err != nil {
+		if err == refractor.ErrNotFound {
+			return &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			}
+		}
+
+		s.log.Error("Could not delete escalation policy with ID %d. Error: %v", id, err)
+		return refractor.InternalErrorResponse
+	}
+
+	return &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Escalation policy deleted",
+	}
+}
+
+// OnInfractionCreate evaluates all enabled escalation policies which are triggered by the created infraction's type.
+// For each policy whose threshold has been reached, a system infraction linked to the triggering infraction is created
+// and enforced through RCON.
+//
+// Escalated infractions are themselves evaluated once created, which is what allows policies to form a ladder. To stop
+// misconfigured policies from escalating each other forever, escalation chains are capped at config.EscalationMaxDepth.
+func (s *escalationService) OnInfractionCreate(infraction *refractor.Infraction) {
+	policies, err := s.repo.FindAll()
+	if err != nil {
+		if err != refractor.ErrNotFound {
+			s.log.Error("Could not get escalation policies. Error: %v", err)
+		}
+
+		return
+	}
+
+	var triggeredPolicies []*refractor.EscalationPolicy
+
+	for _, policy := range policies {
+		if policy.Enabled && policy.TriggerType == infraction.Type {
+			triggeredPolicies = append(triggeredPolicies, policy)
+		}
+	}
+
+	if len(triggeredPolicies) < 1 {
+		return
+	}
+
+	playerInfractions, res := s.infractionService.GetPlayerInfractions(infraction.PlayerID)
+	if !res.Success {
+		s.log.Error("Could not get infractions of player ID %d to evaluate escalation policies", infraction.PlayerID)
+		return
+	}
+
+	if depth := getEscalationDepth(infraction, playerInfractions); depth >= config.EscalationMaxDepth {
+		s.log.Warn("Escalation chain for infraction ID %d reached the max depth of %d. Check your escalation policies "+
+			"for policies which trigger each other.", infraction.InfractionID, config.EscalationMaxDepth)
+		return
+	}
+
+	now := time.Now().Unix()
+
+	for _, policy := range triggeredPolicies {
+		// Infractions never count towards the policy which created them
+		if infraction.PolicyID == policy.PolicyID {
+			continue
+		}
+
+		if !isPolicyTriggered(policy, playerInfractions, now) {
+			continue
+		}
+
+		newInfraction := &refractor.DBInfraction{
+			PlayerID:           infraction.PlayerID,
+			UserID:             s.systemUserID,
+			ServerID:           infraction.ServerID,
+			Type:               policy.ActionType,
+			Reason:             sql.NullString{String: policy.ActionReason, Valid: true},
+			LinkedInfractionID: sql.NullInt64{Int64: infraction.InfractionID, Valid: true},
+			PolicyID:           sql.NullInt64{Int64: policy.PolicyID, Valid: true},
+		}
+
+		if policy.ActionType == refractor.INFRACTION_TYPE_MUTE || policy.ActionType == refractor.INFRACTION_TYPE_BAN {
+			newInfraction.Duration = sql.NullInt32{Int32: int32(policy.ActionDuration), Valid: true}
+		}
+
+		escalated, res := s.infractionService.CreateSystemInfraction(newInfraction, true)
+		if !res.Success {
+			s.log.Error("Escalation policy ID %d could not create an infraction for player ID %d. Message: %s",
+				policy.PolicyID, infraction.PlayerID, res.Message)
+			continue
+		}
+
+		s.log.Info("Escalation policy ID %d created infraction ID %d for player ID %d, triggered by infraction ID %d",
+			policy.PolicyID, escalated.InfractionID, infraction.PlayerID, infraction.InfractionID)
+	}
+}
+
+// isPolicyTriggered checks if a player's infractions have reached a policy's threshold. Only infractions created since
+// the policy last escalated for the player are counted so that each escalation starts a fresh count. Revoked
+// infractions and infractions created by the policy itself are never counted.
+func isPolicyTriggered(policy *refractor.EscalationPolicy, playerInfractions []*refractor.Infraction, now int64) bool {
+	// Find the most recent infraction which caused this policy to escalate
+	var lastTriggerID int64
+
+	for _, infraction := range playerInfractions {
+		if infraction.PolicyID == policy.PolicyID && infraction.LinkedInfractionID > lastTriggerID {
+			lastTriggerID = infraction.LinkedInfractionID
+		}
+	}
+
+	windowStart := int64(0)
+	if policy.TimeWindow > 0 {
+		windowStart = now - int64(policy.TimeWindow)*60
+	}
+
+	count := 0
+
+	for _, infraction := range playerInfractions {
+		if infraction.Type != policy.TriggerType || infraction.Revoked || infraction.PolicyID == policy.PolicyID {
+			continue
+		}
+
+		if infraction.InfractionID <= lastTriggerID || infraction.Timestamp < windowStart {
+			continue
+		}
+
+		count++
+	}
+
+	return count >= policy.TriggerCount
+}
+
+// getEscalationDepth returns the number of escalations which led to an infraction by following its linked infractions.
+func getEscalationDepth(infraction *refractor.Infraction, playerInfractions []*refractor.Infraction) int {
+	infractionsByID := map[int64]*refractor.Infraction{}
+	for _, playerInfraction := range playerInfractions {
+		infractionsByID[playerInfraction.InfractionID] = playerInfraction
+	}
+
+	depth := 0
+
+	for current := infraction; current != nil && current.LinkedInfractionID != 0; depth++ {
+		current = infractionsByID[current.LinkedInfractionID]
+	}
+
+	return depth
+}
@@ -0,0 +1,230 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package escalation
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/internal/game"
+	"github.com/sniddunc/refractor/internal/infraction"
+	"github.com/sniddunc/refractor/internal/mock"
+	"github.com/sniddunc/refractor/internal/player"
+	"github.com/sniddunc/refractor/internal/server"
+	"github.com/sniddunc/refractor/internal/user"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_escalationService_OnInfractionCreate(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	warning := func(id int64, age time.Duration) *refractor.DBInfraction {
+		return &refractor.DBInfraction{
+			InfractionID: id,
+			PlayerID:     1,
+			UserID:       1,
+			ServerID:     1,
+			Type:         refractor.INFRACTION_TYPE_WARNING,
+			Reason:       sql.NullString{String: "Test warning reason", Valid: true},
+			Timestamp:    time.Now().Add(-age).Unix(),
+		}
+	}
+
+	type fields struct {
+		mockInfractions map[int64]*refractor.DBInfraction
+		mockPolicies    map[int64]*refractor.EscalationPolicy
+	}
+	tests := []struct {
+		name          string
+		fields        fields
+		wantEscalated []string
+	}{
+		{
+			name: "escalation.oninfractioncreate.1",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: warning(1, time.Hour*48),
+					2: warning(2, time.Hour*24),
+				},
+				mockPolicies: map[int64]*refractor.EscalationPolicy{
+					1: {
+						PolicyID:       1,
+						Name:           "3 warnings in 30 days",
+						TriggerType:    refractor.INFRACTION_TYPE_WARNING,
+						TriggerCount:   3,
+						TimeWindow:     43200,
+						ActionType:     refractor.INFRACTION_TYPE_MUTE,
+						ActionDuration: 60,
+						ActionReason:   "Too many warnings",
+						Enabled:        true,
+					},
+				},
+			},
+			wantEscalated: []string{refractor.INFRACTION_TYPE_MUTE},
+		},
+		{
+			name: "escalation.oninfractioncreate.2",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: warning(1, time.Hour*24*60),
+					2: warning(2, time.Hour*24),
+				},
+				mockPolicies: map[int64]*refractor.EscalationPolicy{
+					1: {
+						PolicyID:       1,
+						Name:           "3 warnings in 30 days",
+						TriggerType:    refractor.INFRACTION_TYPE_WARNING,
+						TriggerCount:   3,
+						TimeWindow:     43200,
+						ActionType:     refractor.INFRACTION_TYPE_MUTE,
+						ActionDuration: 60,
+						ActionReason:   "Too many warnings",
+						Enabled:        true,
+					},
+				},
+			},
+			wantEscalated: nil,
+		},
+		{
+			name: "escalation.oninfractioncreate.3",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: warning(1, time.Hour*48),
+					2: warning(2, time.Hour*24),
+				},
+				mockPolicies: map[int64]*refractor.EscalationPolicy{
+					1: {
+						PolicyID:       1,
+						Name:           "3 warnings",
+						TriggerType:    refractor.INFRACTION_TYPE_WARNING,
+						TriggerCount:   3,
+						ActionType:     refractor.INFRACTION_TYPE_MUTE,
+						ActionDuration: 60,
+						ActionReason:   "Too many warnings",
+						Enabled:        true,
+					},
+					2: {
+						PolicyID:       2,
+						Name:           "1 mute",
+						TriggerType:    refractor.INFRACTION_TYPE_MUTE,
+						TriggerCount:   1,
+						ActionType:     refractor.INFRACTION_TYPE_BAN,
+						ActionDuration: 1440,
+						ActionReason:   "Muted too often",
+						Enabled:        true,
+					},
+				},
+			},
+			wantEscalated: []string{refractor.INFRACTION_TYPE_MUTE, refractor.INFRACTION_TYPE_BAN},
+		},
+		{
+			name: "escalation.oninfractioncreate.4",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{},
+				mockPolicies: map[int64]*refractor.EscalationPolicy{
+					1: {
+						PolicyID:       1,
+						Name:           "Warning to mute",
+						TriggerType:    refractor.INFRACTION_TYPE_WARNING,
+						TriggerCount:   1,
+						ActionType:     refractor.INFRACTION_TYPE_MUTE,
+						ActionDuration: 60,
+						ActionReason:   "Warned",
+						Enabled:        true,
+					},
+					2: {
+						PolicyID:       2,
+						Name:           "Mute to ban",
+						TriggerType:    refractor.INFRACTION_TYPE_MUTE,
+						TriggerCount:   1,
+						ActionType:     refractor.INFRACTION_TYPE_BAN,
+						ActionDuration: 60,
+						ActionReason:   "Muted",
+						Enabled:        true,
+					},
+					3: {
+						PolicyID:       3,
+						Name:           "Ban to mute",
+						TriggerType:    refractor.INFRACTION_TYPE_BAN,
+						TriggerCount:   1,
+						ActionType:     refractor.INFRACTION_TYPE_MUTE,
+						ActionDuration: 60,
+						ActionReason:   "Banned",
+						Enabled:        true,
+					},
+				},
+			},
+			// Policies 2 and 3 escalate each other until the max escalation depth is reached
+			wantEscalated: []string{
+				refractor.INFRACTION_TYPE_MUTE,
+				refractor.INFRACTION_TYPE_BAN,
+				refractor.INFRACTION_TYPE_MUTE,
+				refractor.INFRACTION_TYPE_BAN,
+				refractor.INFRACTION_TYPE_MUTE,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPlayerRepo := mock.NewMockPlayerRepository(map[int64]*refractor.DBPlayer{
+				1: {
+					PlayerID:  1,
+					PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+				},
+			})
+			playerService := player.NewPlayerService(mockPlayerRepo, testLogger)
+			mockServerRepo := mock.NewMockServerRepository(map[int64]*refractor.Server{
+				1: {
+					ServerID: 1,
+					Game:     "TestGame",
+				},
+			})
+			serverService := server.NewServerService(mockServerRepo, nil, testLogger)
+			userService := user.NewUserService(mock.NewMockUserRepository(mock.GetMockUsers()), testLogger)
+			gameService := game.NewGameService()
+			gameService.AddGame(mock.NewMockGame())
+			rconService := mock.NewMockRCONService(1)
+			mockInfractionRepo := mock.NewMockInfractionRepository(tt.fields.mockInfractions)
+			infractionService := infraction.NewInfractionService(mockInfractionRepo, playerService, serverService,
+				userService, rconService, gameService, testLogger)
+			escalationService := NewEscalationService(mock.NewMockEscalationPolicyRepository(tt.fields.mockPolicies),
+				infractionService, 1, testLogger)
+			infractionService.SubscribeCreate(escalationService.OnInfractionCreate)
+
+			// Create the triggering warning
+			existing := len(tt.fields.mockInfractions)
+			_, res := infractionService.CreateSystemInfraction(warning(0, 0), false)
+			assert.True(t, res.Success, "Triggering warning should have been created")
+
+			// Collect the types of all infractions created by escalation policies, in order of creation
+			var escalated []string
+			for id := int64(existing + 2); id <= int64(len(tt.fields.mockInfractions)); id++ {
+				created := tt.fields.mockInfractions[id]
+
+				assert.True(t, created.SystemAction, "Escalated infraction should be a system action")
+				assert.Equal(t, id-1, created.LinkedInfractionID.Int64, "Escalated infraction should link to its trigger")
+
+				escalated = append(escalated, created.Type)
+			}
+
+			assert.Equal(t, tt.wantEscalated, escalated, "Escalated infraction types should be equal")
+		})
+	}
+}
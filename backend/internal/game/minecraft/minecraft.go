@@ -18,6 +18,7 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package minecraft
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/sniddunc/refractor/refractor"
 	"regexp"
@@ -77,6 +78,40 @@ func (g *minecraft) GetBanCommand(args refractor.CommandArgs) string {
 	return fmt.Sprintf("Ban %s %d %s", args.PlayerID, args.Duration, args.Reason)
 }
 
+// GetUnmuteCommand returns a constructed unmute command for Minecraft.
+// The following fields must be present on CommandArgs: PlayerID
+func (g *minecraft) GetUnmuteCommand(args refractor.CommandArgs) string {
+	return fmt.Sprintf("Unmute %s", args.PlayerID)
+}
+
+// GetUnbanCommand returns a constructed unban command for Minecraft.
+// The following fields must be present on CommandArgs: PlayerID
+func (g *minecraft) GetUnbanCommand(args refractor.CommandArgs) string {
+	return fmt.Sprintf("Unban %s", args.PlayerID)
+}
+
+// GetBroadcastChatCommand returns a constructed command for sending a message to everyone on a Minecraft server.
+// The following fields must be present on CommandArgs: Message
+func (g *minecraft) GetBroadcastChatCommand(args refractor.CommandArgs) string {
+	return fmt.Sprintf("tellraw @a %s", getTellrawText(args.Message))
+}
+
+// GetPrivateMessageCommand returns a constructed command for sending a message to a single player on a Minecraft
+// server. The following fields must be present on CommandArgs: PlayerID, Message
+func (g *minecraft) GetPrivateMessageCommand(args refractor.CommandArgs) string {
+	return fmt.Sprintf("tellraw %s %s", args.PlayerID, getTellrawText(args.Message))
+}
+
 func (g *minecraft) GetPlayerListCommand() string {
 	return "refractormc:playerlist" // use refractor minecraft plugin's command
 }
+
+// getTellrawText returns the JSON text component tellraw uses to display message. Using a text component rather than
+// say or msg means the message is shown without a "[Server]" prefix and isn't mangled by quotes or special characters.
+func getTellrawText(message string) string {
+	text, _ := json.Marshal(map[string]string{
+		"text": message,
+	})
+
+	return string(text)
+}
@@ -82,6 +82,29 @@ func (g *mordhau) GetBanCommand(args refractor.CommandArgs) string {
 	return fmt.Sprintf("Ban %s %d %s", args.PlayerID, args.Duration, args.Reason)
 }
 
+// GetUnmuteCommand returns a constructed unmute command for Mordhau.
+// The following fields must be present on CommandArgs: PlayerID
+func (g *mordhau) GetUnmuteCommand(args refractor.CommandArgs) string {
+	return fmt.Sprintf("Unmute %s", args.PlayerID)
+}
+
+// GetUnbanCommand returns a constructed unban command for Mordhau.
+// The following fields must be present on CommandArgs: PlayerID
+func (g *mordhau) GetUnbanCommand(args refractor.CommandArgs) string {
+	return fmt.Sprintf("Unban %s", args.PlayerID)
+}
+
+// GetBroadcastChatCommand returns a constructed command for sending a message to everyone on a Mordhau server.
+// The following fields must be present on CommandArgs: Message
+func (g *mordhau) GetBroadcastChatCommand(args refractor.CommandArgs) string {
+	return fmt.Sprintf("Say %s", args.Message)
+}
+
+// GetPrivateMessageCommand returns an empty string since Mordhau does not have a private message command
+func (g *mordhau) GetPrivateMessageCommand(args refractor.CommandArgs) string {
+	return ""
+}
+
 func (g *mordhau) GetPlayerListCommand() string {
 	return "PlayerList"
 }
@@ -47,6 +47,20 @@ type Handlers struct {
 	InfractionHandler refractor.InfractionHandler
 	SummaryHandler    refractor.SummaryHandler
 	SearchHandler     refractor.SearchHandler
+	EscalationHandler refractor.EscalationHandler
+	AppealHandler     refractor.AppealHandler
+	AttachmentHandler refractor.AttachmentHandler
+	CommentHandler    refractor.CommentHandler
+	NoteHandler       refractor.NoteHandler
+	SessionHandler    refractor.SessionHandler
+	ChatFilterHandler refractor.ChatFilterHandler
+	SpamHandler       refractor.SpamHandler
+	ChatHandler       refractor.ChatHandler
+	WebhookHandler    refractor.WebhookHandler
+	DiscordHandler    refractor.DiscordHandler
+	ScheduleHandler   refractor.ScheduleHandler
+	ConsoleHandler    refractor.RCONConsoleHandler
+	BanImportHandler  refractor.BanImportHandler
 }
 
 type Response struct {
@@ -128,6 +142,10 @@ func (api *API) setupRoutes() {
 	serverGroup.GET("/data", api.ServerHandler.GetAllServerData)
 	serverGroup.PATCH("/:id", api.ServerHandler.UpdateServer, api.RequirePerms(perms.FULL_ACCESS))
 	serverGroup.DELETE("/:id", api.ServerHandler.DeleteServer, api.RequirePerms(perms.FULL_ACCESS))
+	serverGroup.GET("/:id/spam", api.SpamHandler.GetServerSettings, api.RequirePerms(perms.MANAGE_CHAT_FILTERS))
+	serverGroup.PATCH("/:id/spam", api.SpamHandler.UpdateServerSettings, api.RequirePerms(perms.MANAGE_CHAT_FILTERS))
+	serverGroup.POST("/:id/rcon", api.ConsoleHandler.ExecCommand, api.RequirePerms(perms.RCON_CONSOLE))
+	serverGroup.GET("/:id/rcon/logs", api.ConsoleHandler.GetCommandLogs, api.RequirePerms(perms.FULL_ACCESS))
 
 	// Infraction endpoints
 	infractionGroup := apiGroup.Group("/infractions", jwtMiddleware, AttachClaims())
@@ -135,13 +153,81 @@ func (api *API) setupRoutes() {
 	infractionGroup.POST("/mute", api.InfractionHandler.CreateMute, api.RequirePerms(perms.LOG_MUTE))
 	infractionGroup.POST("/kick", api.InfractionHandler.CreateKick, api.RequirePerms(perms.LOG_KICK))
 	infractionGroup.POST("/ban", api.InfractionHandler.CreateBan, api.RequirePerms(perms.LOG_BAN))
+	infractionGroup.POST("/ban/offline", api.InfractionHandler.CreateOfflineBan, api.RequirePerms(perms.LOG_BAN))
+	infractionGroup.POST("/import", api.BanImportHandler.ImportBans, api.RequirePerms(perms.FULL_ACCESS))
 	infractionGroup.DELETE("/:id", api.InfractionHandler.DeleteInfraction, api.RequireOneOfPerms(perms.DELETE_OWN_INFRACTIONS, perms.DELETE_ANY_INFRACTION))
 	infractionGroup.PATCH("/:id", api.InfractionHandler.UpdateInfraction, api.RequireOneOfPerms(perms.EDIT_OWN_INFRACTIONS, perms.EDIT_ANY_INFRACTION))
+	infractionGroup.POST("/:id/revoke", api.InfractionHandler.RevokeInfraction, api.RequireOneOfPerms(perms.EDIT_OWN_INFRACTIONS, perms.EDIT_ANY_INFRACTION))
+	infractionGroup.GET("/:id/history", api.InfractionHandler.GetInfractionHistory)
+	infractionGroup.GET("/:id/chat", api.ChatHandler.GetInfractionChatSnapshot)
 	infractionGroup.GET("/:id/warnings", api.InfractionHandler.GetPlayerInfractions(refractor.INFRACTION_TYPE_WARNING))
 	infractionGroup.GET("/:id/mutes", api.InfractionHandler.GetPlayerInfractions(refractor.INFRACTION_TYPE_MUTE))
 	infractionGroup.GET("/:id/kicks", api.InfractionHandler.GetPlayerInfractions(refractor.INFRACTION_TYPE_KICK))
 	infractionGroup.GET("/:id/bans", api.InfractionHandler.GetPlayerInfractions(refractor.INFRACTION_TYPE_BAN))
 	infractionGroup.GET("/recent", api.InfractionHandler.GetRecentInfractions)
+	infractionGroup.GET("/deleted", api.InfractionHandler.GetDeletedInfractions, api.RequirePerms(perms.FULL_ACCESS))
+	infractionGroup.POST("/:id/restore", api.InfractionHandler.RestoreInfraction, api.RequirePerms(perms.FULL_ACCESS))
+	infractionGroup.DELETE("/:id/purge", api.InfractionHandler.PurgeInfraction, api.RequirePerms(perms.SUPER_ADMIN))
+	infractionGroup.GET("/:id/attachments", api.AttachmentHandler.GetInfractionAttachments)
+	infractionGroup.POST("/:id/attachments", api.AttachmentHandler.UploadAttachment, api.RequireOneOfPerms(perms.EDIT_OWN_INFRACTIONS, perms.EDIT_ANY_INFRACTION))
+	infractionGroup.GET("/:id/attachments/:attachmentId", api.AttachmentHandler.DownloadAttachment)
+	infractionGroup.DELETE("/:id/attachments/:attachmentId", api.AttachmentHandler.DeleteAttachment, api.RequireOneOfPerms(perms.EDIT_OWN_INFRACTIONS, perms.EDIT_ANY_INFRACTION))
+	infractionGroup.GET("/:id/comments", api.CommentHandler.GetInfractionComments)
+	infractionGroup.POST("/:id/comments", api.CommentHandler.CreateComment)
+	infractionGroup.PATCH("/:id/comments/:commentId", api.CommentHandler.UpdateComment, api.RequireOneOfPerms(perms.EDIT_OWN_INFRACTIONS, perms.EDIT_ANY_INFRACTION))
+	infractionGroup.DELETE("/:id/comments/:commentId", api.CommentHandler.DeleteComment, api.RequireOneOfPerms(perms.EDIT_OWN_INFRACTIONS, perms.EDIT_ANY_INFRACTION))
+
+	// Escalation policy endpoints
+	escalationGroup := apiGroup.Group("/escalations", jwtMiddleware, AttachClaims(), api.RequirePerms(perms.MANAGE_ESCALATION_POLICIES))
+	escalationGroup.GET("/", api.EscalationHandler.GetAllPolicies)
+	escalationGroup.POST("/", api.EscalationHandler.CreatePolicy)
+	escalationGroup.PATCH("/:id", api.EscalationHandler.UpdatePolicy)
+	escalationGroup.DELETE("/:id", api.EscalationHandler.DeletePolicy)
+
+	// Chat filter rule endpoints
+	chatFilterGroup := apiGroup.Group("/chatfilters", jwtMiddleware, AttachClaims(), api.RequirePerms(perms.MANAGE_CHAT_FILTERS))
+	chatFilterGroup.GET("/", api.ChatFilterHandler.GetAllRules)
+	chatFilterGroup.POST("/", api.ChatFilterHandler.CreateRule)
+	chatFilterGroup.PATCH("/:id", api.ChatFilterHandler.UpdateRule)
+	chatFilterGroup.DELETE("/:id", api.ChatFilterHandler.DeleteRule)
+
+	// Webhook endpoints
+	webhookGroup := apiGroup.Group("/webhooks", jwtMiddleware, AttachClaims(), api.RequirePerms(perms.FULL_ACCESS))
+	webhookGroup.GET("/", api.WebhookHandler.GetAllWebhooks)
+	webhookGroup.POST("/", api.WebhookHandler.CreateWebhook)
+	webhookGroup.PATCH("/:id", api.WebhookHandler.UpdateWebhook)
+	webhookGroup.DELETE("/:id", api.WebhookHandler.DeleteWebhook)
+	webhookGroup.GET("/:id/deliveries", api.WebhookHandler.GetWebhookDeliveries)
+
+	// Discord channel endpoints
+	discordGroup := apiGroup.Group("/discord/channels", jwtMiddleware, AttachClaims(), api.RequirePerms(perms.FULL_ACCESS))
+	discordGroup.GET("/", api.DiscordHandler.GetAllChannels)
+	discordGroup.POST("/", api.DiscordHandler.CreateChannel)
+	discordGroup.PATCH("/:id", api.DiscordHandler.UpdateChannel)
+	discordGroup.DELETE("/:id", api.DiscordHandler.DeleteChannel)
+
+	// Schedule endpoints
+	scheduleGroup := apiGroup.Group("/schedules", jwtMiddleware, AttachClaims(), api.RequirePerms(perms.FULL_ACCESS))
+	scheduleGroup.GET("/", api.ScheduleHandler.GetAllSchedules)
+	scheduleGroup.POST("/", api.ScheduleHandler.CreateSchedule)
+	scheduleGroup.PATCH("/:id", api.ScheduleHandler.UpdateSchedule)
+	scheduleGroup.DELETE("/:id", api.ScheduleHandler.DeleteSchedule)
+	scheduleGroup.GET("/:id/runs", api.ScheduleHandler.GetScheduleRuns)
+
+	// RCON console command rule endpoints
+	rconRuleGroup := apiGroup.Group("/rcon/rules", jwtMiddleware, AttachClaims(), api.RequirePerms(perms.FULL_ACCESS))
+	rconRuleGroup.GET("/", api.ConsoleHandler.GetAllRules)
+	rconRuleGroup.POST("/", api.ConsoleHandler.CreateRule)
+	rconRuleGroup.DELETE("/:id", api.ConsoleHandler.DeleteRule)
+
+	// Appeal endpoints
+	appealGroup := apiGroup.Group("/appeals", jwtMiddleware, AttachClaims())
+	appealGroup.POST("/", api.AppealHandler.CreateAppeal)
+	appealGroup.GET("/:id", api.AppealHandler.GetAppeal)
+	appealGroup.PATCH("/:id/reviewer", api.AppealHandler.AssignReviewer, api.RequirePerms(perms.REVIEW_APPEALS))
+	appealGroup.POST("/:id/notes", api.AppealHandler.AddNote, api.RequirePerms(perms.REVIEW_APPEALS))
+	appealGroup.POST("/:id/accept", api.AppealHandler.DecideAppeal(refractor.APPEAL_STATUS_ACCEPTED), api.RequirePerms(perms.REVIEW_APPEALS))
+	appealGroup.POST("/:id/deny", api.AppealHandler.DecideAppeal(refractor.APPEAL_STATUS_DENIED), api.RequirePerms(perms.REVIEW_APPEALS))
 
 	// Player endpoints
 	playerGroup := apiGroup.Group("/players", jwtMiddleware, AttachClaims())
@@ -149,11 +235,20 @@ func (api *API) setupRoutes() {
 	playerGroup.GET("/summary/:id", api.SummaryHandler.GetPlayerSummary)
 	playerGroup.POST("/:id/watch", api.PlayerHandler.SwitchPlayerWatch(true))
 	playerGroup.POST("/:id/unwatch", api.PlayerHandler.SwitchPlayerWatch(false))
+	playerGroup.POST("/:id/whisper", api.ChatHandler.WhisperPlayer)
+	playerGroup.GET("/:id/notes", api.NoteHandler.GetPlayerNotes)
+	playerGroup.POST("/:id/notes", api.NoteHandler.CreateNote)
+	playerGroup.PATCH("/:id/notes/:noteId", api.NoteHandler.UpdateNote)
+	playerGroup.DELETE("/:id/notes/:noteId", api.NoteHandler.DeleteNote)
+	playerGroup.GET("/:id/sessions", api.SessionHandler.GetPlayerSessions)
+	playerGroup.GET("/:id/playtime", api.SessionHandler.GetPlayerPlaytime)
 
 	// Search endpoints
 	searchGroup := apiGroup.Group("/search", jwtMiddleware, AttachClaims())
 	searchGroup.POST("/players", api.SearchHandler.SearchPlayers)
 	searchGroup.POST("/infractions", api.SearchHandler.SearchInfractions)
+	searchGroup.POST("/appeals", api.SearchHandler.SearchAppeals)
+	searchGroup.POST("/chat", api.SearchHandler.SearchChat)
 
 	// Websocket endpoint
 	api.echo.Any("/ws", api.websocketHandler)
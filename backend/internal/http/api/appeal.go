@@ -0,0 +1,180 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package api
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/jwt"
+	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"strconv"
+)
+
+type appealHandler struct {
+	service refractor.AppealService
+}
+
+func NewAppealHandler(service refractor.AppealService) refractor.AppealHandler {
+	return &appealHandler{
+		service: service,
+	}
+}
+
+func (h *appealHandler) CreateAppeal(c echo.Context) error {
+	body := params.CreateAppealParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	claims := c.Get("claims").(*jwt.Claims)
+
+	body.UserMeta = &params.UserMeta{
+		UserID:      claims.UserID,
+		Permissions: claims.Permissions,
+	}
+
+	appeal, res := h.service.CreateAppeal(body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Errors:  res.ValidationErrors,
+		Payload: appeal,
+	})
+}
+
+func (h *appealHandler) GetAppeal(c echo.Context) error {
+	idString := c.Param("id")
+
+	appealID, err := strconv.ParseInt(idString, 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	appeal, res := h.service.GetAppealByID(appealID)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Payload: appeal,
+	})
+}
+
+func (h *appealHandler) AssignReviewer(c echo.Context) error {
+	idString := c.Param("id")
+
+	appealID, err := strconv.ParseInt(idString, 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	// Validate request body
+	body := params.AssignAppealReviewerParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	claims := c.Get("claims").(*jwt.Claims)
+
+	body.UserMeta = &params.UserMeta{
+		UserID:      claims.UserID,
+		Permissions: claims.Permissions,
+	}
+
+	updatedAppeal, res := h.service.AssignReviewer(appealID, body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Errors:  res.ValidationErrors,
+		Payload: updatedAppeal,
+	})
+}
+
+func (h *appealHandler) AddNote(c echo.Context) error {
+	idString := c.Param("id")
+
+	appealID, err := strconv.ParseInt(idString, 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	// Validate request body
+	body := params.AppealNoteParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	claims := c.Get("claims").(*jwt.Claims)
+
+	body.UserMeta = &params.UserMeta{
+		UserID:      claims.UserID,
+		Permissions: claims.Permissions,
+	}
+
+	appeal, res := h.service.AddNote(appealID, body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Errors:  res.ValidationErrors,
+		Payload: appeal,
+	})
+}
+
+func (h *appealHandler) DecideAppeal(status string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		idString := c.Param("id")
+
+		appealID, err := strconv.ParseInt(idString, 10, 32)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, Response{
+				Success: false,
+				Message: config.MessageInvalidIDProvided,
+			})
+		}
+
+		// Validate request body
+		body := params.AppealNoteParams{}
+		if ok := ValidateRequest(&body, c); !ok {
+			return nil
+		}
+
+		claims := c.Get("claims").(*jwt.Claims)
+
+		body.UserMeta = &params.UserMeta{
+			UserID:      claims.UserID,
+			Permissions: claims.Permissions,
+		}
+
+		appeal, res := h.service.DecideAppeal(appealID, status, body)
+		return c.JSON(res.StatusCode, Response{
+			Success: res.Success,
+			Message: res.Message,
+			Errors:  res.ValidationErrors,
+			Payload: appeal,
+		})
+	}
+}
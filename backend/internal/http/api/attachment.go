@@ -0,0 +1,182 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package api
+
+import (
+	"fmt"
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/jwt"
+	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"strconv"
+)
+
+type attachmentHandler struct {
+	service refractor.AttachmentService
+}
+
+func NewAttachmentHandler(service refractor.AttachmentService) refractor.AttachmentHandler {
+	return &attachmentHandler{
+		service: service,
+	}
+}
+
+func (h *attachmentHandler) UploadAttachment(c echo.Context) error {
+	idString := c.Param("id")
+
+	infractionID, err := strconv.ParseInt(idString, 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Errors: map[string][]string{
+				"file": {"A file is required"},
+			},
+		})
+	}
+
+	// Validate file metadata
+	body := params.UploadAttachmentParams{
+		FileName: fileHeader.Filename,
+		Size:     fileHeader.Size,
+	}
+
+	if ok, validationErrors := body.Validate(); !ok {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Errors:  validationErrors,
+		})
+	}
+
+	claims := c.Get("claims").(*jwt.Claims)
+
+	body.UserMeta = &params.UserMeta{
+		UserID:      claims.UserID,
+		Permissions: claims.Permissions,
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Errors: map[string][]string{
+				"file": {"The file could not be read"},
+			},
+		})
+	}
+	defer file.Close()
+
+	attachment, res := h.service.UploadAttachment(infractionID, file, body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Errors:  res.ValidationErrors,
+		Payload: attachment,
+	})
+}
+
+func (h *attachmentHandler) GetInfractionAttachments(c echo.Context) error {
+	idString := c.Param("id")
+
+	infractionID, err := strconv.ParseInt(idString, 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	attachments, res := h.service.GetInfractionAttachments(infractionID)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Payload: attachments,
+	})
+}
+
+func (h *attachmentHandler) DownloadAttachment(c echo.Context) error {
+	infractionID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	attachmentID, err := strconv.ParseInt(c.Param("attachmentId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	attachment, file, res := h.service.GetAttachmentFile(infractionID, attachmentID)
+	if !res.Success {
+		return c.JSON(res.StatusCode, Response{
+			Success: res.Success,
+			Message: res.Message,
+		})
+	}
+	defer file.Close()
+
+	// Always send attachments as downloads so that uploaded files are never rendered by the dashboard's origin
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", attachment.FileName))
+	c.Response().Header().Set("X-Content-Type-Options", "nosniff")
+
+	return c.Stream(http.StatusOK, attachment.ContentType, file)
+}
+
+func (h *attachmentHandler) DeleteAttachment(c echo.Context) error {
+	infractionID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	attachmentID, err := strconv.ParseInt(c.Param("attachmentId"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	claims := c.Get("claims").(*jwt.Claims)
+
+	res := h.service.DeleteAttachment(infractionID, attachmentID, params.UserMeta{
+		UserID:      claims.UserID,
+		Permissions: claims.Permissions,
+	})
+
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+	})
+}
@@ -0,0 +1,84 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package api
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"strconv"
+)
+
+type banImportHandler struct {
+	service refractor.BanImportService
+}
+
+func NewBanImportHandler(service refractor.BanImportService) refractor.BanImportHandler {
+	return &banImportHandler{
+		service: service,
+	}
+}
+
+func (h *banImportHandler) ImportBans(c echo.Context) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Errors: map[string][]string{
+				"file": {"A file is required"},
+			},
+		})
+	}
+
+	// An invalid server ID is left as 0 so that it is reported by validation
+	serverID, _ := strconv.ParseInt(c.FormValue("serverId"), 10, 32)
+
+	body := params.ImportBansParams{
+		Format:   c.FormValue("format"),
+		ServerID: serverID,
+		DryRun:   c.FormValue("dryRun") == "true",
+		Size:     fileHeader.Size,
+	}
+
+	if ok, validationErrors := body.Validate(); !ok {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Errors:  validationErrors,
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Errors: map[string][]string{
+				"file": {"The file could not be read"},
+			},
+		})
+	}
+	defer file.Close()
+
+	result, res := h.service.ImportBans(file, body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Errors:  res.ValidationErrors,
+		Payload: result,
+	})
+}
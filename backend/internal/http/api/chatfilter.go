@@ -0,0 +1,105 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package api
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"strconv"
+)
+
+type chatFilterHandler struct {
+	service refractor.ChatFilterService
+}
+
+func NewChatFilterHandler(service refractor.ChatFilterService) refractor.ChatFilterHandler {
+	return &chatFilterHandler{
+		service: service,
+	}
+}
+
+func (h *chatFilterHandler) CreateRule(c echo.Context) error {
+	body := params.CreateChatFilterRuleParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	rule, res := h.service.CreateRule(body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Errors:  res.ValidationErrors,
+		Payload: rule,
+	})
+}
+
+func (h *chatFilterHandler) GetAllRules(c echo.Context) error {
+	rules, res := h.service.GetAllRules()
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Payload: rules,
+	})
+}
+
+func (h *chatFilterHandler) UpdateRule(c echo.Context) error {
+	idString := c.Param("id")
+
+	ruleID, err := strconv.ParseInt(idString, 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	// Validate request body
+	body := params.UpdateChatFilterRuleParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	updatedRule, res := h.service.UpdateRule(ruleID, body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Errors:  res.ValidationErrors,
+		Payload: updatedRule,
+	})
+}
+
+func (h *chatFilterHandler) DeleteRule(c echo.Context) error {
+	idString := c.Param("id")
+
+	ruleID, err := strconv.ParseInt(idString, 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	res := h.service.DeleteRule(ruleID)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+	})
+}
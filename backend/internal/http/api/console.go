@@ -0,0 +1,137 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package api
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/jwt"
+	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"strconv"
+)
+
+type consoleHandler struct {
+	service refractor.RCONConsoleService
+}
+
+func NewConsoleHandler(service refractor.RCONConsoleService) refractor.RCONConsoleHandler {
+	return &consoleHandler{
+		service: service,
+	}
+}
+
+type commandLogResultPayload struct {
+	Results []*refractor.RCONCommandLog `json:"results"`
+	Count   int                         `json:"count"`
+}
+
+func (h *consoleHandler) ExecCommand(c echo.Context) error {
+	serverID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	body := params.RCONCommandParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	claims := c.Get("claims").(*jwt.Claims)
+
+	body.UserMeta = &params.UserMeta{
+		UserID:      claims.UserID,
+		Permissions: claims.Permissions,
+	}
+
+	commandLog, res := h.service.ExecCommand(serverID, body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Payload: commandLog,
+	})
+}
+
+func (h *consoleHandler) CreateRule(c echo.Context) error {
+	body := params.CreateRCONCommandRuleParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	rule, res := h.service.CreateRule(body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Errors:  res.ValidationErrors,
+		Payload: rule,
+	})
+}
+
+func (h *consoleHandler) GetAllRules(c echo.Context) error {
+	rules, res := h.service.GetAllRules()
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Payload: rules,
+	})
+}
+
+func (h *consoleHandler) DeleteRule(c echo.Context) error {
+	ruleID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	res := h.service.DeleteRule(ruleID)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+	})
+}
+
+func (h *consoleHandler) GetCommandLogs(c echo.Context) error {
+	serverID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	body := params.SearchParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	count, logs, res := h.service.GetCommandLogs(serverID, body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Payload: commandLogResultPayload{
+			Results: logs,
+			Count:   count,
+		},
+	})
+}
@@ -0,0 +1,101 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package api
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"strconv"
+)
+
+type discordHandler struct {
+	service refractor.DiscordService
+}
+
+func NewDiscordHandler(service refractor.DiscordService) refractor.DiscordHandler {
+	return &discordHandler{
+		service: service,
+	}
+}
+
+func (h *discordHandler) CreateChannel(c echo.Context) error {
+	body := params.CreateDiscordChannelParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	channel, res := h.service.CreateChannel(body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Errors:  res.ValidationErrors,
+		Payload: channel,
+	})
+}
+
+func (h *discordHandler) GetAllChannels(c echo.Context) error {
+	channels, res := h.service.GetAllChannels()
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Payload: channels,
+	})
+}
+
+func (h *discordHandler) UpdateChannel(c echo.Context) error {
+	channelID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	// Validate request body
+	body := params.UpdateDiscordChannelParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	updatedChannel, res := h.service.UpdateChannel(channelID, body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Errors:  res.ValidationErrors,
+		Payload: updatedChannel,
+	})
+}
+
+func (h *discordHandler) DeleteChannel(c echo.Context) error {
+	channelID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	res := h.service.DeleteChannel(channelID)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+	})
+}
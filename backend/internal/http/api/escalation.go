@@ -0,0 +1,104 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package api
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"strconv"
+)
+
+type escalationHandler struct {
+	service refractor.EscalationService
+}
+
+func NewEscalationHandler(service refractor.EscalationService) refractor.EscalationHandler {
+	return &escalationHandler{
+		service: service,
+	}
+}
+
+func (h *escalationHandler) CreatePolicy(c echo.Context) error {
+	body := params.CreateEscalationPolicyParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	policy, res := h.service.CreatePolicy(body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Errors:  res.ValidationErrors,
+		Payload: policy,
+	})
+}
+
+func (h *escalationHandler) GetAllPolicies(c echo.Context) error {
+	policies, res := h.service.GetAllPolicies()
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Payload: policies,
+	})
+}
+
+func (h *escalationHandler) UpdatePolicy(c echo.Context) error {
+	idString := c.Param("id")
+
+	policyID, err := strconv.ParseInt(idString, 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	// Validate request body
+	body := params.UpdateEscalationPolicyParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	updatedPolicy, res := h.service.UpdatePolicy(policyID, body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Payload: updatedPolicy,
+	})
+}
+
+func (h *escalationHandler) DeletePolicy(c echo.Context) error {
+	idString := c.Param("id")
+
+	policyID, err := strconv.ParseInt(idString, 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	res := h.service.DeletePolicy(policyID)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+	})
+}
@@ -20,20 +20,27 @@ package api
 import (
 	"github.com/labstack/echo/v4"
 	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/broadcast"
 	"github.com/sniddunc/refractor/pkg/config"
 	"github.com/sniddunc/refractor/pkg/jwt"
+	"github.com/sniddunc/refractor/pkg/log"
 	"github.com/sniddunc/refractor/refractor"
 	"net/http"
 	"strconv"
 )
 
 type infractionHandler struct {
-	service refractor.InfractionService
+	service       refractor.InfractionService
+	playerService refractor.PlayerService
+	log           log.Logger
 }
 
-func NewInfractionHandler(service refractor.InfractionService) refractor.InfractionHandler {
+func NewInfractionHandler(service refractor.InfractionService, playerService refractor.PlayerService,
+	log log.Logger) refractor.InfractionHandler {
 	return &infractionHandler{
-		service: service,
+		service:       service,
+		playerService: playerService,
+		log:           log,
 	}
 }
 
@@ -105,6 +112,23 @@ func (h *infractionHandler) CreateBan(c echo.Context) error {
 	})
 }
 
+func (h *infractionHandler) CreateOfflineBan(c echo.Context) error {
+	body := params.CreateOfflineBanParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	claims := c.Get("claims").(*jwt.Claims)
+
+	ban, res := h.service.CreateOfflineBan(claims.UserID, body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Errors:  res.ValidationErrors,
+		Payload: ban,
+	})
+}
+
 func (h *infractionHandler) DeleteInfraction(c echo.Context) error {
 	idString := c.Param("id")
 
@@ -116,13 +140,92 @@ func (h *infractionHandler) DeleteInfraction(c echo.Context) error {
 		})
 	}
 
+	// Validate request body
+	body := params.DeleteInfractionParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
 	claims := c.Get("claims").(*jwt.Claims)
 
-	res := h.service.DeleteInfraction(infractionID, params.UserMeta{
+	body.UserMeta = &params.UserMeta{
+		UserID:      claims.UserID,
+		Permissions: claims.Permissions,
+	}
+
+	res := h.service.DeleteInfraction(infractionID, body)
+
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Errors:  res.ValidationErrors,
+	})
+}
+
+type deletedInfractionsPayload struct {
+	Results []*refractor.Infraction `json:"results"`
+	Count   int                     `json:"count"`
+}
+
+func (h *infractionHandler) GetDeletedInfractions(c echo.Context) error {
+	body := params.SearchParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	count, infractions, res := h.service.GetDeletedInfractions(body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Payload: deletedInfractionsPayload{
+			Results: infractions,
+			Count:   count,
+		},
+	})
+}
+
+func (h *infractionHandler) RestoreInfraction(c echo.Context) error {
+	idString := c.Param("id")
+
+	infractionID, err := strconv.ParseInt(idString, 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	claims := c.Get("claims").(*jwt.Claims)
+
+	restoredInfraction, res := h.service.RestoreInfraction(infractionID, params.UserMeta{
 		UserID:      claims.UserID,
 		Permissions: claims.Permissions,
 	})
 
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Payload: restoredInfraction,
+	})
+}
+
+func (h *infractionHandler) PurgeInfraction(c echo.Context) error {
+	idString := c.Param("id")
+
+	infractionID, err := strconv.ParseInt(idString, 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	claims := c.Get("claims").(*jwt.Claims)
+
+	res := h.service.PurgeInfraction(infractionID, params.UserMeta{
+		UserID:      claims.UserID,
+		Permissions: claims.Permissions,
+	})
 	return c.JSON(res.StatusCode, Response{
 		Success: res.Success,
 		Message: res.Message,
@@ -162,6 +265,58 @@ func (h *infractionHandler) UpdateInfraction(c echo.Context) error {
 	})
 }
 
+func (h *infractionHandler) RevokeInfraction(c echo.Context) error {
+	idString := c.Param("id")
+
+	infractionID, err := strconv.ParseInt(idString, 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	// Validate request body
+	body := params.RevokeInfractionParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	claims := c.Get("claims").(*jwt.Claims)
+
+	body.UserMeta = &params.UserMeta{
+		UserID:      claims.UserID,
+		Permissions: claims.Permissions,
+	}
+
+	revokedInfraction, res := h.service.RevokeInfraction(infractionID, body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Errors:  res.ValidationErrors,
+		Payload: revokedInfraction,
+	})
+}
+
+func (h *infractionHandler) GetInfractionHistory(c echo.Context) error {
+	idString := c.Param("id")
+
+	infractionID, err := strconv.ParseInt(idString, 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	revisions, res := h.service.GetInfractionHistory(infractionID)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Payload: revisions,
+	})
+}
+
 func (h *infractionHandler) GetPlayerInfractions(infractionType string) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		idString := c.Param("id")
@@ -191,3 +346,18 @@ func (h *infractionHandler) GetRecentInfractions(c echo.Context) error {
 		Payload: infractions,
 	})
 }
+
+func (h *infractionHandler) OnPlayerJoin(fields broadcast.Fields, serverID int64, gameConfig *refractor.GameConfig) {
+	playerGameID := gameConfig.PlayerGameIDField
+
+	player, res := h.playerService.GetPlayer(refractor.FindArgs{
+		playerGameID: fields[playerGameID],
+	})
+
+	if !res.Success {
+		h.log.Error("Could not get player by their PlayerGameID field. %v = %v", playerGameID, fields[playerGameID])
+		return
+	}
+
+	h.service.OnPlayerJoin(serverID, player)
+}
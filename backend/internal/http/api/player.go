@@ -19,8 +19,10 @@ package api
 
 import (
 	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
 	"github.com/sniddunc/refractor/pkg/broadcast"
 	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/jwt"
 	"github.com/sniddunc/refractor/refractor"
 	"net/http"
 	"strconv"
@@ -57,10 +59,31 @@ func (h *playerHandler) SwitchPlayerWatch(watch bool) echo.HandlerFunc {
 			})
 		}
 
-		res := h.service.SetPlayerWatch(playerID, watch)
+		if !watch {
+			res := h.service.UnwatchPlayer(playerID)
+			return c.JSON(res.StatusCode, Response{
+				Success: res.Success,
+				Message: res.Message,
+			})
+		}
+
+		// Validate request body
+		body := params.WatchPlayerParams{}
+		if ok := ValidateRequest(&body, c); !ok {
+			return nil
+		}
+
+		claims := c.Get("claims").(*jwt.Claims)
+		body.UserMeta = &params.UserMeta{
+			UserID:      claims.UserID,
+			Permissions: claims.Permissions,
+		}
+
+		res := h.service.WatchPlayer(playerID, body)
 		return c.JSON(res.StatusCode, Response{
 			Success: res.Success,
 			Message: res.Message,
+			Errors:  res.ValidationErrors,
 		})
 	}
 }
@@ -0,0 +1,139 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package api
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/jwt"
+	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"strconv"
+)
+
+type scheduleHandler struct {
+	service refractor.ScheduleService
+}
+
+func NewScheduleHandler(service refractor.ScheduleService) refractor.ScheduleHandler {
+	return &scheduleHandler{
+		service: service,
+	}
+}
+
+type scheduleRunResultPayload struct {
+	Results []*refractor.ScheduleRun `json:"results"`
+	Count   int                      `json:"count"`
+}
+
+func (h *scheduleHandler) CreateSchedule(c echo.Context) error {
+	body := params.CreateScheduleParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	claims := c.Get("claims").(*jwt.Claims)
+
+	body.UserMeta = &params.UserMeta{
+		UserID:      claims.UserID,
+		Permissions: claims.Permissions,
+	}
+
+	schedule, res := h.service.CreateSchedule(body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Errors:  res.ValidationErrors,
+		Payload: schedule,
+	})
+}
+
+func (h *scheduleHandler) GetAllSchedules(c echo.Context) error {
+	schedules, res := h.service.GetAllSchedules()
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Payload: schedules,
+	})
+}
+
+func (h *scheduleHandler) UpdateSchedule(c echo.Context) error {
+	scheduleID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	// Validate request body
+	body := params.UpdateScheduleParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	updatedSchedule, res := h.service.UpdateSchedule(scheduleID, body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Errors:  res.ValidationErrors,
+		Payload: updatedSchedule,
+	})
+}
+
+func (h *scheduleHandler) DeleteSchedule(c echo.Context) error {
+	scheduleID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	res := h.service.DeleteSchedule(scheduleID)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+	})
+}
+
+func (h *scheduleHandler) GetScheduleRuns(c echo.Context) error {
+	scheduleID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	body := params.SearchParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	count, runs, res := h.service.GetScheduleRuns(scheduleID, body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Payload: scheduleRunResultPayload{
+			Results: runs,
+			Count:   count,
+		},
+	})
+}
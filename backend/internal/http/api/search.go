@@ -78,3 +78,49 @@ func (h *searchHandler) SearchInfractions(c echo.Context) error {
 		},
 	})
 }
+
+type appealResultPayload struct {
+	Results []*refractor.Appeal `json:"results"`
+	Count   int                 `json:"count"`
+}
+
+func (h *searchHandler) SearchAppeals(c echo.Context) error {
+	body := params.SearchAppealsParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	count, appeals, res := h.service.SearchAppeals(body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Errors:  res.ValidationErrors,
+		Payload: appealResultPayload{
+			Results: appeals,
+			Count:   count,
+		},
+	})
+}
+
+type chatResultPayload struct {
+	Results []*refractor.ChatMessage `json:"results"`
+	Count   int                      `json:"count"`
+}
+
+func (h *searchHandler) SearchChat(c echo.Context) error {
+	body := params.SearchChatParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	count, messages, res := h.service.SearchChat(body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Errors:  res.ValidationErrors,
+		Payload: chatResultPayload{
+			Results: messages,
+			Count:   count,
+		},
+	})
+}
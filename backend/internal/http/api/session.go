@@ -0,0 +1,84 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package api
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"strconv"
+)
+
+type sessionHandler struct {
+	service refractor.SessionService
+}
+
+func NewSessionHandler(service refractor.SessionService) refractor.SessionHandler {
+	return &sessionHandler{
+		service: service,
+	}
+}
+
+type sessionResultPayload struct {
+	Results []*refractor.PlayerSession `json:"results"`
+	Count   int                        `json:"count"`
+}
+
+func (h *sessionHandler) GetPlayerSessions(c echo.Context) error {
+	playerID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	body := params.SearchParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	count, sessions, res := h.service.GetPlayerSessions(playerID, body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Payload: sessionResultPayload{
+			Results: sessions,
+			Count:   count,
+		},
+	})
+}
+
+func (h *sessionHandler) GetPlayerPlaytime(c echo.Context) error {
+	playerID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	playtime, res := h.service.GetPlayerPlaytime(playerID)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Payload: playtime,
+	})
+}
@@ -19,7 +19,9 @@ package api
 
 import (
 	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
 	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/jwt"
 	"github.com/sniddunc/refractor/refractor"
 	"net/http"
 	"strconv"
@@ -46,7 +48,13 @@ func (h *summaryHandler) GetPlayerSummary(c echo.Context) error {
 		})
 	}
 
-	summary, res := h.service.GetPlayerSummary(playerID)
+	claims := c.Get("claims").(*jwt.Claims)
+
+	summary, res := h.service.GetPlayerSummary(playerID, params.UserMeta{
+		UserID:      claims.UserID,
+		Permissions: claims.Permissions,
+	})
+
 	return c.JSON(res.StatusCode, Response{
 		Success: res.Success,
 		Message: res.Message,
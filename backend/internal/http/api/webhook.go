@@ -0,0 +1,137 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package api
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"strconv"
+)
+
+type webhookHandler struct {
+	service refractor.WebhookService
+}
+
+func NewWebhookHandler(service refractor.WebhookService) refractor.WebhookHandler {
+	return &webhookHandler{
+		service: service,
+	}
+}
+
+type webhookDeliveryResultPayload struct {
+	Results []*refractor.WebhookDelivery `json:"results"`
+	Count   int                          `json:"count"`
+}
+
+func (h *webhookHandler) CreateWebhook(c echo.Context) error {
+	body := params.CreateWebhookParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	webhook, res := h.service.CreateWebhook(body)
+
+	var payload interface{}
+	if webhook != nil {
+		payload = &refractor.NewWebhook{Webhook: webhook, Secret: webhook.Secret}
+	}
+
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Errors:  res.ValidationErrors,
+		Payload: payload,
+	})
+}
+
+func (h *webhookHandler) GetAllWebhooks(c echo.Context) error {
+	webhooks, res := h.service.GetAllWebhooks()
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Payload: webhooks,
+	})
+}
+
+func (h *webhookHandler) UpdateWebhook(c echo.Context) error {
+	webhookID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	// Validate request body
+	body := params.UpdateWebhookParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	updatedWebhook, res := h.service.UpdateWebhook(webhookID, body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Errors:  res.ValidationErrors,
+		Payload: updatedWebhook,
+	})
+}
+
+func (h *webhookHandler) DeleteWebhook(c echo.Context) error {
+	webhookID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	res := h.service.DeleteWebhook(webhookID)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+	})
+}
+
+func (h *webhookHandler) GetWebhookDeliveries(c echo.Context) error {
+	webhookID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{
+			Success: false,
+			Message: config.MessageInvalidIDProvided,
+		})
+	}
+
+	body := params.SearchParams{}
+	if ok := ValidateRequest(&body, c); !ok {
+		return nil
+	}
+
+	count, deliveries, res := h.service.GetWebhookDeliveries(webhookID, body)
+	return c.JSON(res.StatusCode, Response{
+		Success: res.Success,
+		Message: res.Message,
+		Payload: webhookDeliveryResultPayload{
+			Results: deliveries,
+			Count:   count,
+		},
+	})
+}
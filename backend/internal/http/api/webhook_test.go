@@ -0,0 +1,72 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/mock"
+	"github.com/sniddunc/refractor/internal/player"
+	"github.com/sniddunc/refractor/internal/webhook"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_webhookHandler_Secret(t *testing.T) {
+	logger, _ := log.NewLogger(true, false)
+	echoApp := echo.New()
+
+	playerService := player.NewPlayerService(mock.NewMockPlayerRepository(map[int64]*refractor.DBPlayer{}), logger)
+	webhookService := webhook.NewWebhookService(mock.NewMockWebhookRepository(map[int64]*refractor.Webhook{}),
+		mock.NewMockWebhookDeliveryRepository(map[int64]*refractor.WebhookDelivery{}), playerService, logger)
+	webhookHandler := NewWebhookHandler(webhookService)
+
+	body := `{"name": "Test", "url": "https://example.com/hook", "secret": "0123456789abcdef", "events": ["server.online"]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+
+	assert.NoError(t, webhookHandler.CreateWebhook(echoApp.NewContext(req, rec)), "CreateWebhook returned an error")
+	assert.Equal(t, http.StatusOK, rec.Code, "Webhook should have been created. Body: %s", rec.Body.String())
+
+	var created struct {
+		Payload map[string]interface{} `json:"payload"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created), "Could not unmarshal response")
+	assert.Equal(t, "0123456789abcdef", created.Payload["secret"], "The secret should be shown when the webhook is created")
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/webhooks", nil)
+	rec = httptest.NewRecorder()
+
+	assert.NoError(t, webhookHandler.GetAllWebhooks(echoApp.NewContext(req, rec)), "GetAllWebhooks returned an error")
+
+	var listed struct {
+		Payload []map[string]interface{} `json:"payload"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &listed), "Could not unmarshal response")
+	assert.Equal(t, 1, len(listed.Payload), "One webhook should be listed")
+
+	for _, listedWebhook := range listed.Payload {
+		assert.NotContains(t, listedWebhook, "secret", "Secrets should not be listed")
+	}
+}
@@ -0,0 +1,135 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package infraction
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/refractor"
+	"time"
+)
+
+// deliverWarning shows a warning to the player in-game if they are online on the server it was created on. Players
+// who are offline have the warning queued until they next join. It returns one of the DELIVERY_* statuses.
+func (s *infractionService) deliverWarning(warning *refractor.Infraction, player *refractor.Player,
+	server *refractor.Server) string {
+	if !s.isPlayerOnline(player, server) {
+		return refractor.DELIVERY_QUEUED
+	}
+
+	return s.sendWarning(warning, player, server)
+}
+
+// sendWarning sends a warning to the player using the game's private message command. Games which can't message a
+// single player fall back to a server wide message addressed to the player. It returns one of the DELIVERY_* statuses.
+func (s *infractionService) sendWarning(warning *refractor.Infraction, player *refractor.Player,
+	server *refractor.Server) string {
+	game, _ := s.gameService.GetGame(server.Game)
+	if game == nil {
+		s.log.Error("Could not deliver warning ID %d. Game %s was not found", warning.InfractionID, server.Game)
+		return refractor.DELIVERY_FAILED
+	}
+
+	args := refractor.CommandArgs{
+		PlayerID: getPlayerGameID(player, game.GetConfig()),
+		Message:  fmt.Sprintf("You have been warned. Reason: %s", warning.Reason),
+	}
+
+	command := game.GetPrivateMessageCommand(args)
+	if command == "" {
+		args.Message = fmt.Sprintf("%s has been warned. Reason: %s", player.CurrentName, warning.Reason)
+		command = game.GetBroadcastChatCommand(args)
+	}
+
+	if command == "" {
+		return refractor.DELIVERY_UNSUPPORTED
+	}
+
+	if _, err := s.rconService.ExecCommand(server.ServerID, command); err != nil {
+		s.log.Warn("Could not deliver warning ID %d on server ID %d. Error: %v", warning.InfractionID,
+			server.ServerID, err)
+		return refractor.DELIVERY_FAILED
+	}
+
+	s.log.Info("Warning ID %d was delivered to player ID %d on server ID %d", warning.InfractionID, player.PlayerID,
+		server.ServerID)
+
+	return refractor.DELIVERY_DELIVERED
+}
+
+// isPlayerOnline checks if a player is in the server's online player list
+func (s *infractionService) isPlayerOnline(player *refractor.Player, server *refractor.Server) bool {
+	serverData, _ := s.serverService.GetServerData(server.ServerID)
+	if serverData == nil || !serverData.Online {
+		return false
+	}
+
+	game, _ := s.gameService.GetGame(server.Game)
+	if game == nil {
+		return false
+	}
+
+	return serverData.OnlinePlayers[getPlayerGameID(player, game.GetConfig())] != nil
+}
+
+// deliverQueuedWarnings sends a player the warnings which were queued while they were offline, or which previously
+// failed to send, now that they have joined server.
+func (s *infractionService) deliverQueuedWarnings(warnings []*refractor.Infraction, player *refractor.Player,
+	server *refractor.Server) {
+	for _, warning := range warnings {
+		if warning.Revoked || warning.Deleted {
+			continue
+		}
+
+		if warning.Delivery != refractor.DELIVERY_QUEUED && warning.Delivery != refractor.DELIVERY_FAILED {
+			continue
+		}
+
+		delivery := s.sendWarning(warning, player, server)
+
+		if _, err := s.repo.Update(warning.InfractionID, getDeliveryUpdateArgs(delivery)); err != nil {
+			s.log.Error("Could not update delivery status of warning ID %d. Error: %v", warning.InfractionID, err)
+		}
+	}
+}
+
+// getDeliveryUpdateArgs returns the update args used to store a warning's delivery status
+func getDeliveryUpdateArgs(delivery string) refractor.UpdateArgs {
+	args := refractor.UpdateArgs{
+		"Delivery": delivery,
+	}
+
+	if delivery == refractor.DELIVERY_DELIVERED {
+		args["DeliveredAt"] = time.Now().Unix()
+	}
+
+	return args
+}
+
+// getDeliveryMessage returns the service response message to use for a newly created warning
+func getDeliveryMessage(delivery string) string {
+	switch delivery {
+	case refractor.DELIVERY_DELIVERED:
+		return "Warning created and delivered in-game"
+	case refractor.DELIVERY_QUEUED:
+		return "Warning created. The player is offline so it will be shown to them when they next join"
+	case refractor.DELIVERY_UNSUPPORTED:
+		return "Warning created. This game does not support delivering warnings in-game"
+	default:
+		return "Warning created, but it could not be delivered in-game. It will be sent when the player next joins"
+	}
+}
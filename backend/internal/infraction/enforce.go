@@ -0,0 +1,246 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package infraction
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/refractor"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// enforceInfraction runs the game's command for the infraction's type on the server the infraction was created on.
+// It returns one of the ENFORCEMENT_* statuses describing the outcome.
+func (s *infractionService) enforceInfraction(infraction *refractor.Infraction, player *refractor.Player,
+	server *refractor.Server) string {
+	return s.runGameCommand(infraction, player, server, func(game refractor.Game, args refractor.CommandArgs) string {
+		switch infraction.Type {
+		case refractor.INFRACTION_TYPE_WARNING:
+			return game.GetWarnCommand(args)
+		case refractor.INFRACTION_TYPE_MUTE:
+			return game.GetMuteCommand(args)
+		case refractor.INFRACTION_TYPE_KICK:
+			return game.GetKickCommand(args)
+		case refractor.INFRACTION_TYPE_BAN:
+			return game.GetBanCommand(args)
+		}
+
+		return ""
+	})
+}
+
+// liftInfraction runs the game's unban or unmute command for a ban or mute on the server the infraction was created on.
+// It returns one of the ENFORCEMENT_* statuses describing the outcome.
+func (s *infractionService) liftInfraction(infraction *refractor.Infraction, player *refractor.Player,
+	server *refractor.Server) string {
+	return s.runGameCommand(infraction, player, server, func(game refractor.Game, args refractor.CommandArgs) string {
+		switch infraction.Type {
+		case refractor.INFRACTION_TYPE_MUTE:
+			return game.GetUnmuteCommand(args)
+		case refractor.INFRACTION_TYPE_BAN:
+			return game.GetUnbanCommand(args)
+		}
+
+		return ""
+	})
+}
+
+// OnPlayerJoin re-applies a player's active ban or mute when they join a server. This keeps infractions in force on
+// games whose own ban list gets wiped or is not shared between servers. Players with an active ban are kicked with the
+// ban reason and remaining time, and players with an active mute are muted again for the time remaining. Players who
+// aren't banned are then sent any warnings which were queued while they were offline.
+func (s *infractionService) OnPlayerJoin(serverID int64, player *refractor.Player) {
+	if player == nil {
+		return
+	}
+
+	infractions, err := s.repo.FindManyByPlayerID(player.PlayerID)
+	if err != nil {
+		if err != refractor.ErrNotFound {
+			s.log.Error("Could not get infractions for joining player ID %d. Error: %v", player.PlayerID, err)
+		}
+
+		return
+	}
+
+	var warnings, mutes, bans []*refractor.Infraction
+
+	for _, infraction := range infractions {
+		switch infraction.Type {
+		case refractor.INFRACTION_TYPE_WARNING:
+			if infraction.Delivery == refractor.DELIVERY_QUEUED || infraction.Delivery == refractor.DELIVERY_FAILED {
+				warnings = append(warnings, infraction)
+			}
+		case refractor.INFRACTION_TYPE_MUTE:
+			mutes = append(mutes, infraction)
+		case refractor.INFRACTION_TYPE_BAN:
+			bans = append(bans, infraction)
+		}
+	}
+
+	activeBan := refractor.GetLongestActive(bans)
+	activeMute := refractor.GetLongestActive(mutes)
+
+	if activeBan == nil && activeMute == nil && len(warnings) == 0 {
+		return
+	}
+
+	server, _ := s.serverService.GetServerByID(serverID)
+	if server == nil {
+		s.log.Error("Could not enforce active infractions for player ID %d. Server ID %d could not be found",
+			player.PlayerID, serverID)
+		return
+	}
+
+	// If the player is banned there is no need to re-apply their mute since they'll be kicked anyways
+	if activeBan != nil {
+		enforcement := s.runGameCommand(activeBan, player, server, func(game refractor.Game, args refractor.CommandArgs) string {
+			args.Reason = getBanKickReason(activeBan)
+			return game.GetKickCommand(args)
+		})
+
+		s.log.Info("Kicked player ID %d from server ID %d because of active ban ID %d. Enforcement: %s",
+			player.PlayerID, serverID, activeBan.InfractionID, enforcement)
+		return
+	}
+
+	if activeMute != nil {
+		enforcement := s.runGameCommand(activeMute, player, server, func(game refractor.Game, args refractor.CommandArgs) string {
+			args.Duration = getMinutesRemaining(activeMute)
+			return game.GetMuteCommand(args)
+		})
+
+		s.log.Info("Re-applied active mute ID %d to player ID %d on server ID %d. Enforcement: %s",
+			activeMute.InfractionID, player.PlayerID, serverID, enforcement)
+	}
+
+	s.deliverQueuedWarnings(warnings, player, server)
+}
+
+// getBanKickReason builds the kick reason shown to a player who joins while they have an active ban.
+func getBanKickReason(ban *refractor.Infraction) string {
+	if ban.ExpiresAt == 0 {
+		return fmt.Sprintf("You are permanently banned. Reason: %s", ban.Reason)
+	}
+
+	return fmt.Sprintf("You are banned for another %s. Reason: %s", formatMinutes(getMinutesRemaining(ban)), ban.Reason)
+}
+
+// getMinutesRemaining returns the number of minutes, rounded up, until an active infraction expires. Permanent
+// infractions return 0 which matches the duration used to create them.
+func getMinutesRemaining(infraction *refractor.Infraction) int {
+	if infraction.ExpiresAt == 0 {
+		return 0
+	}
+
+	secondsRemaining := infraction.ExpiresAt - time.Now().Unix()
+	if secondsRemaining <= 0 {
+		return 1
+	}
+
+	return int((secondsRemaining + 59) / 60)
+}
+
+// formatMinutes formats a number of minutes in a human readable way, e.g. 1d 2h 30m.
+func formatMinutes(minutes int) string {
+	days := minutes / 1440
+	hours := (minutes % 1440) / 60
+	minutes = minutes % 60
+
+	var parts []string
+
+	if days > 0 {
+		parts = append(parts, fmt.Sprintf("%dd", days))
+	}
+
+	if hours > 0 {
+		parts = append(parts, fmt.Sprintf("%dh", hours))
+	}
+
+	if minutes > 0 || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%dm", minutes))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// commandBuilder returns the command to run for an infraction, or an empty string if the game does not support it.
+type commandBuilder func(game refractor.Game, args refractor.CommandArgs) string
+
+// runGameCommand builds a command for the infraction using buildCommand and runs it on the server through RCON.
+func (s *infractionService) runGameCommand(infraction *refractor.Infraction, player *refractor.Player,
+	server *refractor.Server, buildCommand commandBuilder) string {
+	game, _ := s.gameService.GetGame(server.Game)
+	if game == nil {
+		s.log.Error("Could not run command for infraction ID %d. Game %s was not found", infraction.InfractionID, server.Game)
+		return refractor.ENFORCEMENT_FAILED
+	}
+
+	command := buildCommand(game, refractor.CommandArgs{
+		PlayerID: getPlayerGameID(player, game.GetConfig()),
+		Reason:   infraction.Reason,
+		Duration: infraction.Duration,
+	})
+
+	// Games return an empty command if they don't support the action
+	if command == "" {
+		return refractor.ENFORCEMENT_UNSUPPORTED
+	}
+
+	if _, err := s.rconService.ExecCommand(server.ServerID, command); err != nil {
+		s.log.Warn("Could not run command for infraction ID %d on server ID %d. Error: %v", infraction.InfractionID,
+			server.ServerID, err)
+		return refractor.ENFORCEMENT_FAILED
+	}
+
+	s.log.Info("Command for infraction ID %d was run on server ID %d", infraction.InfractionID, server.ServerID)
+
+	return refractor.ENFORCEMENT_SUCCESS
+}
+
+// getEnforcementMessage returns the service response message to use for an infraction which was created with
+// enforcement requested.
+func getEnforcementMessage(enforcement string) string {
+	switch enforcement {
+	case refractor.ENFORCEMENT_SUCCESS:
+		return "Infraction created and enforced in-game"
+	case refractor.ENFORCEMENT_UNSUPPORTED:
+		return "Infraction created. This game does not support enforcing this infraction type in-game"
+	default:
+		return "Infraction created, but it could not be enforced in-game"
+	}
+}
+
+// getRevokeMessage returns the service response message to use once an infraction has been revoked.
+func getRevokeMessage(enforcement string) string {
+	switch enforcement {
+	case refractor.ENFORCEMENT_SUCCESS:
+		return "Infraction revoked and lifted in-game"
+	case refractor.ENFORCEMENT_UNSUPPORTED:
+		return "Infraction revoked. This game does not support lifting this infraction type in-game"
+	default:
+		return "Infraction revoked, but it could not be lifted in-game"
+	}
+}
+
+// getPlayerGameID uses reflection to get the value of the player's game specific identifier field.
+func getPlayerGameID(player *refractor.Player, gameConfig *refractor.GameConfig) string {
+	r := reflect.ValueOf(player)
+	return reflect.Indirect(r).FieldByName(gameConfig.PlayerGameIDField).String()
+}
@@ -25,9 +25,11 @@ import (
 	"github.com/sniddunc/refractor/pkg/config"
 	"github.com/sniddunc/refractor/pkg/log"
 	"github.com/sniddunc/refractor/pkg/perms"
+	"github.com/sniddunc/refractor/pkg/validation"
 	"github.com/sniddunc/refractor/refractor"
 	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -36,71 +38,170 @@ type infractionService struct {
 	playerService refractor.PlayerService
 	serverService refractor.ServerService
 	userService   refractor.UserService
+	rconService   refractor.RCONService
+	gameService   refractor.GameService
 	log           log.Logger
+
+	createSubscribers []refractor.InfractionSubscriber
+	updateSubscribers []refractor.InfractionSubscriber
+	deleteSubscribers []refractor.InfractionSubscriber
+	purgeSubscribers  []refractor.InfractionSubscriber
 }
 
 func NewInfractionService(repo refractor.InfractionRepository, playerService refractor.PlayerService,
-	serverService refractor.ServerService, userService refractor.UserService, log log.Logger) refractor.InfractionService {
+	serverService refractor.ServerService, userService refractor.UserService, rconService refractor.RCONService,
+	gameService refractor.GameService, log log.Logger) refractor.InfractionService {
 	return &infractionService{
 		repo:          repo,
 		playerService: playerService,
 		serverService: serverService,
 		userService:   userService,
+		rconService:   rconService,
+		gameService:   gameService,
 		log:           log,
+
+		createSubscribers: []refractor.InfractionSubscriber{},
+		updateSubscribers: []refractor.InfractionSubscriber{},
+		deleteSubscribers: []refractor.InfractionSubscriber{},
+		purgeSubscribers:  []refractor.InfractionSubscriber{},
 	}
 }
 
 func (s *infractionService) CreateWarning(userID int64, body params.CreateWarningParams) (*refractor.Infraction, *refractor.ServiceResponse) {
-	// Create nullable fields from values
-	duration := sql.NullInt32{}
-	reason := sql.NullString{String: body.Reason, Valid: true}
-
-	warning, res := s.createInfraction(body.PlayerID, userID, body.ServerID, refractor.INFRACTION_TYPE_WARNING, reason,
-		duration, time.Now().Unix(), false)
+	warning, res := s.createInfraction(&refractor.DBInfraction{
+		PlayerID: body.PlayerID,
+		UserID:   userID,
+		ServerID: body.ServerID,
+		Type:     refractor.INFRACTION_TYPE_WARNING,
+		Reason:   sql.NullString{String: body.Reason, Valid: true},
+	}, false)
 
 	return warning, res
 }
 
 func (s *infractionService) CreateMute(userID int64, body params.CreateMuteParams) (*refractor.Infraction, *refractor.ServiceResponse) {
-	// Create nullable fields from values
-	duration := sql.NullInt32{Int32: int32(body.Duration), Valid: true}
-	reason := sql.NullString{String: body.Reason, Valid: true}
-
-	mute, res := s.createInfraction(body.PlayerID, userID, body.ServerID, refractor.INFRACTION_TYPE_MUTE, reason,
-		duration, time.Now().Unix(), false)
+	mute, res := s.createInfraction(&refractor.DBInfraction{
+		PlayerID: body.PlayerID,
+		UserID:   userID,
+		ServerID: body.ServerID,
+		Type:     refractor.INFRACTION_TYPE_MUTE,
+		Reason:   sql.NullString{String: body.Reason, Valid: true},
+		Duration: sql.NullInt32{Int32: int32(body.Duration), Valid: true},
+	}, body.Enforce)
 
 	return mute, res
 }
 
 func (s *infractionService) CreateKick(userID int64, body params.CreateKickParams) (*refractor.Infraction, *refractor.ServiceResponse) {
-	// Create nullable fields from values
-	duration := sql.NullInt32{}
-	reason := sql.NullString{String: body.Reason, Valid: true}
-
-	kick, res := s.createInfraction(body.PlayerID, userID, body.ServerID, refractor.INFRACTION_TYPE_KICK, reason,
-		duration, time.Now().Unix(), false)
+	kick, res := s.createInfraction(&refractor.DBInfraction{
+		PlayerID: body.PlayerID,
+		UserID:   userID,
+		ServerID: body.ServerID,
+		Type:     refractor.INFRACTION_TYPE_KICK,
+		Reason:   sql.NullString{String: body.Reason, Valid: true},
+	}, body.Enforce)
 
 	return kick, res
 }
 
 func (s *infractionService) CreateBan(userID int64, body params.CreateBanParams) (*refractor.Infraction, *refractor.ServiceResponse) {
-	// Create nullable fields from values
-	duration := sql.NullInt32{Int32: int32(body.Duration), Valid: true}
-	reason := sql.NullString{String: body.Reason, Valid: true}
+	ban, res := s.createInfraction(&refractor.DBInfraction{
+		PlayerID: body.PlayerID,
+		UserID:   userID,
+		ServerID: body.ServerID,
+		Type:     refractor.INFRACTION_TYPE_BAN,
+		Reason:   sql.NullString{String: body.Reason, Valid: true},
+		Duration: sql.NullInt32{Int32: int32(body.Duration), Valid: true},
+	}, body.Enforce)
+
+	return ban, res
+}
+
+// CreateOfflineBan bans a player by their game ID so that players can be banned before they have ever joined one of our
+// servers. If no player with the game ID is stored yet, a placeholder player is created for the ban to belong to. The
+// placeholder is given the player's real name once they join.
+func (s *infractionService) CreateOfflineBan(userID int64, body params.CreateOfflineBanParams) (*refractor.Infraction, *refractor.ServiceResponse) {
+	game, _ := s.gameService.GetGame(body.Game)
+	if game == nil {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			ValidationErrors: url.Values{
+				"game": []string{"Invalid game"},
+			},
+		}
+	}
+
+	// Make sure the server exists and runs the game before a placeholder player is created
+	server, _ := s.serverService.GetServerByID(body.ServerID)
+	if server == nil {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			ValidationErrors: url.Values{
+				"serverId": []string{"Invalid server ID"},
+			},
+		}
+	}
+
+	if server.Game != game.GetName() {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			ValidationErrors: url.Values{
+				"serverId": []string{"This server does not run the selected game"},
+			},
+		}
+	}
+
+	// Players are looked up by an exact match on their game ID when they join, so the ID must be in the form the game
+	// reports or the ban would never be enforced.
+	playerGameID, valid := validation.NormalisePlayerGameID(game.GetConfig().PlayerGameIDField, body.PlayerGameID)
+	if !valid {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			ValidationErrors: url.Values{
+				"playerGameId": []string{"Invalid player game ID for this game"},
+			},
+		}
+	}
+
+	player, res := s.playerService.GetOrCreatePlaceholder(playerGameID, body.PlayerName, game.GetConfig())
+	if player == nil {
+		return nil, res
+	}
 
-	ban, res := s.createInfraction(body.PlayerID, userID, body.ServerID, refractor.INFRACTION_TYPE_BAN, reason,
-		duration, time.Now().Unix(), false)
+	ban, res := s.createInfraction(&refractor.DBInfraction{
+		PlayerID: player.PlayerID,
+		UserID:   userID,
+		ServerID: body.ServerID,
+		Type:     refractor.INFRACTION_TYPE_BAN,
+		Reason:   sql.NullString{String: body.Reason, Valid: true},
+		Duration: sql.NullInt32{Int32: int32(body.Duration), Valid: true},
+	}, body.Enforce)
 
 	return ban, res
 }
 
+// CreateSystemInfraction creates an infraction on behalf of Refractor itself rather than a staff member. The provided
+// infraction is marked as a system action before it is stored.
+func (s *infractionService) CreateSystemInfraction(newInfraction *refractor.DBInfraction, enforce bool) (*refractor.Infraction, *refractor.ServiceResponse) {
+	newInfraction.SystemAction = true
+
+	return s.createInfraction(newInfraction, enforce)
+}
+
 // We don't just make this function a member of the infraction service interface because there is a good chance we'll need to wrap
 // other code around this logic in the future. To avoid code repetition, the creation logic was moved into this function.
-func (s *infractionService) createInfraction(playerID int64, userID int64, serverID int64, infractionType string,
-	reason sql.NullString, duration sql.NullInt32, timestamp int64, systemAction bool) (*refractor.Infraction, *refractor.ServiceResponse) {
-
+//
+// If enforce is true, the game's matching command is run on the server through RCON once the infraction has been stored.
+// The outcome of this is stored on the infraction's Enforcement field. Warnings are always delivered to the player
+// in-game, or queued if they are offline, and the outcome is stored on the Delivery field. Create subscribers are
+// notified once the infraction has been stored, enforced and delivered.
+func (s *infractionService) createInfraction(newInfraction *refractor.DBInfraction, enforce bool) (*refractor.Infraction, *refractor.ServiceResponse) {
 	// Make sure player exists
-	player, _ := s.playerService.GetPlayerByID(playerID)
+	player, _ := s.playerService.GetPlayerByID(newInfraction.PlayerID)
 	if player == nil {
 		return nil, &refractor.ServiceResponse{
 			Success:    false,
@@ -112,7 +213,7 @@ func (s *infractionService) createInfraction(playerID int64, userID int64, serve
 	}
 
 	// Make sure server exists
-	server, _ := s.serverService.GetServerByID(serverID)
+	server, _ := s.serverService.GetServerByID(newInfraction.ServerID)
 	if server == nil {
 		return nil, &refractor.ServiceResponse{
 			Success:    false,
@@ -123,31 +224,111 @@ func (s *infractionService) createInfraction(playerID int64, userID int64, serve
 		}
 	}
 
-	newInfraction := &refractor.DBInfraction{
-		PlayerID:     playerID,
-		UserID:       userID,
-		ServerID:     serverID,
-		Type:         infractionType,
-		Reason:       reason,
-		Duration:     duration,
-		Timestamp:    timestamp,
-		SystemAction: systemAction,
+	if newInfraction.Timestamp == 0 {
+		newInfraction.Timestamp = time.Now().Unix()
 	}
 
+	newInfraction.Enforcement = refractor.ENFORCEMENT_NONE
+	newInfraction.Delivery = refractor.DELIVERY_NONE
+
 	infraction, err := s.repo.Create(newInfraction)
 	if err != nil {
 		s.log.Error("Could not create new infraction in repo. Error: %v", err)
 		return nil, refractor.InternalErrorResponse
 	}
 
+	message := "Infraction created"
+	updateArgs := refractor.UpdateArgs{}
+
+	// The infraction is stored before it is enforced or delivered so that a player is never punished in-game without
+	// a record of it existing in Refractor.
+	if enforce {
+		infraction.Enforcement = s.enforceInfraction(infraction, player, server)
+
+		updateArgs["Enforcement"] = infraction.Enforcement
+		message = getEnforcementMessage(infraction.Enforcement)
+	}
+
+	if infraction.Type == refractor.INFRACTION_TYPE_WARNING {
+		infraction.Delivery = s.deliverWarning(infraction, player, server)
+
+		for key, value := range getDeliveryUpdateArgs(infraction.Delivery) {
+			updateArgs[key] = value
+		}
+
+		if deliveredAt, ok := updateArgs["DeliveredAt"].(int64); ok {
+			infraction.DeliveredAt = deliveredAt
+		}
+
+		message = getDeliveryMessage(infraction.Delivery)
+	}
+
+	if len(updateArgs) > 0 {
+		updated, err := s.repo.Update(infraction.InfractionID, updateArgs)
+		if err != nil {
+			// By now the infraction has been stored and has already taken effect in-game, so creation still counts
+			// as a success. Only the stored status is out of date, so the in-memory status is returned instead.
+			s.log.Error("Could not update status of infraction ID %d. Error: %v", infraction.InfractionID, err)
+		} else {
+			infraction = updated
+		}
+	}
+
+	s.notifyCreate(infraction)
+
 	return infraction, &refractor.ServiceResponse{
 		Success:    true,
 		StatusCode: http.StatusOK,
-		Message:    "Infraction created",
+		Message:    message,
 	}
 }
 
-func (s *infractionService) DeleteInfraction(id int64, user params.UserMeta) *refractor.ServiceResponse {
+// GetInfractionByID gets an infraction which has not been deleted. Deleted infractions are treated as if they don't
+// exist so that they can't be appealed, commented on or otherwise used until they are restored.
+func (s *infractionService) GetInfractionByID(id int64) (*refractor.Infraction, *refractor.ServiceResponse) {
+	infraction, res := s.getInfractionIncludingDeleted(id)
+	if !res.Success {
+		return nil, res
+	}
+
+	if infraction.Deleted {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    config.MessageInvalidIDProvided,
+		}
+	}
+
+	return infraction, res
+}
+
+// getInfractionIncludingDeleted gets an infraction whether or not it has been deleted. It should only be used where
+// deleted infractions are expected, such as when restoring or purging them.
+func (s *infractionService) getInfractionIncludingDeleted(id int64) (*refractor.Infraction, *refractor.ServiceResponse) {
+	infraction, err := s.repo.FindByID(id)
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return nil, &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			}
+		}
+
+		s.log.Error("Could not get infraction by id %d. Error: %v", id, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	return infraction, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Infraction fetched",
+	}
+}
+
+// DeleteInfraction soft deletes an infraction. The infraction is kept so that it can be restored by an admin later on.
+func (s *infractionService) DeleteInfraction(id int64, body params.DeleteInfractionParams) *refractor.ServiceResponse {
+	user := body.UserMeta
 	userPerms := bitperms.PermissionValue(user.Permissions)
 
 	infraction, err := s.repo.FindByID(id)
@@ -184,12 +365,27 @@ func (s *infractionService) DeleteInfraction(id int64, user params.UserMeta) *re
 		}
 	}
 
-	// If the above statement didn't return, the user has permission. Delete infraction.
-	if err := s.repo.Delete(id); err != nil {
+	if infraction.Deleted {
+		return &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    "This infraction has already been deleted",
+		}
+	}
+
+	// If the above statements didn't return, the user has permission. Delete infraction.
+	deletedInfraction, err := s.repo.Update(id, refractor.UpdateArgs{
+		"DeletedBy":    user.UserID,
+		"DeletedAt":    time.Now().Unix(),
+		"DeleteReason": body.Reason,
+	})
+	if err != nil {
 		s.log.Error("Could not delete infraction ID %d. Error: %v", id, err)
 		return refractor.InternalErrorResponse
 	}
 
+	s.notifyDelete(deletedInfraction)
+
 	return &refractor.ServiceResponse{
 		Success:    true,
 		StatusCode: http.StatusOK,
@@ -197,6 +393,120 @@ func (s *infractionService) DeleteInfraction(id int64, user params.UserMeta) *re
 	}
 }
 
+func (s *infractionService) GetDeletedInfractions(body params.SearchParams) (int, []*refractor.Infraction, *refractor.ServiceResponse) {
+	count, infractions, err := s.repo.FindDeleted(body.Limit, body.Offset)
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return 0, []*refractor.Infraction{}, &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Fetched 0 deleted infractions",
+			}
+		}
+
+		s.log.Error("Could not get deleted infractions. Error: %v", err)
+		return 0, nil, refractor.InternalErrorResponse
+	}
+
+	if infractions == nil {
+		infractions = []*refractor.Infraction{}
+	}
+
+	// Get staff names
+	for _, infraction := range infractions {
+		user, err := s.userService.GetUserByID(infraction.UserID)
+		if err != nil {
+			s.log.Error("Could not get infraction user by ID. Error: %v", err)
+			continue
+		}
+
+		// Set StaffName to contain the staff member's username
+		infraction.StaffName = user.Username
+	}
+
+	return count, infractions, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    fmt.Sprintf("Fetched %d deleted infractions", len(infractions)),
+	}
+}
+
+func (s *infractionService) RestoreInfraction(id int64, user params.UserMeta) (*refractor.Infraction, *refractor.ServiceResponse) {
+	infraction, res := s.getInfractionIncludingDeleted(id)
+	if !res.Success {
+		return nil, res
+	}
+
+	if !infraction.Deleted {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    "This infraction has not been deleted",
+		}
+	}
+
+	restoredInfraction, err := s.repo.Update(id, refractor.UpdateArgs{
+		"DeletedBy":    nil,
+		"DeletedAt":    nil,
+		"DeleteReason": nil,
+	})
+	if err != nil {
+		s.log.Error("Could not restore infraction ID %d. Error: %v", id, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	s.log.Info("User ID %d restored deleted infraction ID %d", user.UserID, id)
+
+	s.notifyUpdate(restoredInfraction)
+
+	return restoredInfraction, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Infraction restored",
+	}
+}
+
+// PurgeInfraction permanently deletes an infraction. Only infractions which have already been deleted can be purged,
+// and only super admins can purge them. Users with full access are refused since purging can't be undone.
+func (s *infractionService) PurgeInfraction(id int64, user params.UserMeta) *refractor.ServiceResponse {
+	if !perms.UserIsSuperAdmin(bitperms.PermissionValue(user.Permissions)) {
+		return &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    config.MessageNoPermission,
+		}
+	}
+
+	infraction, res := s.getInfractionIncludingDeleted(id)
+	if !res.Success {
+		return res
+	}
+
+	if !infraction.Deleted {
+		return &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    "Only deleted infractions can be purged",
+		}
+	}
+
+	// Subscribers are notified before the infraction is removed so that they can clean up anything tied to it
+	s.notifyPurge(infraction)
+
+	if err := s.repo.Delete(id); err != nil {
+		s.log.Error("Could not purge infraction ID %d. Error: %v", id, err)
+		return refractor.InternalErrorResponse
+	}
+
+	s.log.Info("User ID %d purged infraction ID %d", user.UserID, id)
+
+	return &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Infraction purged",
+	}
+}
+
 func (s *infractionService) UpdateInfraction(id int64, body params.UpdateInfractionParams) (*refractor.Infraction, *refractor.ServiceResponse) {
 	// Make sure infraction exists
 	foundInfraction, err := s.repo.FindByID(id)
@@ -213,31 +523,20 @@ func (s *infractionService) UpdateInfraction(id int64, body params.UpdateInfract
 		return nil, refractor.InternalErrorResponse
 	}
 
-	userPerms := bitperms.PermissionValue(body.UserMeta.Permissions)
-
-	// We need to make sure that the user has permission to update this infraction.
-	// We do this by:
-	//   a) checking if they're a super admin, have full access or can edit any infraction.
-	//   b) checking if they created this infraction and if they have permission to edit their own infractions.
-
-	hasPermission := false
-
-	// Check if the user is a super admin, has full access or can edit any infraction
-	if perms.UserIsSuperAdmin(userPerms) || perms.UserHasFullAccess(userPerms) || userPerms.HasFlag(perms.EDIT_ANY_INFRACTION) {
-		hasPermission = true
-	}
-
-	// Check if the user created this infraction and has permission to edit their own infractions
-	if !hasPermission && foundInfraction.UserID == body.UserMeta.UserID && userPerms.HasFlag(perms.EDIT_OWN_INFRACTIONS) {
-		hasPermission = true
+	// Make sure the user has permission to update this infraction
+	if !body.UserMeta.CanEditInfraction(foundInfraction.UserID) {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    config.MessageNoPermission,
+		}
 	}
 
-	// Final check of hasPermission
-	if !hasPermission {
+	if foundInfraction.Deleted {
 		return nil, &refractor.ServiceResponse{
 			Success:    false,
 			StatusCode: http.StatusBadRequest,
-			Message:    config.MessageNoPermission,
+			Message:    "Deleted infractions cannot be edited",
 		}
 	}
 
@@ -254,6 +553,10 @@ func (s *infractionService) UpdateInfraction(id int64, body params.UpdateInfract
 		foundInfraction.Type == refractor.INFRACTION_TYPE_BAN {
 		if body.Duration != nil {
 			updateArgs["Duration"] = *body.Duration
+
+			// Clear the expired flag since the new duration may put the infraction back in force. If it doesn't,
+			// the expiry watchdog will mark it as expired again.
+			updateArgs["Expired"] = false
 		}
 	}
 
@@ -271,6 +574,22 @@ func (s *infractionService) UpdateInfraction(id int64, body params.UpdateInfract
 		return nil, refractor.InternalErrorResponse
 	}
 
+	// Record the edit in the infraction's history
+	if changes := getInfractionChanges(foundInfraction, updatedInfraction); len(changes) > 0 {
+		revision := &refractor.InfractionRevision{
+			InfractionID: updatedInfraction.InfractionID,
+			UserID:       body.UserMeta.UserID,
+			Timestamp:    time.Now().Unix(),
+			Changes:      changes,
+		}
+
+		if err := s.repo.CreateRevision(revision); err != nil {
+			s.log.Error("Could not store revision of infraction ID %d. Error: %v", id, err)
+		}
+	}
+
+	s.notifyUpdate(updatedInfraction)
+
 	return updatedInfraction, &refractor.ServiceResponse{
 		Success:    true,
 		StatusCode: http.StatusOK,
@@ -278,6 +597,179 @@ func (s *infractionService) UpdateInfraction(id int64, body params.UpdateInfract
 	}
 }
 
+// getInfractionChanges compares the editable fields of an infraction before and after an update.
+func getInfractionChanges(before *refractor.Infraction, after *refractor.Infraction) []*refractor.InfractionChange {
+	var changes []*refractor.InfractionChange
+
+	if before.Reason != after.Reason {
+		changes = append(changes, &refractor.InfractionChange{
+			Field:    "reason",
+			OldValue: before.Reason,
+			NewValue: after.Reason,
+		})
+	}
+
+	if before.Duration != after.Duration {
+		changes = append(changes, &refractor.InfractionChange{
+			Field:    "duration",
+			OldValue: strconv.Itoa(before.Duration),
+			NewValue: strconv.Itoa(after.Duration),
+		})
+	}
+
+	return changes
+}
+
+func (s *infractionService) GetInfractionHistory(id int64) ([]*refractor.InfractionRevision, *refractor.ServiceResponse) {
+	// Make sure infraction exists
+	if _, res := s.GetInfractionByID(id); !res.Success {
+		return nil, res
+	}
+
+	revisions, err := s.repo.FindRevisions(id)
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return []*refractor.InfractionRevision{}, &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Fetched 0 revisions",
+			}
+		}
+
+		s.log.Error("Could not get revisions of infraction ID %d. Error: %v", id, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	if revisions == nil {
+		revisions = []*refractor.InfractionRevision{}
+	}
+
+	// Get editor names
+	for _, revision := range revisions {
+		user, err := s.userService.GetUserByID(revision.UserID)
+		if err != nil {
+			s.log.Error("Could not get revision editor by ID. Error: %v", err)
+			continue
+		}
+
+		revision.EditorName = user.Username
+	}
+
+	return revisions, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    fmt.Sprintf("Fetched %d revisions", len(revisions)),
+	}
+}
+
+func (s *infractionService) RevokeInfraction(id int64, body params.RevokeInfractionParams) (*refractor.Infraction, *refractor.ServiceResponse) {
+	// Make sure infraction exists
+	foundInfraction, err := s.repo.FindByID(id)
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return nil, &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			}
+		}
+
+		s.log.Error("Could not get infraction by id %d. Error: %v", id, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	// Revoking is the same as editing the infraction as far as permissions are concerned
+	if !body.UserMeta.CanEditInfraction(foundInfraction.UserID) {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    config.MessageNoPermission,
+		}
+	}
+
+	return s.revokeInfraction(foundInfraction, body.UserMeta.UserID, body.Reason)
+}
+
+// RevokeInfractionAsSystem revokes an infraction on behalf of Refractor itself rather than a staff member, such as when
+// an appeal is accepted. The user's permissions are not checked, so callers must make sure the revoke was authorised.
+// The revoke is attributed to revokedBy.
+func (s *infractionService) RevokeInfractionAsSystem(id int64, revokedBy int64, reason string) (*refractor.Infraction, *refractor.ServiceResponse) {
+	foundInfraction, err := s.repo.FindByID(id)
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return nil, &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			}
+		}
+
+		s.log.Error("Could not get infraction by id %d. Error: %v", id, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	return s.revokeInfraction(foundInfraction, revokedBy, reason)
+}
+
+// revokeInfraction revokes a ban or mute and lifts it in-game. Permission checks are left to the caller.
+func (s *infractionService) revokeInfraction(foundInfraction *refractor.Infraction, revokedBy int64, reason string) (*refractor.Infraction, *refractor.ServiceResponse) {
+	id := foundInfraction.InfractionID
+
+	if foundInfraction.Type != refractor.INFRACTION_TYPE_MUTE && foundInfraction.Type != refractor.INFRACTION_TYPE_BAN {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    "Only bans and mutes can be revoked",
+		}
+	}
+
+	if foundInfraction.Revoked {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    "This infraction has already been revoked",
+		}
+	}
+
+	if foundInfraction.Deleted {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    "Deleted infractions cannot be revoked",
+		}
+	}
+
+	revokedInfraction, err := s.repo.Update(foundInfraction.InfractionID, refractor.UpdateArgs{
+		"RevokedBy":    revokedBy,
+		"RevokedAt":    time.Now().Unix(),
+		"RevokeReason": reason,
+	})
+	if err != nil {
+		s.log.Error("Could not revoke infraction with id %d. Error: %v", id, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	// Lift the ban or mute in-game
+	enforcement := refractor.ENFORCEMENT_FAILED
+
+	player, _ := s.playerService.GetPlayerByID(revokedInfraction.PlayerID)
+	server, _ := s.serverService.GetServerByID(revokedInfraction.ServerID)
+
+	if player != nil && server != nil {
+		enforcement = s.liftInfraction(revokedInfraction, player, server)
+	} else {
+		s.log.Warn("Could not lift revoked infraction ID %d in-game. Player or server could not be found", id)
+	}
+
+	s.notifyUpdate(revokedInfraction)
+
+	return revokedInfraction, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    getRevokeMessage(enforcement),
+	}
+}
+
 func (s *infractionService) GetPlayerInfractionsType(infractionType string, playerID int64) ([]*refractor.Infraction, *refractor.ServiceResponse) {
 	infractions, err := s.repo.FindMany(refractor.FindArgs{
 		"PlayerID": playerID,
@@ -370,3 +862,81 @@ func (s *infractionService) GetRecentInfractions(count int) ([]*refractor.Infrac
 		Message:    fmt.Sprintf("Fetched %d recent infractions", len(infractions)),
 	}
 }
+
+// ExpireInfractions marks all mutes and bans which have run out as expired and returns them.
+func (s *infractionService) ExpireInfractions() ([]*refractor.Infraction, *refractor.ServiceResponse) {
+	foundInfractions, err := s.repo.FindExpired(time.Now().Unix())
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return []*refractor.Infraction{}, &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Expired 0 infractions",
+			}
+		}
+
+		s.log.Error("Could not find expired infractions. Error: %v", err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	expiredInfractions := []*refractor.Infraction{}
+
+	for _, infraction := range foundInfractions {
+		expiredInfraction, err := s.repo.Update(infraction.InfractionID, refractor.UpdateArgs{
+			"Expired": true,
+		})
+		if err != nil {
+			s.log.Error("Could not mark infraction ID %d as expired. Error: %v", infraction.InfractionID, err)
+			continue
+		}
+
+		expiredInfractions = append(expiredInfractions, expiredInfraction)
+		s.notifyUpdate(expiredInfraction)
+	}
+
+	return expiredInfractions, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    fmt.Sprintf("Expired %d infractions", len(expiredInfractions)),
+	}
+}
+
+func (s *infractionService) SubscribeCreate(subscriber refractor.InfractionSubscriber) {
+	s.createSubscribers = append(s.createSubscribers, subscriber)
+}
+
+func (s *infractionService) notifyCreate(created *refractor.Infraction) {
+	for _, sub := range s.createSubscribers {
+		sub(created)
+	}
+}
+
+func (s *infractionService) SubscribeUpdate(subscriber refractor.InfractionSubscriber) {
+	s.updateSubscribers = append(s.updateSubscribers, subscriber)
+}
+
+func (s *infractionService) notifyUpdate(updated *refractor.Infraction) {
+	for _, sub := range s.updateSubscribers {
+		sub(updated)
+	}
+}
+
+func (s *infractionService) SubscribeDelete(subscriber refractor.InfractionSubscriber) {
+	s.deleteSubscribers = append(s.deleteSubscribers, subscriber)
+}
+
+func (s *infractionService) notifyDelete(deleted *refractor.Infraction) {
+	for _, sub := range s.deleteSubscribers {
+		sub(deleted)
+	}
+}
+
+func (s *infractionService) SubscribePurge(subscriber refractor.InfractionSubscriber) {
+	s.purgeSubscribers = append(s.purgeSubscribers, subscriber)
+}
+
+func (s *infractionService) notifyPurge(purged *refractor.Infraction) {
+	for _, sub := range s.purgeSubscribers {
+		sub(purged)
+	}
+}
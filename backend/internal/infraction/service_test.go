@@ -19,6 +19,8 @@ package infraction
 
 import (
 	"database/sql"
+	"errors"
+	"github.com/sniddunc/refractor/internal/game"
 	"github.com/sniddunc/refractor/internal/mock"
 	"github.com/sniddunc/refractor/internal/params"
 	"github.com/sniddunc/refractor/internal/player"
@@ -32,6 +34,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 )
 
 func Test_infractionService_CreateWarning(t *testing.T) {
@@ -40,6 +43,7 @@ func Test_infractionService_CreateWarning(t *testing.T) {
 	type fields struct {
 		mockPlayers map[int64]*refractor.DBPlayer
 		mockServers map[int64]*refractor.Server
+		online      bool
 	}
 	type args struct {
 		userID int64
@@ -51,6 +55,8 @@ func Test_infractionService_CreateWarning(t *testing.T) {
 		args           args
 		wantInfraction *refractor.Infraction
 		wantRes        *refractor.ServiceResponse
+		wantDelivery   string
+		wantCommands   []string
 	}{
 		{
 			name: "infraction.createwarning.1",
@@ -82,6 +88,168 @@ func Test_infractionService_CreateWarning(t *testing.T) {
 				Type:         refractor.INFRACTION_TYPE_WARNING,
 				Reason:       "Test warning reason",
 			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Warning created. The player is offline so it will be shown to them when they next join",
+			},
+			wantDelivery: refractor.DELIVERY_QUEUED,
+			wantCommands: nil,
+		},
+		{
+			name: "infraction.createwarning.2",
+			fields: fields{
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+				},
+				online: true,
+			},
+			args: args{
+				userID: 1,
+				body: params.CreateWarningParams{
+					PlayerID: 1,
+					ServerID: 1,
+					Reason:   "Test warning reason",
+				},
+			},
+			wantInfraction: &refractor.Infraction{
+				InfractionID: 1,
+				PlayerID:     1,
+				UserID:       1,
+				ServerID:     1,
+				Type:         refractor.INFRACTION_TYPE_WARNING,
+				Reason:       "Test warning reason",
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Warning created and delivered in-game",
+			},
+			wantDelivery: refractor.DELIVERY_DELIVERED,
+			wantCommands: []string{"mockwhisper ABCDEF You have been warned. Reason: Test warning reason"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPlayerRepo := mock.NewMockPlayerRepository(tt.fields.mockPlayers)
+			playerService := player.NewPlayerService(mockPlayerRepo, testLogger)
+			gameService := game.NewGameService()
+			gameService.AddGame(mock.NewMockGame())
+			mockServerRepo := mock.NewMockServerRepository(tt.fields.mockServers)
+			serverService := server.NewServerService(mockServerRepo, gameService, testLogger)
+			rconService := mock.NewMockRCONService(1)
+			mockInfractionRepo := mock.NewMockInfractionRepository(map[int64]*refractor.DBInfraction{})
+			infractionService := NewInfractionService(mockInfractionRepo, playerService, serverService, nil, rconService,
+				gameService, testLogger)
+
+			if tt.fields.online {
+				onlinePlayer, _ := playerService.GetPlayerByID(tt.args.body.PlayerID)
+
+				serverService.CreateServerData(tt.args.body.ServerID, "TestGame")
+				serverService.OnServerOnline(tt.args.body.ServerID)
+				serverService.OnPlayerJoin(tt.args.body.ServerID, onlinePlayer)
+			}
+
+			warning, res := infractionService.CreateWarning(tt.args.userID, tt.args.body)
+
+			assert.True(t, infractionsAreEqual(tt.wantInfraction, warning), "Infractions were not equal\nWant = %v\nGot  = %v", tt.wantInfraction, warning)
+			assert.True(t, tt.wantRes.Equals(res), "tt.wantRes = %v and res = %v should be equal", tt.wantRes, res)
+			assert.Equal(t, tt.wantDelivery, warning.Delivery, "Delivery statuses should be equal")
+			assert.Equal(t, tt.wantCommands, rconService.Commands[tt.args.body.ServerID], "Executed commands should be equal")
+		})
+	}
+}
+
+func Test_infractionService_CreateBan(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	type fields struct {
+		connectedServers []int64
+	}
+	type args struct {
+		userID int64
+		body   params.CreateBanParams
+	}
+	tests := []struct {
+		name            string
+		fields          fields
+		args            args
+		wantEnforcement string
+		wantCommands    []string
+		wantRes         *refractor.ServiceResponse
+	}{
+		{
+			name: "infraction.createban.1",
+			fields: fields{
+				connectedServers: []int64{1},
+			},
+			args: args{
+				userID: 1,
+				body: params.CreateBanParams{
+					PlayerID: 1,
+					ServerID: 1,
+					Reason:   "Test ban reason",
+					Duration: 60,
+					Enforce:  true,
+				},
+			},
+			wantEnforcement: refractor.ENFORCEMENT_SUCCESS,
+			wantCommands:    []string{"mockban"},
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Infraction created and enforced in-game",
+			},
+		},
+		{
+			name: "infraction.createban.2",
+			fields: fields{
+				connectedServers: []int64{},
+			},
+			args: args{
+				userID: 1,
+				body: params.CreateBanParams{
+					PlayerID: 1,
+					ServerID: 1,
+					Reason:   "Test ban reason",
+					Duration: 60,
+					Enforce:  true,
+				},
+			},
+			wantEnforcement: refractor.ENFORCEMENT_FAILED,
+			wantCommands:    nil,
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Infraction created, but it could not be enforced in-game",
+			},
+		},
+		{
+			name: "infraction.createban.3",
+			fields: fields{
+				connectedServers: []int64{1},
+			},
+			args: args{
+				userID: 1,
+				body: params.CreateBanParams{
+					PlayerID: 1,
+					ServerID: 1,
+					Reason:   "Test ban reason",
+					Duration: 60,
+					Enforce:  false,
+				},
+			},
+			wantEnforcement: refractor.ENFORCEMENT_NONE,
+			wantCommands:    nil,
 			wantRes: &refractor.ServiceResponse{
 				Success:    true,
 				StatusCode: http.StatusOK,
@@ -91,21 +259,559 @@ func Test_infractionService_CreateWarning(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockPlayerRepo := mock.NewMockPlayerRepository(tt.fields.mockPlayers)
+			mockPlayerRepo := mock.NewMockPlayerRepository(map[int64]*refractor.DBPlayer{
+				1: {
+					PlayerID:  1,
+					PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+				},
+			})
+			playerService := player.NewPlayerService(mockPlayerRepo, testLogger)
+			mockServerRepo := mock.NewMockServerRepository(map[int64]*refractor.Server{
+				1: {
+					ServerID: 1,
+					Game:     "TestGame",
+				},
+			})
+			serverService := server.NewServerService(mockServerRepo, nil, testLogger)
+			gameService := game.NewGameService()
+			gameService.AddGame(mock.NewMockGame())
+			rconService := mock.NewMockRCONService(tt.fields.connectedServers...)
+			mockInfractionRepo := mock.NewMockInfractionRepository(map[int64]*refractor.DBInfraction{})
+			infractionService := NewInfractionService(mockInfractionRepo, playerService, serverService, nil, rconService,
+				gameService, testLogger)
+
+			ban, res := infractionService.CreateBan(tt.args.userID, tt.args.body)
+
+			assert.NotNil(t, ban, "Ban should not be nil")
+			assert.Equal(t, tt.wantEnforcement, ban.Enforcement, "Enforcement statuses should be equal")
+			assert.Equal(t, tt.wantCommands, rconService.Commands[tt.args.body.ServerID], "Executed commands should be equal")
+			assert.True(t, tt.wantRes.Equals(res), "tt.wantRes = %v and res = %v should be equal", tt.wantRes, res)
+		})
+	}
+}
+
+// failingUpdateRepo is an infraction repository whose updates always fail
+type failingUpdateRepo struct {
+	refractor.InfractionRepository
+}
+
+func (r *failingUpdateRepo) Update(id int64, args refractor.UpdateArgs) (*refractor.Infraction, error) {
+	return nil, errors.New("update failed")
+}
+
+func Test_infractionService_CreateBan_UpdateFails(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	mockPlayerRepo := mock.NewMockPlayerRepository(map[int64]*refractor.DBPlayer{
+		1: {
+			PlayerID:  1,
+			PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+		},
+	})
+	playerService := player.NewPlayerService(mockPlayerRepo, testLogger)
+	mockServerRepo := mock.NewMockServerRepository(map[int64]*refractor.Server{
+		1: {
+			ServerID: 1,
+			Game:     "TestGame",
+		},
+	})
+	serverService := server.NewServerService(mockServerRepo, nil, testLogger)
+	gameService := game.NewGameService()
+	gameService.AddGame(mock.NewMockGame())
+	rconService := mock.NewMockRCONService(1)
+	infractionRepo := &failingUpdateRepo{mock.NewMockInfractionRepository(map[int64]*refractor.DBInfraction{})}
+	infractionService := NewInfractionService(infractionRepo, playerService, serverService, nil, rconService,
+		gameService, testLogger)
+
+	var notified *refractor.Infraction
+	infractionService.SubscribeCreate(func(infraction *refractor.Infraction) {
+		notified = infraction
+	})
+
+	ban, res := infractionService.CreateBan(1, params.CreateBanParams{
+		PlayerID: 1,
+		ServerID: 1,
+		Reason:   "Test ban reason",
+		Duration: 60,
+		Enforce:  true,
+	})
+
+	assert.True(t, res.Success, "Creation should succeed since the ban was stored and enforced. Message: %s", res.Message)
+	assert.NotNil(t, ban, "Ban should not be nil")
+	assert.Equal(t, refractor.ENFORCEMENT_SUCCESS, ban.Enforcement, "The in-memory enforcement status should be returned")
+	assert.Equal(t, []string{"mockban"}, rconService.Commands[1], "Executed commands should be equal")
+	assert.Equal(t, ban, notified, "Create subscribers should have been notified")
+}
+
+func Test_infractionService_CreateOfflineBan(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	type args struct {
+		userID int64
+		body   params.CreateOfflineBanParams
+	}
+	tests := []struct {
+		name            string
+		args            args
+		wantPlayerID    int64
+		wantPlaceholder *refractor.DBPlayer
+		wantRes         *refractor.ServiceResponse
+	}{
+		{
+			name: "infraction.createofflineban.1",
+			args: args{
+				userID: 1,
+				body: params.CreateOfflineBanParams{
+					Game:         "TestGame",
+					PlayerGameID: "0123456789ABCDEF",
+					PlayerName:   "Cheater",
+					ServerID:     1,
+					Reason:       "Test ban reason",
+				},
+			},
+			wantPlayerID: 2,
+			wantPlaceholder: &refractor.DBPlayer{
+				PlayerID:    2,
+				PlayFabID:   sql.NullString{String: "0123456789ABCDEF", Valid: true},
+				CurrentName: "Cheater",
+				Placeholder: true,
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Infraction created",
+			},
+		},
+		{
+			name: "infraction.createofflineban.2",
+			args: args{
+				userID: 1,
+				body: params.CreateOfflineBanParams{
+					Game:         "TestGame",
+					PlayerGameID: "0123456789ABCDEF",
+					ServerID:     1,
+					Reason:       "Test ban reason",
+				},
+			},
+			wantPlayerID: 2,
+			wantPlaceholder: &refractor.DBPlayer{
+				PlayerID:    2,
+				PlayFabID:   sql.NullString{String: "0123456789ABCDEF", Valid: true},
+				CurrentName: "0123456789ABCDEF",
+				Placeholder: true,
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Infraction created",
+			},
+		},
+		{
+			name: "infraction.createofflineban.3",
+			args: args{
+				userID: 1,
+				body: params.CreateOfflineBanParams{
+					Game:         "TestGame",
+					PlayerGameID: "abcdef1234567890",
+					PlayerName:   "Cheater",
+					ServerID:     1,
+					Reason:       "Test ban reason",
+				},
+			},
+			wantPlayerID:    1,
+			wantPlaceholder: nil,
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Infraction created",
+			},
+		},
+		{
+			name: "infraction.createofflineban.4",
+			args: args{
+				userID: 1,
+				body: params.CreateOfflineBanParams{
+					Game:         "UnknownGame",
+					PlayerGameID: "0123456789ABCDEF",
+					ServerID:     1,
+					Reason:       "Test ban reason",
+				},
+			},
+			wantPlaceholder: nil,
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "infraction.createofflineban.5",
+			args: args{
+				userID: 1,
+				body: params.CreateOfflineBanParams{
+					Game:         "TestGame",
+					PlayerGameID: "0123456789ABCDEF",
+					ServerID:     2,
+					Reason:       "Test ban reason",
+				},
+			},
+			wantPlaceholder: nil,
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "infraction.createofflineban.6",
+			args: args{
+				userID: 1,
+				body: params.CreateOfflineBanParams{
+					Game:         "TestGame",
+					PlayerGameID: "0123456789abcdef",
+					ServerID:     1,
+					Reason:       "Test ban reason",
+				},
+			},
+			wantPlayerID: 2,
+			wantPlaceholder: &refractor.DBPlayer{
+				PlayerID:    2,
+				PlayFabID:   sql.NullString{String: "0123456789ABCDEF", Valid: true},
+				CurrentName: "0123456789ABCDEF",
+				Placeholder: true,
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Infraction created",
+			},
+		},
+		{
+			name: "infraction.createofflineban.7",
+			args: args{
+				userID: 1,
+				body: params.CreateOfflineBanParams{
+					Game:         "TestGame",
+					PlayerGameID: "NEWPLAYER",
+					ServerID:     1,
+					Reason:       "Test ban reason",
+				},
+			},
+			wantPlaceholder: nil,
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPlayers := map[int64]*refractor.DBPlayer{
+				1: {
+					PlayerID:    1,
+					PlayFabID:   sql.NullString{String: "ABCDEF1234567890", Valid: true},
+					CurrentName: "Existing",
+				},
+			}
+			mockPlayerRepo := mock.NewMockPlayerRepository(mockPlayers)
+			playerService := player.NewPlayerService(mockPlayerRepo, testLogger)
+			mockServerRepo := mock.NewMockServerRepository(map[int64]*refractor.Server{
+				1: {
+					ServerID: 1,
+					Game:     "TestGame",
+				},
+				2: {
+					ServerID: 2,
+					Game:     "OtherGame",
+				},
+			})
+			serverService := server.NewServerService(mockServerRepo, nil, testLogger)
+			gameService := game.NewGameService()
+			gameService.AddGame(mock.NewMockGame())
+			mockInfractionRepo := mock.NewMockInfractionRepository(map[int64]*refractor.DBInfraction{})
+			infractionService := NewInfractionService(mockInfractionRepo, playerService, serverService, nil,
+				mock.NewMockRCONService(), gameService, testLogger)
+
+			ban, res := infractionService.CreateOfflineBan(tt.args.userID, tt.args.body)
+
+			assert.True(t, tt.wantRes.Equals(res), "tt.wantRes = %v and res = %v should be equal", tt.wantRes, res)
+
+			if !tt.wantRes.Success {
+				assert.Nil(t, ban, "Ban should be nil")
+				assert.Equal(t, 1, len(mockPlayers), "No placeholder player should have been created")
+				return
+			}
+
+			assert.NotNil(t, ban, "Ban should not be nil")
+			assert.Equal(t, tt.wantPlayerID, ban.PlayerID, "Ban should belong to the right player")
+			assert.Equal(t, refractor.INFRACTION_TYPE_BAN, ban.Type, "Infraction should be a ban")
+
+			if tt.wantPlaceholder != nil {
+				assert.Equal(t, tt.wantPlaceholder, mockPlayers[tt.wantPlaceholder.PlayerID], "Placeholder players should be equal")
+			} else {
+				assert.Equal(t, 1, len(mockPlayers), "No placeholder player should have been created")
+			}
+		})
+	}
+}
+
+func Test_infractionService_RevokeInfraction(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	type fields struct {
+		mockInfractions  map[int64]*refractor.DBInfraction
+		connectedServers []int64
+	}
+	type args struct {
+		id       int64
+		userMeta *params.UserMeta
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		args         args
+		wantRevoked  bool
+		wantCommands []string
+		wantRes      *refractor.ServiceResponse
+	}{
+		{
+			name: "infraction.revokeinfraction.1",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+						Duration:     sql.NullInt32{Int32: 0, Valid: true},
+					},
+				},
+				connectedServers: []int64{1},
+			},
+			args: args{
+				id: 1,
+				userMeta: &params.UserMeta{
+					UserID:      2,
+					Permissions: perms.EDIT_ANY_INFRACTION,
+				},
+			},
+			wantRevoked:  true,
+			wantCommands: []string{"mockunban"},
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Infraction revoked and lifted in-game",
+			},
+		},
+		{
+			name: "infraction.revokeinfraction.2",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_MUTE,
+						Reason:       sql.NullString{String: "Test mute reason", Valid: true},
+						Duration:     sql.NullInt32{Int32: 60, Valid: true},
+					},
+				},
+				connectedServers: []int64{},
+			},
+			args: args{
+				id: 1,
+				userMeta: &params.UserMeta{
+					UserID:      1,
+					Permissions: perms.EDIT_OWN_INFRACTIONS,
+				},
+			},
+			wantRevoked:  true,
+			wantCommands: nil,
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Infraction revoked, but it could not be lifted in-game",
+			},
+		},
+		{
+			name: "infraction.revokeinfraction.3",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+						Duration:     sql.NullInt32{Int32: 0, Valid: true},
+					},
+				},
+				connectedServers: []int64{1},
+			},
+			args: args{
+				id: 1,
+				userMeta: &params.UserMeta{
+					UserID:      2,
+					Permissions: perms.EDIT_OWN_INFRACTIONS,
+				},
+			},
+			wantRevoked:  false,
+			wantCommands: nil,
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageNoPermission,
+			},
+		},
+		{
+			name: "infraction.revokeinfraction.4",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_KICK,
+						Reason:       sql.NullString{String: "Test kick reason", Valid: true},
+					},
+				},
+				connectedServers: []int64{1},
+			},
+			args: args{
+				id: 1,
+				userMeta: &params.UserMeta{
+					UserID:      1,
+					Permissions: perms.SUPER_ADMIN,
+				},
+			},
+			wantRevoked:  false,
+			wantCommands: nil,
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    "Only bans and mutes can be revoked",
+			},
+		},
+		{
+			name: "infraction.revokeinfraction.5",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+						Duration:     sql.NullInt32{Int32: 0, Valid: true},
+						RevokedBy:    sql.NullInt64{Int64: 1, Valid: true},
+						RevokedAt:    sql.NullInt64{Int64: 1, Valid: true},
+						RevokeReason: sql.NullString{String: "Accepted appeal", Valid: true},
+					},
+				},
+				connectedServers: []int64{1},
+			},
+			args: args{
+				id: 1,
+				userMeta: &params.UserMeta{
+					UserID:      1,
+					Permissions: perms.SUPER_ADMIN,
+				},
+			},
+			wantRevoked:  true,
+			wantCommands: nil,
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    "This infraction has already been revoked",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPlayerRepo := mock.NewMockPlayerRepository(map[int64]*refractor.DBPlayer{
+				1: {
+					PlayerID:  1,
+					PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+				},
+			})
 			playerService := player.NewPlayerService(mockPlayerRepo, testLogger)
-			mockServerRepo := mock.NewMockServerRepository(tt.fields.mockServers)
+			mockServerRepo := mock.NewMockServerRepository(map[int64]*refractor.Server{
+				1: {
+					ServerID: 1,
+					Game:     "TestGame",
+				},
+			})
 			serverService := server.NewServerService(mockServerRepo, nil, testLogger)
-			mockInfractionRepo := mock.NewMockInfractionRepository(map[int64]*refractor.DBInfraction{})
-			infractionService := NewInfractionService(mockInfractionRepo, playerService, serverService, nil, testLogger)
+			gameService := game.NewGameService()
+			gameService.AddGame(mock.NewMockGame())
+			rconService := mock.NewMockRCONService(tt.fields.connectedServers...)
+			mockInfractionRepo := mock.NewMockInfractionRepository(tt.fields.mockInfractions)
+			infractionService := NewInfractionService(mockInfractionRepo, playerService, serverService, nil, rconService,
+				gameService, testLogger)
 
-			warning, res := infractionService.CreateWarning(tt.args.userID, tt.args.body)
+			_, res := infractionService.RevokeInfraction(tt.args.id, params.RevokeInfractionParams{
+				Reason:   "Test revoke reason",
+				UserMeta: tt.args.userMeta,
+			})
 
-			assert.True(t, infractionsAreEqual(tt.wantInfraction, warning), "Infractions were not equal\nWant = %v\nGot  = %v", tt.wantInfraction, warning)
+			assert.Equal(t, tt.wantRevoked, tt.fields.mockInfractions[tt.args.id].RevokedAt.Valid, "Revoked states should be equal")
+			assert.Equal(t, tt.wantCommands, rconService.Commands[1], "Executed commands should be equal")
 			assert.True(t, tt.wantRes.Equals(res), "tt.wantRes = %v and res = %v should be equal", tt.wantRes, res)
 		})
 	}
 }
 
+func Test_infractionService_RevokeInfractionAsSystem(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	mockInfractions := map[int64]*refractor.DBInfraction{
+		1: {
+			InfractionID: 1,
+			PlayerID:     1,
+			UserID:       1,
+			ServerID:     1,
+			Type:         refractor.INFRACTION_TYPE_BAN,
+			Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+			Duration:     sql.NullInt32{Int32: 0, Valid: true},
+		},
+	}
+
+	mockPlayerRepo := mock.NewMockPlayerRepository(map[int64]*refractor.DBPlayer{
+		1: {
+			PlayerID:  1,
+			PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+		},
+	})
+	playerService := player.NewPlayerService(mockPlayerRepo, testLogger)
+	mockServerRepo := mock.NewMockServerRepository(map[int64]*refractor.Server{
+		1: {
+			ServerID: 1,
+			Game:     "TestGame",
+		},
+	})
+	serverService := server.NewServerService(mockServerRepo, nil, testLogger)
+	gameService := game.NewGameService()
+	gameService.AddGame(mock.NewMockGame())
+	rconService := mock.NewMockRCONService(1)
+	infractionService := NewInfractionService(mock.NewMockInfractionRepository(mockInfractions), playerService,
+		serverService, nil, rconService, gameService, testLogger)
+
+	// The revoking user has no permissions of their own since the system carries out the revoke
+	revoked, res := infractionService.RevokeInfractionAsSystem(1, 2, "Test revoke reason")
+
+	assert.True(t, res.Success, "Revoke should have succeeded. Message: %s", res.Message)
+	assert.True(t, revoked.Revoked, "Infraction should be revoked")
+	assert.Equal(t, int64(2), revoked.RevokedBy, "Revoke should be attributed to the provided user")
+	assert.Equal(t, []string{"mockunban"}, rconService.Commands[1], "Executed commands should be equal")
+
+	_, res = infractionService.RevokeInfractionAsSystem(1, 2, "Test revoke reason")
+	assert.Equal(t, "This infraction has already been revoked", res.Message, "Infractions should only be revoked once")
+}
+
 func Test_infractionService_DeleteInfraction(t *testing.T) {
 	testLogger, _ := log.NewLogger(true, false)
 
@@ -239,11 +945,301 @@ func Test_infractionService_DeleteInfraction(t *testing.T) {
 			wantRes: &refractor.ServiceResponse{
 				Success:    false,
 				StatusCode: http.StatusBadRequest,
-				Message:    config.MessageNoPermission,
+				Message:    config.MessageNoPermission,
+			},
+		},
+		{
+			name: "infraction.deleteinfraction.5",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_WARNING,
+						Reason:       sql.NullString{String: strings.Repeat("a", config.InfractionReasonMinLen), Valid: true},
+						Duration:     sql.NullInt32{Int32: 0, Valid: true},
+						Timestamp:    0,
+						SystemAction: false,
+					},
+				},
+			},
+			args: args{
+				id: 1,
+				user: params.UserMeta{
+					UserID:      2,
+					Permissions: 0,
+				},
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageNoPermission,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInfractionRepo := mock.NewMockInfractionRepository(tt.fields.mockInfractions)
+			infractionService := NewInfractionService(mockInfractionRepo, nil, nil, nil, nil, nil, testLogger)
+
+			res := infractionService.DeleteInfraction(tt.args.id, params.DeleteInfractionParams{UserMeta: &tt.args.user})
+
+			assert.True(t, tt.wantRes.Equals(res), "tt.wantRes = %v and res = %v should be equal", tt.wantRes, res)
+		})
+	}
+}
+
+func Test_infractionService_GetInfractionByID(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	mockInfractions := map[int64]*refractor.DBInfraction{
+		1: {
+			InfractionID: 1,
+			PlayerID:     1,
+			UserID:       1,
+			ServerID:     1,
+			Type:         refractor.INFRACTION_TYPE_WARNING,
+			Reason:       sql.NullString{String: strings.Repeat("a", config.InfractionReasonMinLen), Valid: true},
+		},
+		2: {
+			InfractionID: 2,
+			PlayerID:     1,
+			UserID:       1,
+			ServerID:     1,
+			Type:         refractor.INFRACTION_TYPE_WARNING,
+			Reason:       sql.NullString{String: strings.Repeat("a", config.InfractionReasonMinLen), Valid: true},
+			DeletedBy:    sql.NullInt64{Int64: 1, Valid: true},
+			DeletedAt:    sql.NullInt64{Int64: 1000, Valid: true},
+			DeleteReason: sql.NullString{String: "Duplicate", Valid: true},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		id      int64
+		wantRes *refractor.ServiceResponse
+	}{
+		{
+			name: "infraction.getinfractionbyid.1",
+			id:   1,
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Infraction fetched",
+			},
+		},
+		{
+			name: "infraction.getinfractionbyid.2",
+			id:   2,
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			},
+		},
+		{
+			name: "infraction.getinfractionbyid.3",
+			id:   3,
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInfractionRepo := mock.NewMockInfractionRepository(mockInfractions)
+			infractionService := NewInfractionService(mockInfractionRepo, nil, nil, nil, nil, nil, testLogger)
+
+			infraction, res := infractionService.GetInfractionByID(tt.id)
+
+			assert.True(t, tt.wantRes.Equals(res), "tt.wantRes = %v and res = %v should be equal", tt.wantRes, res)
+
+			if res.Success {
+				assert.Equal(t, tt.id, infraction.InfractionID, "Wrong infraction was fetched")
+			}
+		})
+	}
+}
+
+func Test_infractionService_RestoreInfraction(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	type fields struct {
+		mockInfractions map[int64]*refractor.DBInfraction
+	}
+	type args struct {
+		id int64
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		args        args
+		wantDeleted bool
+		wantRes     *refractor.ServiceResponse
+	}{
+		{
+			name: "infraction.restoreinfraction.1",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_WARNING,
+						Reason:       sql.NullString{String: strings.Repeat("a", config.InfractionReasonMinLen), Valid: true},
+						DeletedBy:    sql.NullInt64{Int64: 1, Valid: true},
+						DeletedAt:    sql.NullInt64{Int64: 1000, Valid: true},
+						DeleteReason: sql.NullString{String: "Duplicate", Valid: true},
+					},
+				},
+			},
+			args: args{
+				id: 1,
+			},
+			wantDeleted: false,
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Infraction restored",
+			},
+		},
+		{
+			name: "infraction.restoreinfraction.2",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_WARNING,
+						Reason:       sql.NullString{String: strings.Repeat("a", config.InfractionReasonMinLen), Valid: true},
+					},
+				},
+			},
+			args: args{
+				id: 1,
+			},
+			wantDeleted: false,
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    "This infraction has not been deleted",
+			},
+		},
+		{
+			name: "infraction.restoreinfraction.3",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{},
+			},
+			args: args{
+				id: 1,
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInfractionRepo := mock.NewMockInfractionRepository(tt.fields.mockInfractions)
+			infractionService := NewInfractionService(mockInfractionRepo, nil, nil, nil, nil, nil, testLogger)
+
+			infraction, res := infractionService.RestoreInfraction(tt.args.id, params.UserMeta{UserID: 1})
+
+			assert.True(t, tt.wantRes.Equals(res), "tt.wantRes = %v and res = %v should be equal", tt.wantRes, res)
+
+			if res.Success {
+				assert.Equal(t, tt.wantDeleted, infraction.Deleted, "Restored infraction should not be deleted")
+			}
+		})
+	}
+}
+
+func Test_infractionService_PurgeInfraction(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	type fields struct {
+		mockInfractions map[int64]*refractor.DBInfraction
+	}
+	type args struct {
+		id   int64
+		user params.UserMeta
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		args       args
+		wantPurged bool
+		wantRes    *refractor.ServiceResponse
+	}{
+		{
+			name: "infraction.purgeinfraction.1",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_WARNING,
+						Reason:       sql.NullString{String: strings.Repeat("a", config.InfractionReasonMinLen), Valid: true},
+						DeletedBy:    sql.NullInt64{Int64: 1, Valid: true},
+						DeletedAt:    sql.NullInt64{Int64: 1000, Valid: true},
+					},
+				},
+			},
+			args: args{
+				id: 1,
+				user: params.UserMeta{
+					UserID:      1,
+					Permissions: perms.SUPER_ADMIN,
+				},
+			},
+			wantPurged: true,
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Infraction purged",
+			},
+		},
+		{
+			name: "infraction.purgeinfraction.2",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						UserID:       1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_WARNING,
+						Reason:       sql.NullString{String: strings.Repeat("a", config.InfractionReasonMinLen), Valid: true},
+					},
+				},
+			},
+			args: args{
+				id: 1,
+				user: params.UserMeta{
+					UserID:      1,
+					Permissions: perms.SUPER_ADMIN,
+				},
+			},
+			wantPurged: false,
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    "Only deleted infractions can be purged",
 			},
 		},
 		{
-			name: "infraction.deleteinfraction.5",
+			name: "infraction.purgeinfraction.3",
 			fields: fields{
 				mockInfractions: map[int64]*refractor.DBInfraction{
 					1: {
@@ -253,9 +1249,8 @@ func Test_infractionService_DeleteInfraction(t *testing.T) {
 						ServerID:     1,
 						Type:         refractor.INFRACTION_TYPE_WARNING,
 						Reason:       sql.NullString{String: strings.Repeat("a", config.InfractionReasonMinLen), Valid: true},
-						Duration:     sql.NullInt32{Int32: 0, Valid: true},
-						Timestamp:    0,
-						SystemAction: false,
+						DeletedBy:    sql.NullInt64{Int64: 1, Valid: true},
+						DeletedAt:    sql.NullInt64{Int64: 1000, Valid: true},
 					},
 				},
 			},
@@ -263,9 +1258,10 @@ func Test_infractionService_DeleteInfraction(t *testing.T) {
 				id: 1,
 				user: params.UserMeta{
 					UserID:      2,
-					Permissions: 0,
+					Permissions: perms.FULL_ACCESS,
 				},
 			},
+			wantPurged: false,
 			wantRes: &refractor.ServiceResponse{
 				Success:    false,
 				StatusCode: http.StatusBadRequest,
@@ -276,11 +1272,18 @@ func Test_infractionService_DeleteInfraction(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockInfractionRepo := mock.NewMockInfractionRepository(tt.fields.mockInfractions)
-			infractionService := NewInfractionService(mockInfractionRepo, nil, nil, nil, testLogger)
+			infractionService := NewInfractionService(mockInfractionRepo, nil, nil, nil, nil, nil, testLogger)
+
+			purged := false
+			infractionService.SubscribePurge(func(infraction *refractor.Infraction) {
+				purged = true
+			})
 
-			res := infractionService.DeleteInfraction(tt.args.id, tt.args.user)
+			res := infractionService.PurgeInfraction(tt.args.id, tt.args.user)
 
 			assert.True(t, tt.wantRes.Equals(res), "tt.wantRes = %v and res = %v should be equal", tt.wantRes, res)
+			assert.Equal(t, tt.wantPurged, purged, "Purge subscribers were not notified as expected")
+			assert.Equal(t, !tt.wantPurged, tt.fields.mockInfractions[tt.args.id] != nil, "Infraction was not purged as expected")
 		})
 	}
 }
@@ -504,7 +1507,7 @@ func Test_infractionService_UpdateInfraction(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockInfractionRepo := mock.NewMockInfractionRepository(tt.fields.mockInfractions)
-			infractionService := NewInfractionService(mockInfractionRepo, nil, nil, nil, testLogger)
+			infractionService := NewInfractionService(mockInfractionRepo, nil, nil, nil, nil, nil, testLogger)
 
 			body := params.UpdateInfractionParams{
 				Reason:   &tt.args.reason,
@@ -523,6 +1526,98 @@ func Test_infractionService_UpdateInfraction(t *testing.T) {
 	}
 }
 
+func Test_infractionService_GetInfractionHistory(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	type args struct {
+		updates []params.UpdateInfractionParams
+	}
+	tests := []struct {
+		name          string
+		args          args
+		wantRevisions [][]*refractor.InfractionChange
+	}{
+		{
+			name: "infraction.getinfractionhistory.1",
+			args: args{
+				updates: []params.UpdateInfractionParams{
+					{
+						Duration: intPtr(1440),
+						UserMeta: &params.UserMeta{UserID: 1, Permissions: perms.EDIT_ANY_INFRACTION},
+					},
+					{
+						Reason:   stringPtr("Updated ban reason"),
+						Duration: intPtr(1440),
+						UserMeta: &params.UserMeta{UserID: 1, Permissions: perms.EDIT_ANY_INFRACTION},
+					},
+				},
+			},
+			wantRevisions: [][]*refractor.InfractionChange{
+				{
+					{Field: "duration", OldValue: "0", NewValue: "1440"},
+				},
+				{
+					{Field: "reason", OldValue: "Test ban reason", NewValue: "Updated ban reason"},
+				},
+			},
+		},
+		{
+			name: "infraction.getinfractionhistory.2",
+			args: args{
+				updates: []params.UpdateInfractionParams{
+					{
+						Reason:   stringPtr("Test ban reason"),
+						UserMeta: &params.UserMeta{UserID: 1, Permissions: perms.EDIT_ANY_INFRACTION},
+					},
+				},
+			},
+			wantRevisions: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInfractionRepo := mock.NewMockInfractionRepository(map[int64]*refractor.DBInfraction{
+				1: {
+					InfractionID: 1,
+					PlayerID:     1,
+					UserID:       1,
+					ServerID:     1,
+					Type:         refractor.INFRACTION_TYPE_BAN,
+					Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+					Duration:     sql.NullInt32{Int32: 0, Valid: true},
+				},
+			})
+			userService := user.NewUserService(mock.NewMockUserRepository(mock.GetMockUsers()), testLogger)
+			infractionService := NewInfractionService(mockInfractionRepo, nil, nil, userService, nil, nil, testLogger)
+
+			for _, update := range tt.args.updates {
+				_, res := infractionService.UpdateInfraction(1, update)
+				assert.True(t, res.Success, "Update should have succeeded. Message: %s", res.Message)
+			}
+
+			revisions, res := infractionService.GetInfractionHistory(1)
+			assert.True(t, res.Success, "History should have been fetched. Message: %s", res.Message)
+
+			var gotRevisions [][]*refractor.InfractionChange
+			for _, revision := range revisions {
+				assert.Equal(t, "tester", revision.EditorName, "Editor name should be set")
+
+				gotRevisions = append(gotRevisions, revision.Changes)
+			}
+
+			assert.Equal(t, tt.wantRevisions, gotRevisions, "Revision changes should be equal")
+		})
+	}
+}
+
+func intPtr(value int) *int {
+	return &value
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
+
 func Test_infractionService_GetPlayerInfractions(t *testing.T) {
 	testLogger, _ := log.NewLogger(true, false)
 
@@ -647,7 +1742,7 @@ func Test_infractionService_GetPlayerInfractions(t *testing.T) {
 			mockUserRepo := mock.NewMockUserRepository(tt.fields.mockUsers)
 			userService := user.NewUserService(mockUserRepo, testLogger)
 			mockInfractionRepo := mock.NewMockInfractionRepository(tt.fields.mockInfractions)
-			infractionService := NewInfractionService(mockInfractionRepo, nil, nil, userService, testLogger)
+			infractionService := NewInfractionService(mockInfractionRepo, nil, nil, userService, nil, nil, testLogger)
 
 			foundInfractions, res := infractionService.GetPlayerInfractionsType(tt.args.infractionType, tt.args.playerID)
 
@@ -697,3 +1792,304 @@ func infractionsAreEqual(infraction1 *refractor.Infraction, infraction2 *refract
 
 	return true
 }
+
+func Test_infractionService_ExpireInfractions(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	type fields struct {
+		mockInfractions map[int64]*refractor.DBInfraction
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		wantExpired []int64
+		wantRes     *refractor.ServiceResponse
+	}{
+		{
+			name: "infraction.expireinfractions.1",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Duration:     sql.NullInt32{Int32: 60, Valid: true},
+						Timestamp:    time.Now().Add(-time.Hour * 2).Unix(),
+					},
+					2: {
+						InfractionID: 2,
+						Type:         refractor.INFRACTION_TYPE_MUTE,
+						Duration:     sql.NullInt32{Int32: 1440, Valid: true},
+						Timestamp:    time.Now().Unix(),
+					},
+					3: {
+						InfractionID: 3,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Duration:     sql.NullInt32{Int32: 0, Valid: true},
+						Timestamp:    0,
+					},
+				},
+			},
+			wantExpired: []int64{1},
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Expired 1 infractions",
+			},
+		},
+		{
+			name: "infraction.expireinfractions.2",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						Type:         refractor.INFRACTION_TYPE_MUTE,
+						Duration:     sql.NullInt32{Int32: 60, Valid: true},
+						Timestamp:    time.Now().Add(-time.Hour * 2).Unix(),
+						RevokedAt:    sql.NullInt64{Int64: time.Now().Unix(), Valid: true},
+					},
+					2: {
+						InfractionID: 2,
+						Type:         refractor.INFRACTION_TYPE_MUTE,
+						Duration:     sql.NullInt32{Int32: 60, Valid: true},
+						Timestamp:    time.Now().Add(-time.Hour * 2).Unix(),
+						Expired:      true,
+					},
+				},
+			},
+			wantExpired: []int64{},
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Expired 0 infractions",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInfractionRepo := mock.NewMockInfractionRepository(tt.fields.mockInfractions)
+			infractionService := NewInfractionService(mockInfractionRepo, nil, nil, nil, nil, nil, testLogger)
+
+			expired, res := infractionService.ExpireInfractions()
+
+			expiredIDs := []int64{}
+			for _, infraction := range expired {
+				expiredIDs = append(expiredIDs, infraction.InfractionID)
+
+				assert.True(t, infraction.Expired, "Infraction should be marked as expired")
+				assert.False(t, infraction.Active, "Infraction should not be active")
+			}
+
+			assert.Equal(t, tt.wantExpired, expiredIDs, "Expired infraction IDs should be equal")
+			assert.True(t, tt.wantRes.Equals(res), "tt.wantRes = %v and res = %v should be equal", tt.wantRes, res)
+		})
+	}
+}
+
+func Test_infractionService_OnPlayerJoin(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	type fields struct {
+		mockInfractions map[int64]*refractor.DBInfraction
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		wantCommands []string
+	}{
+		{
+			name: "infraction.onplayerjoin.1",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						ServerID:     2,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+						Duration:     sql.NullInt32{Int32: 1440, Valid: true},
+						Timestamp:    time.Now().Unix(),
+					},
+					2: {
+						InfractionID: 2,
+						PlayerID:     1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_MUTE,
+						Reason:       sql.NullString{String: "Test mute reason", Valid: true},
+						Duration:     sql.NullInt32{Int32: 0, Valid: true},
+						Timestamp:    time.Now().Unix(),
+					},
+				},
+			},
+			wantCommands: []string{"mockkick"},
+		},
+		{
+			name: "infraction.onplayerjoin.2",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_MUTE,
+						Reason:       sql.NullString{String: "Test mute reason", Valid: true},
+						Duration:     sql.NullInt32{Int32: 60, Valid: true},
+						Timestamp:    time.Now().Unix(),
+					},
+				},
+			},
+			wantCommands: []string{"mockmute"},
+		},
+		{
+			name: "infraction.onplayerjoin.3",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+						Duration:     sql.NullInt32{Int32: 60, Valid: true},
+						Timestamp:    time.Now().Add(-time.Hour * 2).Unix(),
+					},
+					2: {
+						InfractionID: 2,
+						PlayerID:     1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_MUTE,
+						Reason:       sql.NullString{String: "Test mute reason", Valid: true},
+						Duration:     sql.NullInt32{Int32: 0, Valid: true},
+						Timestamp:    time.Now().Unix(),
+						RevokedAt:    sql.NullInt64{Int64: time.Now().Unix(), Valid: true},
+					},
+					3: {
+						InfractionID: 3,
+						PlayerID:     1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_KICK,
+						Reason:       sql.NullString{String: "Test kick reason", Valid: true},
+						Timestamp:    time.Now().Unix(),
+					},
+				},
+			},
+			wantCommands: nil,
+		},
+		{
+			name: "infraction.onplayerjoin.4",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						ServerID:     2,
+						Type:         refractor.INFRACTION_TYPE_WARNING,
+						Reason:       sql.NullString{String: "Queued", Valid: true},
+						Timestamp:    time.Now().Unix(),
+						Delivery:     refractor.DELIVERY_QUEUED,
+					},
+					2: {
+						InfractionID: 2,
+						PlayerID:     1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_WARNING,
+						Reason:       sql.NullString{String: "Delivered", Valid: true},
+						Timestamp:    time.Now().Unix(),
+						Delivery:     refractor.DELIVERY_DELIVERED,
+					},
+					3: {
+						InfractionID: 3,
+						PlayerID:     1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_MUTE,
+						Reason:       sql.NullString{String: "Test mute reason", Valid: true},
+						Duration:     sql.NullInt32{Int32: 60, Valid: true},
+						Timestamp:    time.Now().Unix(),
+					},
+				},
+			},
+			wantCommands: []string{"mockmute", "mockwhisper ABCDEF You have been warned. Reason: Queued"},
+		},
+		{
+			name: "infraction.onplayerjoin.5",
+			fields: fields{
+				mockInfractions: map[int64]*refractor.DBInfraction{
+					1: {
+						InfractionID: 1,
+						PlayerID:     1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_WARNING,
+						Reason:       sql.NullString{String: "Queued", Valid: true},
+						Timestamp:    time.Now().Unix(),
+						Delivery:     refractor.DELIVERY_QUEUED,
+					},
+					2: {
+						InfractionID: 2,
+						PlayerID:     1,
+						ServerID:     1,
+						Type:         refractor.INFRACTION_TYPE_BAN,
+						Reason:       sql.NullString{String: "Test ban reason", Valid: true},
+						Duration:     sql.NullInt32{Int32: 0, Valid: true},
+						Timestamp:    time.Now().Unix(),
+					},
+				},
+			},
+			wantCommands: []string{"mockkick"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPlayerRepo := mock.NewMockPlayerRepository(map[int64]*refractor.DBPlayer{
+				1: {
+					PlayerID:  1,
+					PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+				},
+			})
+			playerService := player.NewPlayerService(mockPlayerRepo, testLogger)
+			mockServerRepo := mock.NewMockServerRepository(map[int64]*refractor.Server{
+				1: {
+					ServerID: 1,
+					Game:     "TestGame",
+				},
+			})
+			serverService := server.NewServerService(mockServerRepo, nil, testLogger)
+			gameService := game.NewGameService()
+			gameService.AddGame(mock.NewMockGame())
+			rconService := mock.NewMockRCONService(1)
+			mockInfractionRepo := mock.NewMockInfractionRepository(tt.fields.mockInfractions)
+			infractionService := NewInfractionService(mockInfractionRepo, playerService, serverService, nil, rconService,
+				gameService, testLogger)
+
+			joiningPlayer, _ := playerService.GetPlayerByID(1)
+
+			infractionService.OnPlayerJoin(1, joiningPlayer)
+
+			assert.Equal(t, tt.wantCommands, rconService.Commands[1], "Executed commands should be equal")
+
+			// Queued warnings should only be delivered once
+			for _, infraction := range tt.fields.mockInfractions {
+				if infraction.Type == refractor.INFRACTION_TYPE_WARNING && tt.wantCommands != nil &&
+					tt.wantCommands[0] != "mockkick" {
+					assert.Equal(t, refractor.DELIVERY_DELIVERED, infraction.Delivery, "Warnings should be delivered")
+				}
+			}
+		})
+	}
+}
+
+func Test_formatMinutes(t *testing.T) {
+	tests := []struct {
+		minutes int
+		want    string
+	}{
+		{minutes: 0, want: "0m"},
+		{minutes: 45, want: "45m"},
+		{minutes: 60, want: "1h"},
+		{minutes: 1530, want: "1d 1h 30m"},
+		{minutes: 2880, want: "2d"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatMinutes(tt.minutes), "Formatted durations should be equal")
+		})
+	}
+}
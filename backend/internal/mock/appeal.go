@@ -0,0 +1,117 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mock
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/refractor"
+)
+
+type mockAppealRepo struct {
+	appeals map[int64]*refractor.DBAppeal
+	events  map[int64][]*refractor.AppealEvent
+}
+
+func NewMockAppealRepository(mockAppeals map[int64]*refractor.DBAppeal) refractor.AppealRepository {
+	return &mockAppealRepo{
+		appeals: mockAppeals,
+		events:  map[int64][]*refractor.AppealEvent{},
+	}
+}
+
+func (r *mockAppealRepo) Create(appeal *refractor.DBAppeal) (*refractor.Appeal, error) {
+	newID := int64(len(r.appeals) + 1)
+	r.appeals[newID] = appeal
+
+	appeal.AppealID = newID
+
+	return appeal.Appeal(), nil
+}
+
+func (r *mockAppealRepo) FindByID(id int64) (*refractor.Appeal, error) {
+	foundAppeal := r.appeals[id]
+
+	if foundAppeal == nil {
+		return nil, refractor.ErrNotFound
+	}
+
+	return foundAppeal.Appeal(), nil
+}
+
+func (r *mockAppealRepo) FindMany(args refractor.FindArgs) ([]*refractor.Appeal, error) {
+	var appeals []*refractor.Appeal
+
+	for _, appeal := range r.appeals {
+		if args["InfractionID"] != nil && args["InfractionID"].(int64) != appeal.InfractionID {
+			continue
+		}
+
+		if args["PlayerID"] != nil && args["PlayerID"].(int64) != appeal.PlayerID {
+			continue
+		}
+
+		if args["Status"] != nil && args["Status"].(string) != appeal.Status {
+			continue
+		}
+
+		appeals = append(appeals, appeal.Appeal())
+	}
+
+	if len(appeals) < 1 {
+		return nil, refractor.ErrNotFound
+	}
+
+	return appeals, nil
+}
+
+func (r *mockAppealRepo) Update(id int64, args refractor.UpdateArgs) (*refractor.Appeal, error) {
+	appeal := r.appeals[id]
+	if appeal == nil {
+		return nil, refractor.ErrNotFound
+	}
+
+	if args["ReviewerID"] != nil {
+		appeal.ReviewerID = sql.NullInt64{Int64: args["ReviewerID"].(int64), Valid: true}
+	}
+
+	if args["Status"] != nil {
+		appeal.Status = args["Status"].(string)
+	}
+
+	if args["DecisionNotes"] != nil {
+		appeal.DecisionNotes = sql.NullString{String: args["DecisionNotes"].(string), Valid: true}
+	}
+
+	return appeal.Appeal(), nil
+}
+
+func (r *mockAppealRepo) Search(args refractor.FindArgs, limit int, offset int) (int, []*refractor.Appeal, error) {
+	panic("implement me")
+}
+
+func (r *mockAppealRepo) CreateEvent(event *refractor.AppealEvent) error {
+	event.EventID = int64(len(r.events[event.AppealID]) + 1)
+
+	r.events[event.AppealID] = append(r.events[event.AppealID], event)
+
+	return nil
+}
+
+func (r *mockAppealRepo) FindEvents(appealID int64) ([]*refractor.AppealEvent, error) {
+	return r.events[appealID], nil
+}
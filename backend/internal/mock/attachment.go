@@ -0,0 +1,120 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mock
+
+import (
+	"bytes"
+	"github.com/sniddunc/refractor/refractor"
+	"io"
+	"io/ioutil"
+)
+
+type mockAttachmentRepo struct {
+	attachments map[int64]*refractor.Attachment
+}
+
+func NewMockAttachmentRepository(mockAttachments map[int64]*refractor.Attachment) refractor.AttachmentRepository {
+	return &mockAttachmentRepo{
+		attachments: mockAttachments,
+	}
+}
+
+func (r *mockAttachmentRepo) Create(attachment *refractor.Attachment) error {
+	newID := int64(len(r.attachments) + 1)
+	r.attachments[newID] = attachment
+
+	attachment.AttachmentID = newID
+
+	return nil
+}
+
+func (r *mockAttachmentRepo) FindByID(id int64) (*refractor.Attachment, error) {
+	foundAttachment := r.attachments[id]
+
+	if foundAttachment == nil {
+		return nil, refractor.ErrNotFound
+	}
+
+	return foundAttachment, nil
+}
+
+func (r *mockAttachmentRepo) FindManyByInfractionID(infractionID int64) ([]*refractor.Attachment, error) {
+	var foundAttachments []*refractor.Attachment
+
+	for _, attachment := range r.attachments {
+		if attachment.InfractionID == infractionID {
+			foundAttachments = append(foundAttachments, attachment)
+		}
+	}
+
+	if len(foundAttachments) < 1 {
+		return nil, refractor.ErrNotFound
+	}
+
+	return foundAttachments, nil
+}
+
+func (r *mockAttachmentRepo) CountByPlayerID(playerID int64) (map[int64]int, error) {
+	panic("implement me")
+}
+
+func (r *mockAttachmentRepo) Delete(id int64) error {
+	if r.attachments[id] == nil {
+		return refractor.ErrNotFound
+	}
+
+	delete(r.attachments, id)
+
+	return nil
+}
+
+// MockBlobStore is an in-memory blob store. Stored files can be inspected through Blobs.
+type MockBlobStore struct {
+	Blobs map[string][]byte
+}
+
+func NewMockBlobStore() *MockBlobStore {
+	return &MockBlobStore{
+		Blobs: map[string][]byte{},
+	}
+}
+
+func (s *MockBlobStore) Put(key string, data io.Reader) (int64, error) {
+	contents, err := ioutil.ReadAll(data)
+	if err != nil {
+		return 0, err
+	}
+
+	s.Blobs[key] = contents
+
+	return int64(len(contents)), nil
+}
+
+func (s *MockBlobStore) Get(key string) (io.ReadCloser, error) {
+	contents, ok := s.Blobs[key]
+	if !ok {
+		return nil, refractor.ErrNotFound
+	}
+
+	return ioutil.NopCloser(bytes.NewReader(contents)), nil
+}
+
+func (s *MockBlobStore) Delete(key string) error {
+	delete(s.Blobs, key)
+	return nil
+}
@@ -0,0 +1,114 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mock
+
+import (
+	"github.com/sniddunc/refractor/refractor"
+	"sort"
+)
+
+type mockChatRepo struct {
+	messages map[int64]*refractor.ChatMessage
+}
+
+func NewMockChatRepository(mockMessages map[int64]*refractor.ChatMessage) refractor.ChatRepository {
+	return &mockChatRepo{
+		messages: mockMessages,
+	}
+}
+
+func (r *mockChatRepo) Create(message *refractor.ChatMessage) error {
+	newID := int64(len(r.messages) + 1)
+	for r.messages[newID] != nil {
+		newID++
+	}
+
+	r.messages[newID] = message
+
+	message.MessageID = newID
+
+	return nil
+}
+
+// Search supports the PlayerID, ServerID, StartDate and EndDate arguments.
+func (r *mockChatRepo) Search(args refractor.FindArgs, limit int, offset int) (int, []*refractor.ChatMessage, error) {
+	var foundMessages []*refractor.ChatMessage
+
+	for _, message := range r.messages {
+		if args["PlayerID"] != nil && args["PlayerID"].(int64) != message.PlayerID {
+			continue
+		}
+
+		if args["ServerID"] != nil && args["ServerID"].(int64) != message.ServerID {
+			continue
+		}
+
+		if args["StartDate"] != nil && message.Timestamp < args["StartDate"].(int64) {
+			continue
+		}
+
+		if args["EndDate"] != nil && message.Timestamp > args["EndDate"].(int64) {
+			continue
+		}
+
+		foundMessages = append(foundMessages, message)
+	}
+
+	sort.Slice(foundMessages, func(i, j int) bool {
+		if foundMessages[i].Timestamp == foundMessages[j].Timestamp {
+			return foundMessages[i].MessageID > foundMessages[j].MessageID
+		}
+
+		return foundMessages[i].Timestamp > foundMessages[j].Timestamp
+	})
+
+	count := len(foundMessages)
+
+	if offset >= count {
+		return count, nil, nil
+	}
+
+	end := offset + limit
+	if end > count {
+		end = count
+	}
+
+	return count, foundMessages[offset:end], nil
+}
+
+// MockChatSnapshotRepository is an in-memory chat snapshot repository. Stored snapshots can be inspected through
+// Snapshots which is keyed by infraction ID.
+type MockChatSnapshotRepository struct {
+	Snapshots map[int64][]*refractor.ChatMessage
+}
+
+func NewMockChatSnapshotRepository(mockSnapshots map[int64][]*refractor.ChatMessage) *MockChatSnapshotRepository {
+	return &MockChatSnapshotRepository{
+		Snapshots: mockSnapshots,
+	}
+}
+
+func (r *MockChatSnapshotRepository) Create(infractionID int64, messages []*refractor.ChatMessage) error {
+	r.Snapshots[infractionID] = append(r.Snapshots[infractionID], messages...)
+
+	return nil
+}
+
+func (r *MockChatSnapshotRepository) FindByInfractionID(infractionID int64) ([]*refractor.ChatMessage, error) {
+	return r.Snapshots[infractionID], nil
+}
@@ -0,0 +1,116 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mock
+
+import (
+	"github.com/sniddunc/refractor/refractor"
+)
+
+type mockChatFilterRuleRepo struct {
+	rules map[int64]*refractor.ChatFilterRule
+}
+
+func NewMockChatFilterRuleRepository(mockRules map[int64]*refractor.ChatFilterRule) refractor.ChatFilterRuleRepository {
+	return &mockChatFilterRuleRepo{
+		rules: mockRules,
+	}
+}
+
+func (r *mockChatFilterRuleRepo) Create(rule *refractor.ChatFilterRule) error {
+	newID := int64(len(r.rules) + 1)
+	for r.rules[newID] != nil {
+		newID++
+	}
+
+	r.rules[newID] = rule
+
+	rule.RuleID = newID
+
+	return nil
+}
+
+func (r *mockChatFilterRuleRepo) FindByID(id int64) (*refractor.ChatFilterRule, error) {
+	foundRule := r.rules[id]
+
+	if foundRule == nil {
+		return nil, refractor.ErrNotFound
+	}
+
+	return foundRule, nil
+}
+
+func (r *mockChatFilterRuleRepo) FindAll() ([]*refractor.ChatFilterRule, error) {
+	var foundRules []*refractor.ChatFilterRule
+
+	for _, rule := range r.rules {
+		foundRules = append(foundRules, rule)
+	}
+
+	if len(foundRules) < 1 {
+		return nil, refractor.ErrNotFound
+	}
+
+	return foundRules, nil
+}
+
+func (r *mockChatFilterRuleRepo) Update(id int64, args refractor.UpdateArgs) (*refractor.ChatFilterRule, error) {
+	rule := r.rules[id]
+	if rule == nil {
+		return nil, refractor.ErrNotFound
+	}
+
+	if args["Pattern"] != nil {
+		rule.Pattern = args["Pattern"].(string)
+	}
+
+	if args["MatchType"] != nil {
+		rule.MatchType = args["MatchType"].(string)
+	}
+
+	if args["Leetspeak"] != nil {
+		rule.Leetspeak = args["Leetspeak"].(bool)
+	}
+
+	if args["ActionType"] != nil {
+		rule.ActionType = args["ActionType"].(string)
+	}
+
+	if args["ActionDuration"] != nil {
+		rule.ActionDuration = args["ActionDuration"].(int)
+	}
+
+	if args["ActionReason"] != nil {
+		rule.ActionReason = args["ActionReason"].(string)
+	}
+
+	if args["Enabled"] != nil {
+		rule.Enabled = args["Enabled"].(bool)
+	}
+
+	return rule, nil
+}
+
+func (r *mockChatFilterRuleRepo) Delete(id int64) error {
+	if r.rules[id] == nil {
+		return refractor.ErrNotFound
+	}
+
+	delete(r.rules, id)
+
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mock
+
+import (
+	"github.com/sniddunc/refractor/refractor"
+	"sort"
+)
+
+type mockCommentRepo struct {
+	comments map[int64]*refractor.Comment
+}
+
+func NewMockCommentRepository(mockComments map[int64]*refractor.Comment) refractor.CommentRepository {
+	return &mockCommentRepo{
+		comments: mockComments,
+	}
+}
+
+func (r *mockCommentRepo) Create(comment *refractor.Comment) error {
+	newID := int64(len(r.comments) + 1)
+	for r.comments[newID] != nil {
+		newID++
+	}
+
+	r.comments[newID] = comment
+
+	comment.CommentID = newID
+
+	return nil
+}
+
+func (r *mockCommentRepo) FindByID(id int64) (*refractor.Comment, error) {
+	foundComment := r.comments[id]
+
+	if foundComment == nil {
+		return nil, refractor.ErrNotFound
+	}
+
+	return foundComment, nil
+}
+
+func (r *mockCommentRepo) FindManyByInfractionID(infractionID int64) ([]*refractor.Comment, error) {
+	var foundComments []*refractor.Comment
+
+	for _, comment := range r.comments {
+		if comment.InfractionID == infractionID {
+			foundComments = append(foundComments, comment)
+		}
+	}
+
+	if len(foundComments) < 1 {
+		return nil, refractor.ErrNotFound
+	}
+
+	sort.Slice(foundComments, func(i, j int) bool {
+		return foundComments[i].CommentID < foundComments[j].CommentID
+	})
+
+	return foundComments, nil
+}
+
+func (r *mockCommentRepo) Update(id int64, args refractor.UpdateArgs) (*refractor.Comment, error) {
+	comment := r.comments[id]
+
+	if comment == nil {
+		return nil, refractor.ErrNotFound
+	}
+
+	if args["Body"] != nil {
+		comment.Body = args["Body"].(string)
+	}
+
+	if args["EditedAt"] != nil {
+		comment.EditedAt = args["EditedAt"].(int64)
+	}
+
+	return comment, nil
+}
+
+func (r *mockCommentRepo) Delete(id int64) error {
+	if r.comments[id] == nil {
+		return refractor.ErrNotFound
+	}
+
+	delete(r.comments, id)
+
+	// Remove replies the same way the database would
+	for replyID, comment := range r.comments {
+		if comment.ParentID == id {
+			_ = r.Delete(replyID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mock
+
+import (
+	"github.com/sniddunc/refractor/refractor"
+	"sort"
+)
+
+type mockRCONCommandRuleRepo struct {
+	rules map[int64]*refractor.RCONCommandRule
+}
+
+func NewMockRCONCommandRuleRepository(mockRules map[int64]*refractor.RCONCommandRule) refractor.RCONCommandRuleRepository {
+	return &mockRCONCommandRuleRepo{
+		rules: mockRules,
+	}
+}
+
+func (r *mockRCONCommandRuleRepo) Create(rule *refractor.RCONCommandRule) error {
+	newID := int64(len(r.rules) + 1)
+	for r.rules[newID] != nil {
+		newID++
+	}
+
+	r.rules[newID] = rule
+
+	rule.RuleID = newID
+
+	return nil
+}
+
+func (r *mockRCONCommandRuleRepo) FindAll() ([]*refractor.RCONCommandRule, error) {
+	var foundRules []*refractor.RCONCommandRule
+
+	for _, rule := range r.rules {
+		foundRules = append(foundRules, rule)
+	}
+
+	if len(foundRules) < 1 {
+		return nil, refractor.ErrNotFound
+	}
+
+	return foundRules, nil
+}
+
+func (r *mockRCONCommandRuleRepo) Delete(id int64) error {
+	if r.rules[id] == nil {
+		return refractor.ErrNotFound
+	}
+
+	delete(r.rules, id)
+
+	return nil
+}
+
+// MockRCONCommandLogRepository is exported so that tests can inspect the stored logs
+type MockRCONCommandLogRepository struct {
+	Logs map[int64]*refractor.RCONCommandLog
+}
+
+func NewMockRCONCommandLogRepository(mockLogs map[int64]*refractor.RCONCommandLog) *MockRCONCommandLogRepository {
+	return &MockRCONCommandLogRepository{
+		Logs: mockLogs,
+	}
+}
+
+func (r *MockRCONCommandLogRepository) Create(log *refractor.RCONCommandLog) error {
+	newID := int64(len(r.Logs) + 1)
+	for r.Logs[newID] != nil {
+		newID++
+	}
+
+	r.Logs[newID] = log
+
+	log.LogID = newID
+
+	return nil
+}
+
+func (r *MockRCONCommandLogRepository) FindManyByServerID(serverID int64, limit int, offset int) (int, []*refractor.RCONCommandLog, error) {
+	var foundLogs []*refractor.RCONCommandLog
+
+	for _, log := range r.Logs {
+		if log.ServerID == serverID {
+			foundLogs = append(foundLogs, log)
+		}
+	}
+
+	if len(foundLogs) < 1 {
+		return 0, nil, refractor.ErrNotFound
+	}
+
+	// Newest first
+	sort.Slice(foundLogs, func(i, j int) bool {
+		return foundLogs[i].LogID > foundLogs[j].LogID
+	})
+
+	count := len(foundLogs)
+
+	if offset >= count {
+		return count, []*refractor.RCONCommandLog{}, nil
+	}
+
+	end := offset + limit
+	if end > count {
+		end = count
+	}
+
+	return count, foundLogs[offset:end], nil
+}
@@ -0,0 +1,108 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mock
+
+import (
+	"github.com/sniddunc/refractor/refractor"
+)
+
+type mockDiscordChannelRepo struct {
+	channels map[int64]*refractor.DiscordChannel
+}
+
+func NewMockDiscordChannelRepository(mockChannels map[int64]*refractor.DiscordChannel) refractor.DiscordChannelRepository {
+	return &mockDiscordChannelRepo{
+		channels: mockChannels,
+	}
+}
+
+func (r *mockDiscordChannelRepo) Create(channel *refractor.DiscordChannel) error {
+	newID := int64(len(r.channels) + 1)
+	for r.channels[newID] != nil {
+		newID++
+	}
+
+	r.channels[newID] = channel
+
+	channel.ChannelID = newID
+
+	return nil
+}
+
+func (r *mockDiscordChannelRepo) FindByID(id int64) (*refractor.DiscordChannel, error) {
+	foundChannel := r.channels[id]
+
+	if foundChannel == nil {
+		return nil, refractor.ErrNotFound
+	}
+
+	return foundChannel, nil
+}
+
+func (r *mockDiscordChannelRepo) FindAll() ([]*refractor.DiscordChannel, error) {
+	var foundChannels []*refractor.DiscordChannel
+
+	for _, channel := range r.channels {
+		foundChannels = append(foundChannels, channel)
+	}
+
+	if len(foundChannels) < 1 {
+		return nil, refractor.ErrNotFound
+	}
+
+	return foundChannels, nil
+}
+
+func (r *mockDiscordChannelRepo) Update(id int64, args refractor.UpdateArgs) (*refractor.DiscordChannel, error) {
+	channel := r.channels[id]
+	if channel == nil {
+		return nil, refractor.ErrNotFound
+	}
+
+	if args["Name"] != nil {
+		channel.Name = args["Name"].(string)
+	}
+
+	if args["WebhookURL"] != nil {
+		channel.WebhookURL = args["WebhookURL"].(string)
+	}
+
+	if args["InfractionType"] != nil {
+		channel.InfractionType = args["InfractionType"].(string)
+	}
+
+	if args["ServerID"] != nil {
+		channel.ServerID = args["ServerID"].(int64)
+	}
+
+	if args["Enabled"] != nil {
+		channel.Enabled = args["Enabled"].(bool)
+	}
+
+	return channel, nil
+}
+
+func (r *mockDiscordChannelRepo) Delete(id int64) error {
+	if r.channels[id] == nil {
+		return refractor.ErrNotFound
+	}
+
+	delete(r.channels, id)
+
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mock
+
+import (
+	"github.com/sniddunc/refractor/refractor"
+)
+
+type mockEscalationPolicyRepo struct {
+	policies map[int64]*refractor.EscalationPolicy
+}
+
+func NewMockEscalationPolicyRepository(mockPolicies map[int64]*refractor.EscalationPolicy) refractor.EscalationPolicyRepository {
+	return &mockEscalationPolicyRepo{
+		policies: mockPolicies,
+	}
+}
+
+func (r *mockEscalationPolicyRepo) Create(policy *refractor.EscalationPolicy) error {
+	newID := int64(len(r.policies) + 1)
+	r.policies[newID] = policy
+
+	policy.PolicyID = newID
+
+	return nil
+}
+
+func (r *mockEscalationPolicyRepo) FindByID(id int64) (*refractor.EscalationPolicy, error) {
+	foundPolicy := r.policies[id]
+
+	if foundPolicy == nil {
+		return nil, refractor.ErrNotFound
+	}
+
+	return foundPolicy, nil
+}
+
+func (r *mockEscalationPolicyRepo) FindAll() ([]*refractor.EscalationPolicy, error) {
+	var foundPolicies []*refractor.EscalationPolicy
+
+	for _, policy := range r.policies {
+		foundPolicies = append(foundPolicies, policy)
+	}
+
+	if len(foundPolicies) < 1 {
+		return nil, refractor.ErrNotFound
+	}
+
+	return foundPolicies, nil
+}
+
+func (r *mockEscalationPolicyRepo) Update(id int64, args refractor.UpdateArgs) (*refractor.EscalationPolicy, error) {
+	policy := r.policies[id]
+	if policy == nil {
+		return nil, refractor.ErrNotFound
+	}
+
+	if args["Name"] != nil {
+		policy.Name = args["Name"].(string)
+	}
+
+	if args["TriggerType"] != nil {
+		policy.TriggerType = args["TriggerType"].(string)
+	}
+
+	if args["TriggerCount"] != nil {
+		policy.TriggerCount = args["TriggerCount"].(int)
+	}
+
+	if args["TimeWindow"] != nil {
+		policy.TimeWindow = args["TimeWindow"].(int)
+	}
+
+	if args["ActionType"] != nil {
+		policy.ActionType = args["ActionType"].(string)
+	}
+
+	if args["ActionDuration"] != nil {
+		policy.ActionDuration = args["ActionDuration"].(int)
+	}
+
+	if args["ActionReason"] != nil {
+		policy.ActionReason = args["ActionReason"].(string)
+	}
+
+	if args["Enabled"] != nil {
+		policy.Enabled = args["Enabled"].(bool)
+	}
+
+	return policy, nil
+}
+
+func (r *mockEscalationPolicyRepo) Delete(id int64) error {
+	if r.policies[id] == nil {
+		return refractor.ErrNotFound
+	}
+
+	delete(r.policies, id)
+
+	return nil
+}
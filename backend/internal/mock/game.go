@@ -39,6 +39,7 @@ func NewMockGame() refractor.Game {
 				broadcast.TYPE_JOIN: regexp.MustCompile("^(?P<name>.+) joined the game$"),
 				broadcast.TYPE_QUIT: regexp.MustCompile("^(?P<name>.+) quit the game$"),
 			},
+			PlayerGameIDField: "PlayFabID",
 		},
 	}
 }
@@ -67,6 +68,22 @@ func (g *mockGame) GetBanCommand(args refractor.CommandArgs) string {
 	return "mockban"
 }
 
+func (g *mockGame) GetUnmuteCommand(args refractor.CommandArgs) string {
+	return "mockunmute"
+}
+
+func (g *mockGame) GetUnbanCommand(args refractor.CommandArgs) string {
+	return "mockunban"
+}
+
+func (g *mockGame) GetBroadcastChatCommand(args refractor.CommandArgs) string {
+	return "mocksay " + args.Message
+}
+
+func (g *mockGame) GetPrivateMessageCommand(args refractor.CommandArgs) string {
+	return "mockwhisper " + args.PlayerID + " " + args.Message
+}
+
 func (g *mockGame) GetPlayerListCommand() string {
 	return "mocklist"
 }
@@ -24,11 +24,13 @@ import (
 
 type mockInfractionsRepo struct {
 	infractions map[int64]*refractor.DBInfraction
+	revisions   map[int64][]*refractor.InfractionRevision
 }
 
 func NewMockInfractionRepository(mockInfractions map[int64]*refractor.DBInfraction) refractor.InfractionRepository {
 	return &mockInfractionsRepo{
 		infractions: mockInfractions,
+		revisions:   map[int64][]*refractor.InfractionRevision{},
 	}
 }
 
@@ -69,7 +71,7 @@ func (r *mockInfractionsRepo) FindManyByPlayerID(playerID int64) ([]*refractor.I
 	var foundInfractions []*refractor.Infraction
 
 	for _, infraction := range r.infractions {
-		if infraction.PlayerID == playerID {
+		if infraction.PlayerID == playerID && !infraction.DeletedAt.Valid {
 			foundInfractions = append(foundInfractions, infraction.Infraction())
 		}
 	}
@@ -157,6 +159,10 @@ func (r *mockInfractionsRepo) FindMany(args refractor.FindArgs) ([]*refractor.In
 	var infractions []*refractor.Infraction
 
 	for _, infraction := range r.infractions {
+		if infraction.DeletedAt.Valid {
+			continue
+		}
+
 		if args["InfractionID"] != nil && args["InfractionID"].(int64) != infraction.InfractionID {
 			continue
 		}
@@ -221,6 +227,56 @@ func (r *mockInfractionsRepo) Update(id int64, args refractor.UpdateArgs) (*refr
 		r.infractions[id].Duration = sql.NullInt32{Int32: int32(args["Duration"].(int)), Valid: true}
 	}
 
+	if args["Enforcement"] != nil {
+		r.infractions[id].Enforcement = args["Enforcement"].(string)
+	}
+
+	if args["Delivery"] != nil {
+		r.infractions[id].Delivery = args["Delivery"].(string)
+	}
+
+	if args["DeliveredAt"] != nil {
+		r.infractions[id].DeliveredAt = sql.NullInt64{Int64: args["DeliveredAt"].(int64), Valid: true}
+	}
+
+	if args["RevokedBy"] != nil {
+		r.infractions[id].RevokedBy = sql.NullInt64{Int64: args["RevokedBy"].(int64), Valid: true}
+	}
+
+	if args["RevokedAt"] != nil {
+		r.infractions[id].RevokedAt = sql.NullInt64{Int64: args["RevokedAt"].(int64), Valid: true}
+	}
+
+	if args["RevokeReason"] != nil {
+		r.infractions[id].RevokeReason = sql.NullString{String: args["RevokeReason"].(string), Valid: true}
+	}
+
+	if args["Expired"] != nil {
+		r.infractions[id].Expired = args["Expired"].(bool)
+	}
+
+	// Deletion fields can be cleared by setting them to nil, so check for the presence of the key instead
+	if val, ok := args["DeletedBy"]; ok {
+		r.infractions[id].DeletedBy = sql.NullInt64{}
+		if val != nil {
+			r.infractions[id].DeletedBy = sql.NullInt64{Int64: val.(int64), Valid: true}
+		}
+	}
+
+	if val, ok := args["DeletedAt"]; ok {
+		r.infractions[id].DeletedAt = sql.NullInt64{}
+		if val != nil {
+			r.infractions[id].DeletedAt = sql.NullInt64{Int64: val.(int64), Valid: true}
+		}
+	}
+
+	if val, ok := args["DeleteReason"]; ok {
+		r.infractions[id].DeleteReason = sql.NullString{}
+		if val != nil {
+			r.infractions[id].DeleteReason = sql.NullString{String: val.(string), Valid: true}
+		}
+	}
+
 	return r.infractions[id].Infraction(), nil
 }
 
@@ -240,3 +296,54 @@ func (r *mockInfractionsRepo) Search(args refractor.FindArgs, limit int, offset
 func (r *mockInfractionsRepo) GetRecent(count int) ([]*refractor.Infraction, error) {
 	panic("implement me")
 }
+
+func (r *mockInfractionsRepo) FindExpired(now int64) ([]*refractor.Infraction, error) {
+	var foundInfractions []*refractor.Infraction
+
+	for _, infraction := range r.infractions {
+		if infraction.Expired || infraction.RevokedAt.Valid || infraction.DeletedAt.Valid {
+			continue
+		}
+
+		expiresAt := infraction.ExpiresAt()
+		if expiresAt == 0 || expiresAt > now {
+			continue
+		}
+
+		foundInfractions = append(foundInfractions, infraction.Infraction())
+	}
+
+	return foundInfractions, nil
+}
+
+func (r *mockInfractionsRepo) CreateRevision(revision *refractor.InfractionRevision) error {
+	revision.RevisionID = int64(len(r.revisions[revision.InfractionID]) + 1)
+
+	r.revisions[revision.InfractionID] = append(r.revisions[revision.InfractionID], revision)
+
+	return nil
+}
+
+func (r *mockInfractionsRepo) FindRevisions(infractionID int64) ([]*refractor.InfractionRevision, error) {
+	if len(r.revisions[infractionID]) < 1 {
+		return nil, refractor.ErrNotFound
+	}
+
+	return r.revisions[infractionID], nil
+}
+
+func (r *mockInfractionsRepo) FindDeleted(limit int, offset int) (int, []*refractor.Infraction, error) {
+	var foundInfractions []*refractor.Infraction
+
+	for _, infraction := range r.infractions {
+		if infraction.DeletedAt.Valid {
+			foundInfractions = append(foundInfractions, infraction.Infraction())
+		}
+	}
+
+	if len(foundInfractions) < 1 {
+		return 0, nil, refractor.ErrNotFound
+	}
+
+	return len(foundInfractions), foundInfractions, nil
+}
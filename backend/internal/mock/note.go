@@ -0,0 +1,117 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mock
+
+import (
+	"github.com/sniddunc/refractor/refractor"
+	"sort"
+)
+
+type mockNoteRepo struct {
+	notes map[int64]*refractor.PlayerNote
+}
+
+func NewMockNoteRepository(mockNotes map[int64]*refractor.PlayerNote) refractor.NoteRepository {
+	return &mockNoteRepo{
+		notes: mockNotes,
+	}
+}
+
+func (r *mockNoteRepo) Create(note *refractor.PlayerNote) error {
+	newID := int64(len(r.notes) + 1)
+	for r.notes[newID] != nil {
+		newID++
+	}
+
+	r.notes[newID] = note
+
+	note.NoteID = newID
+
+	return nil
+}
+
+func (r *mockNoteRepo) FindByID(id int64) (*refractor.PlayerNote, error) {
+	foundNote := r.notes[id]
+
+	if foundNote == nil {
+		return nil, refractor.ErrNotFound
+	}
+
+	return foundNote, nil
+}
+
+func (r *mockNoteRepo) FindManyByPlayerID(playerID int64) ([]*refractor.PlayerNote, error) {
+	var foundNotes []*refractor.PlayerNote
+
+	for _, note := range r.notes {
+		if note.PlayerID == playerID {
+			foundNotes = append(foundNotes, note)
+		}
+	}
+
+	if len(foundNotes) < 1 {
+		return nil, refractor.ErrNotFound
+	}
+
+	// Match the ordering of the real repository: pinned first, then newest first
+	sort.Slice(foundNotes, func(i, j int) bool {
+		if foundNotes[i].Pinned != foundNotes[j].Pinned {
+			return foundNotes[i].Pinned
+		}
+
+		return foundNotes[i].NoteID > foundNotes[j].NoteID
+	})
+
+	return foundNotes, nil
+}
+
+func (r *mockNoteRepo) Update(id int64, args refractor.UpdateArgs) (*refractor.PlayerNote, error) {
+	note := r.notes[id]
+
+	if note == nil {
+		return nil, refractor.ErrNotFound
+	}
+
+	if args["Body"] != nil {
+		note.Body = args["Body"].(string)
+	}
+
+	if args["Pinned"] != nil {
+		note.Pinned = args["Pinned"].(bool)
+	}
+
+	if args["AdminOnly"] != nil {
+		note.AdminOnly = args["AdminOnly"].(bool)
+	}
+
+	if args["EditedAt"] != nil {
+		note.EditedAt = args["EditedAt"].(int64)
+	}
+
+	return note, nil
+}
+
+func (r *mockNoteRepo) Delete(id int64) error {
+	if r.notes[id] == nil {
+		return refractor.ErrNotFound
+	}
+
+	delete(r.notes, id)
+
+	return nil
+}
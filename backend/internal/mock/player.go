@@ -124,6 +124,20 @@ func (r *mockPlayerRepo) UpdateName(player *refractor.Player, currentName string
 	panic("not implemented")
 }
 
+func (r *mockPlayerRepo) ReplaceName(player *refractor.Player, currentName string) error {
+	if r.players[player.PlayerID] == nil {
+		return refractor.ErrNotFound
+	}
+
+	r.players[player.PlayerID].CurrentName = currentName
+	r.players[player.PlayerID].PreviousNames = nil
+
+	player.CurrentName = currentName
+	player.PreviousNames = nil
+
+	return nil
+}
+
 func (r *mockPlayerRepo) Update(id int64, args refractor.UpdateArgs) (*refractor.Player, error) {
 	if r.players[id] == nil {
 		return nil, refractor.ErrNotFound
@@ -145,6 +159,30 @@ func (r *mockPlayerRepo) Update(id int64, args refractor.UpdateArgs) (*refractor
 		r.players[id].LastSeen = args["LastSeen"].(int64)
 	}
 
+	if args["Watched"] != nil {
+		r.players[id].Watched = args["Watched"].(bool)
+	}
+
+	if args["WatchReason"] != nil {
+		r.players[id].WatchReason = args["WatchReason"].(sql.NullString)
+	}
+
+	if args["WatchedBy"] != nil {
+		r.players[id].WatchedBy = args["WatchedBy"].(sql.NullInt64)
+	}
+
+	if args["WatchedAt"] != nil {
+		r.players[id].WatchedAt = args["WatchedAt"].(sql.NullInt64)
+	}
+
+	if args["WatchExpiresAt"] != nil {
+		r.players[id].WatchExpiresAt = args["WatchExpiresAt"].(sql.NullInt64)
+	}
+
+	if args["Placeholder"] != nil {
+		r.players[id].Placeholder = args["Placeholder"].(bool)
+	}
+
 	return r.players[id].Player(), nil
 }
 
@@ -0,0 +1,96 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mock
+
+import (
+	"github.com/sniddunc/refractor/refractor"
+)
+
+// MockRCONService is a stand-in for the RCON service which records executed commands instead of sending them to a
+// game server. Commands sent to servers which are not in ConnectedServers fail with refractor.ErrNoRCONClient.
+type MockRCONService struct {
+	ConnectedServers           map[int64]bool
+	Commands                   map[int64][]string
+	UnsupportedPrivateMessages bool
+}
+
+func NewMockRCONService(connectedServerIDs ...int64) *MockRCONService {
+	connected := map[int64]bool{}
+	for _, id := range connectedServerIDs {
+		connected[id] = true
+	}
+
+	return &MockRCONService{
+		ConnectedServers: connected,
+		Commands:         map[int64][]string{},
+	}
+}
+
+func (s *MockRCONService) CreateClient(server *refractor.Server) error {
+	s.ConnectedServers[server.ServerID] = true
+	return nil
+}
+
+func (s *MockRCONService) GetClients() map[int64]*refractor.RCONClient {
+	clients := map[int64]*refractor.RCONClient{}
+
+	for id := range s.ConnectedServers {
+		clients[id] = &refractor.RCONClient{Server: &refractor.Server{ServerID: id}}
+	}
+
+	return clients
+}
+
+func (s *MockRCONService) DeleteClient(serverID int64) {
+	delete(s.ConnectedServers, serverID)
+}
+
+func (s *MockRCONService) ExecCommand(serverID int64, command string) (string, error) {
+	if !s.ConnectedServers[serverID] {
+		return "", refractor.ErrNoRCONClient
+	}
+
+	s.Commands[serverID] = append(s.Commands[serverID], command)
+
+	return "", nil
+}
+
+func (s *MockRCONService) SendChatMessage(msgBody *refractor.ChatSendBody) {}
+
+// SendPrivateMessage records the message as a "whisper" command. If UnsupportedPrivateMessages is true, it fails with
+// refractor.ErrUnsupportedCommand like a game without a private message command would.
+func (s *MockRCONService) SendPrivateMessage(serverID int64, playerGameID string, message string) error {
+	if s.UnsupportedPrivateMessages {
+		return refractor.ErrUnsupportedCommand
+	}
+
+	_, err := s.ExecCommand(serverID, "whisper "+playerGameID+" "+message)
+	return err
+}
+
+func (s *MockRCONService) SubscribeJoin(subscriber refractor.BroadcastSubscriber) {}
+
+func (s *MockRCONService) SubscribeQuit(subscriber refractor.BroadcastSubscriber) {}
+
+func (s *MockRCONService) SubscribeOnline(subscriber refractor.StatusSubscriber) {}
+
+func (s *MockRCONService) SubscribeOffline(subscriber refractor.StatusSubscriber) {}
+
+func (s *MockRCONService) SubscribeChat(subscriber refractor.ChatReceiveSubscriber) {}
+
+func (s *MockRCONService) SubscribePlayerListPoll(subscriber refractor.PlayerListPollSubscriber) {}
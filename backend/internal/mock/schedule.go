@@ -0,0 +1,173 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mock
+
+import (
+	"github.com/sniddunc/refractor/refractor"
+	"sort"
+)
+
+type mockScheduleRepo struct {
+	schedules map[int64]*refractor.Schedule
+}
+
+func NewMockScheduleRepository(mockSchedules map[int64]*refractor.Schedule) refractor.ScheduleRepository {
+	return &mockScheduleRepo{
+		schedules: mockSchedules,
+	}
+}
+
+func (r *mockScheduleRepo) Create(schedule *refractor.Schedule) error {
+	newID := int64(len(r.schedules) + 1)
+	for r.schedules[newID] != nil {
+		newID++
+	}
+
+	r.schedules[newID] = schedule
+
+	schedule.ScheduleID = newID
+
+	return nil
+}
+
+func (r *mockScheduleRepo) FindByID(id int64) (*refractor.Schedule, error) {
+	foundSchedule := r.schedules[id]
+
+	if foundSchedule == nil {
+		return nil, refractor.ErrNotFound
+	}
+
+	return foundSchedule, nil
+}
+
+func (r *mockScheduleRepo) FindAll() ([]*refractor.Schedule, error) {
+	var foundSchedules []*refractor.Schedule
+
+	for _, schedule := range r.schedules {
+		foundSchedules = append(foundSchedules, schedule)
+	}
+
+	if len(foundSchedules) < 1 {
+		return nil, refractor.ErrNotFound
+	}
+
+	return foundSchedules, nil
+}
+
+func (r *mockScheduleRepo) Update(id int64, args refractor.UpdateArgs) (*refractor.Schedule, error) {
+	schedule := r.schedules[id]
+	if schedule == nil {
+		return nil, refractor.ErrNotFound
+	}
+
+	if args["Name"] != nil {
+		schedule.Name = args["Name"].(string)
+	}
+
+	if args["Cron"] != nil {
+		schedule.Cron = args["Cron"].(string)
+	}
+
+	if args["ActionType"] != nil {
+		schedule.ActionType = args["ActionType"].(string)
+	}
+
+	if args["Payload"] != nil {
+		schedule.Payload = args["Payload"].(string)
+	}
+
+	if args["ServerID"] != nil {
+		schedule.ServerID = args["ServerID"].(int64)
+	}
+
+	if args["Enabled"] != nil {
+		schedule.Enabled = args["Enabled"].(bool)
+	}
+
+	if args["LastRunAt"] != nil {
+		schedule.LastRunAt = args["LastRunAt"].(int64)
+	}
+
+	return schedule, nil
+}
+
+func (r *mockScheduleRepo) Delete(id int64) error {
+	if r.schedules[id] == nil {
+		return refractor.ErrNotFound
+	}
+
+	delete(r.schedules, id)
+
+	return nil
+}
+
+// MockScheduleRunRepository is exported so that tests can inspect the stored runs
+type MockScheduleRunRepository struct {
+	Runs map[int64]*refractor.ScheduleRun
+}
+
+func NewMockScheduleRunRepository(mockRuns map[int64]*refractor.ScheduleRun) *MockScheduleRunRepository {
+	return &MockScheduleRunRepository{
+		Runs: mockRuns,
+	}
+}
+
+func (r *MockScheduleRunRepository) Create(run *refractor.ScheduleRun) error {
+	newID := int64(len(r.Runs) + 1)
+	for r.Runs[newID] != nil {
+		newID++
+	}
+
+	r.Runs[newID] = run
+
+	run.RunID = newID
+
+	return nil
+}
+
+func (r *MockScheduleRunRepository) FindManyByScheduleID(scheduleID int64, limit int, offset int) (int, []*refractor.ScheduleRun, error) {
+	var foundRuns []*refractor.ScheduleRun
+
+	for _, run := range r.Runs {
+		if run.ScheduleID == scheduleID {
+			foundRuns = append(foundRuns, run)
+		}
+	}
+
+	if len(foundRuns) < 1 {
+		return 0, nil, refractor.ErrNotFound
+	}
+
+	// Newest first
+	sort.Slice(foundRuns, func(i, j int) bool {
+		return foundRuns[i].RunID > foundRuns[j].RunID
+	})
+
+	count := len(foundRuns)
+
+	if offset >= count {
+		return count, []*refractor.ScheduleRun{}, nil
+	}
+
+	end := offset + limit
+	if end > count {
+		end = count
+	}
+
+	return count, foundRuns[offset:end], nil
+}
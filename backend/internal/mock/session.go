@@ -0,0 +1,141 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mock
+
+import (
+	"github.com/sniddunc/refractor/refractor"
+	"sort"
+	"time"
+)
+
+// MockSessionRepository is an in-memory session repository. Stored sessions can be inspected through Sessions.
+type MockSessionRepository struct {
+	Sessions map[int64]*refractor.PlayerSession
+}
+
+func NewMockSessionRepository(mockSessions map[int64]*refractor.PlayerSession) *MockSessionRepository {
+	return &MockSessionRepository{
+		Sessions: mockSessions,
+	}
+}
+
+func (r *MockSessionRepository) Create(session *refractor.PlayerSession) error {
+	newID := int64(len(r.Sessions) + 1)
+	for r.Sessions[newID] != nil {
+		newID++
+	}
+
+	if session.LastSeenAt == 0 {
+		session.LastSeenAt = session.StartedAt
+	}
+
+	r.Sessions[newID] = session
+
+	session.SessionID = newID
+
+	return nil
+}
+
+func (r *MockSessionRepository) FindOpenByServerID(serverID int64) ([]*refractor.PlayerSession, error) {
+	var foundSessions []*refractor.PlayerSession
+
+	for _, session := range r.Sessions {
+		if session.ServerID == serverID && session.EndedAt == 0 {
+			foundSessions = append(foundSessions, session)
+		}
+	}
+
+	return foundSessions, nil
+}
+
+func (r *MockSessionRepository) FindManyByPlayerID(playerID int64, limit int, offset int) (int, []*refractor.PlayerSession, error) {
+	var foundSessions []*refractor.PlayerSession
+
+	for _, session := range r.Sessions {
+		if session.PlayerID == playerID {
+			foundSessions = append(foundSessions, session)
+		}
+	}
+
+	sort.Slice(foundSessions, func(i, j int) bool {
+		return foundSessions[i].StartedAt > foundSessions[j].StartedAt
+	})
+
+	count := len(foundSessions)
+
+	if offset >= count {
+		return count, nil, nil
+	}
+
+	end := offset + limit
+	if end > count {
+		end = count
+	}
+
+	return count, foundSessions[offset:end], nil
+}
+
+func (r *MockSessionRepository) Update(id int64, args refractor.UpdateArgs) error {
+	session := r.Sessions[id]
+
+	if session == nil {
+		return refractor.ErrNotFound
+	}
+
+	if args["EndedAt"] != nil {
+		session.EndedAt = args["EndedAt"].(int64)
+	}
+
+	if args["LastSeenAt"] != nil {
+		session.LastSeenAt = args["LastSeenAt"].(int64)
+	}
+
+	return nil
+}
+
+func (r *MockSessionRepository) CloseAbandoned() (int64, error) {
+	var closed int64
+
+	for _, session := range r.Sessions {
+		if session.EndedAt == 0 {
+			session.EndedAt = session.LastSeenAt
+			closed++
+		}
+	}
+
+	return closed, nil
+}
+
+func (r *MockSessionRepository) GetPlaytime(playerID int64) (map[int64]int64, error) {
+	playtime := map[int64]int64{}
+
+	for _, session := range r.Sessions {
+		if session.PlayerID != playerID {
+			continue
+		}
+
+		endedAt := session.EndedAt
+		if endedAt == 0 {
+			endedAt = time.Now().Unix()
+		}
+
+		playtime[session.ServerID] += endedAt - session.StartedAt
+	}
+
+	return playtime, nil
+}
@@ -0,0 +1,85 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mock
+
+import (
+	"github.com/sniddunc/refractor/refractor"
+)
+
+type mockSpamSettingsRepo struct {
+	settings map[int64]*refractor.SpamSettings
+}
+
+func NewMockSpamSettingsRepository(mockSettings map[int64]*refractor.SpamSettings) refractor.SpamSettingsRepository {
+	return &mockSpamSettingsRepo{
+		settings: mockSettings,
+	}
+}
+
+func (r *mockSpamSettingsRepo) Create(settings *refractor.SpamSettings) error {
+	r.settings[settings.ServerID] = settings
+
+	return nil
+}
+
+func (r *mockSpamSettingsRepo) FindByServerID(serverID int64) (*refractor.SpamSettings, error) {
+	settings := r.settings[serverID]
+
+	if settings == nil {
+		return nil, refractor.ErrNotFound
+	}
+
+	return settings, nil
+}
+
+func (r *mockSpamSettingsRepo) Update(serverID int64, args refractor.UpdateArgs) (*refractor.SpamSettings, error) {
+	settings := r.settings[serverID]
+	if settings == nil {
+		return nil, refractor.ErrNotFound
+	}
+
+	if args["Enabled"] != nil {
+		settings.Enabled = args["Enabled"].(bool)
+	}
+
+	if args["MessageLimit"] != nil {
+		settings.MessageLimit = args["MessageLimit"].(int)
+	}
+
+	if args["MessageWindow"] != nil {
+		settings.MessageWindow = args["MessageWindow"].(int)
+	}
+
+	if args["RepeatLimit"] != nil {
+		settings.RepeatLimit = args["RepeatLimit"].(int)
+	}
+
+	if args["CapsPercent"] != nil {
+		settings.CapsPercent = args["CapsPercent"].(int)
+	}
+
+	if args["CapsMinLength"] != nil {
+		settings.CapsMinLength = args["CapsMinLength"].(int)
+	}
+
+	if args["MuteDuration"] != nil {
+		settings.MuteDuration = args["MuteDuration"].(int)
+	}
+
+	return settings, nil
+}
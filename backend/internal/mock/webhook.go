@@ -0,0 +1,209 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mock
+
+import (
+	"github.com/sniddunc/refractor/refractor"
+	"sort"
+	"sync"
+)
+
+type mockWebhookRepo struct {
+	webhooks map[int64]*refractor.Webhook
+}
+
+func NewMockWebhookRepository(mockWebhooks map[int64]*refractor.Webhook) refractor.WebhookRepository {
+	return &mockWebhookRepo{
+		webhooks: mockWebhooks,
+	}
+}
+
+func (r *mockWebhookRepo) Create(webhook *refractor.Webhook) error {
+	newID := int64(len(r.webhooks) + 1)
+	for r.webhooks[newID] != nil {
+		newID++
+	}
+
+	r.webhooks[newID] = webhook
+
+	webhook.WebhookID = newID
+
+	return nil
+}
+
+func (r *mockWebhookRepo) FindByID(id int64) (*refractor.Webhook, error) {
+	foundWebhook := r.webhooks[id]
+
+	if foundWebhook == nil {
+		return nil, refractor.ErrNotFound
+	}
+
+	return foundWebhook, nil
+}
+
+func (r *mockWebhookRepo) FindAll() ([]*refractor.Webhook, error) {
+	var foundWebhooks []*refractor.Webhook
+
+	for _, webhook := range r.webhooks {
+		foundWebhooks = append(foundWebhooks, webhook)
+	}
+
+	if len(foundWebhooks) < 1 {
+		return nil, refractor.ErrNotFound
+	}
+
+	return foundWebhooks, nil
+}
+
+func (r *mockWebhookRepo) Update(id int64, args refractor.UpdateArgs) (*refractor.Webhook, error) {
+	webhook := r.webhooks[id]
+	if webhook == nil {
+		return nil, refractor.ErrNotFound
+	}
+
+	if args["Name"] != nil {
+		webhook.Name = args["Name"].(string)
+	}
+
+	if args["URL"] != nil {
+		webhook.URL = args["URL"].(string)
+	}
+
+	if args["Secret"] != nil {
+		webhook.Secret = args["Secret"].(string)
+	}
+
+	if args["Events"] != nil {
+		webhook.Events = args["Events"].([]string)
+	}
+
+	if args["Enabled"] != nil {
+		webhook.Enabled = args["Enabled"].(bool)
+	}
+
+	return webhook, nil
+}
+
+func (r *mockWebhookRepo) Delete(id int64) error {
+	if r.webhooks[id] == nil {
+		return refractor.ErrNotFound
+	}
+
+	delete(r.webhooks, id)
+
+	return nil
+}
+
+// MockWebhookDeliveryRepository is exported so that tests can inspect the stored deliveries. Deliveries are made from
+// their own goroutines so access to Deliveries is guarded by a lock.
+type MockWebhookDeliveryRepository struct {
+	Deliveries map[int64]*refractor.WebhookDelivery
+	lock       sync.Mutex
+}
+
+func NewMockWebhookDeliveryRepository(mockDeliveries map[int64]*refractor.WebhookDelivery) *MockWebhookDeliveryRepository {
+	return &MockWebhookDeliveryRepository{
+		Deliveries: mockDeliveries,
+	}
+}
+
+func (r *MockWebhookDeliveryRepository) Create(delivery *refractor.WebhookDelivery) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	newID := int64(len(r.Deliveries) + 1)
+	for r.Deliveries[newID] != nil {
+		newID++
+	}
+
+	delivery.DeliveryID = newID
+
+	stored := *delivery
+	r.Deliveries[newID] = &stored
+
+	return nil
+}
+
+func (r *MockWebhookDeliveryRepository) Update(id int64, args refractor.UpdateArgs) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delivery := r.Deliveries[id]
+	if delivery == nil {
+		return refractor.ErrNotFound
+	}
+
+	if args["Status"] != nil {
+		delivery.Status = args["Status"].(string)
+	}
+
+	if args["Attempts"] != nil {
+		delivery.Attempts = args["Attempts"].(int)
+	}
+
+	if args["ResponseStatus"] != nil {
+		delivery.ResponseStatus = args["ResponseStatus"].(int)
+	}
+
+	if args["Error"] != nil {
+		delivery.Error = args["Error"].(string)
+	}
+
+	if args["CompletedAt"] != nil {
+		delivery.CompletedAt = args["CompletedAt"].(int64)
+	}
+
+	return nil
+}
+
+func (r *MockWebhookDeliveryRepository) FindManyByWebhookID(webhookID int64, limit int, offset int) (int, []*refractor.WebhookDelivery, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var foundDeliveries []*refractor.WebhookDelivery
+
+	for _, delivery := range r.Deliveries {
+		if delivery.WebhookID == webhookID {
+			// Copies are returned so that callers can't race with deliveries which are still being attempted
+			found := *delivery
+			foundDeliveries = append(foundDeliveries, &found)
+		}
+	}
+
+	if len(foundDeliveries) < 1 {
+		return 0, nil, refractor.ErrNotFound
+	}
+
+	// Newest first
+	sort.Slice(foundDeliveries, func(i, j int) bool {
+		return foundDeliveries[i].DeliveryID > foundDeliveries[j].DeliveryID
+	})
+
+	count := len(foundDeliveries)
+
+	if offset >= count {
+		return count, []*refractor.WebhookDelivery{}, nil
+	}
+
+	end := offset + limit
+	if end > count {
+		end = count
+	}
+
+	return count, foundDeliveries[offset:end], nil
+}
@@ -0,0 +1,71 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mock
+
+import (
+	"github.com/sniddunc/refractor/pkg/broadcast"
+	"github.com/sniddunc/refractor/refractor"
+	"net"
+)
+
+// MockWebsocketService records broadcast messages in Messages instead of sending them to clients.
+type MockWebsocketService struct {
+	Messages []*refractor.WebsocketMessage
+}
+
+func NewMockWebsocketService() *MockWebsocketService {
+	return &MockWebsocketService{
+		Messages: []*refractor.WebsocketMessage{},
+	}
+}
+
+func (s *MockWebsocketService) Broadcast(message *refractor.WebsocketMessage) {
+	s.Messages = append(s.Messages, message)
+}
+
+func (s *MockWebsocketService) CreateClient(userID int64, conn net.Conn) {
+	panic("implement me")
+}
+
+func (s *MockWebsocketService) StartPool() {
+	panic("implement me")
+}
+
+func (s *MockWebsocketService) OnPlayerJoin(fields broadcast.Fields, serverID int64, gameConfig *refractor.GameConfig) {
+	panic("implement me")
+}
+
+func (s *MockWebsocketService) OnPlayerQuit(fields broadcast.Fields, serverID int64, gameConfig *refractor.GameConfig) {
+	panic("implement me")
+}
+
+func (s *MockWebsocketService) OnServerOnline(serverID int64) {
+	panic("implement me")
+}
+
+func (s *MockWebsocketService) OnServerOffline(serverID int64) {
+	panic("implement me")
+}
+
+func (s *MockWebsocketService) SubscribeChatSend(subscriber refractor.ChatSendSubscriber) {
+	panic("implement me")
+}
+
+func (s *MockWebsocketService) SubscribeWhisperSend(subscriber refractor.WhisperSendSubscriber) {
+	panic("implement me")
+}
@@ -0,0 +1,261 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package note
+
+import (
+	"fmt"
+	"github.com/sniddunc/bitperms"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/pkg/perms"
+	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"time"
+)
+
+type noteService struct {
+	repo          refractor.NoteRepository
+	playerService refractor.PlayerService
+	userService   refractor.UserService
+	log           log.Logger
+}
+
+func NewNoteService(repo refractor.NoteRepository, playerService refractor.PlayerService,
+	userService refractor.UserService, log log.Logger) refractor.NoteService {
+	return &noteService{
+		repo:          repo,
+		playerService: playerService,
+		userService:   userService,
+		log:           log,
+	}
+}
+
+func (s *noteService) CreateNote(playerID int64, body params.CreateNoteParams) (*refractor.PlayerNote, *refractor.ServiceResponse) {
+	// Make sure player exists
+	if _, res := s.playerService.GetPlayerByID(playerID); !res.Success {
+		return nil, res
+	}
+
+	// Only admins can create notes which only admins can see
+	if body.AdminOnly && !isAdmin(body.UserMeta) {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    config.MessageNoPermission,
+		}
+	}
+
+	newNote := &refractor.PlayerNote{
+		PlayerID:  playerID,
+		UserID:    body.UserMeta.UserID,
+		Body:      body.Body,
+		Pinned:    body.Pinned,
+		AdminOnly: body.AdminOnly,
+		Timestamp: time.Now().Unix(),
+	}
+
+	if err := s.repo.Create(newNote); err != nil {
+		s.log.Error("Could not insert new player note into repository. Error: %v", err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	s.setAuthorName(newNote)
+
+	return newNote, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Note created",
+	}
+}
+
+// GetPlayerNotes gets the notes on a player which the given user is allowed to see. Admin only notes are left out
+// for users who are not admins.
+func (s *noteService) GetPlayerNotes(playerID int64, user params.UserMeta) ([]*refractor.PlayerNote, *refractor.ServiceResponse) {
+	// Make sure player exists
+	if _, res := s.playerService.GetPlayerByID(playerID); !res.Success {
+		return nil, res
+	}
+
+	notes, err := s.repo.FindManyByPlayerID(playerID)
+	if err != nil && err != refractor.ErrNotFound {
+		s.log.Error("Could not get notes of player ID %d. Error: %v", playerID, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	canSeeAdminOnly := isAdmin(&user)
+	visibleNotes := []*refractor.PlayerNote{}
+
+	// Get author names. The same few staff members usually write most notes on a player, so names are cached.
+	authorNames := map[int64]string{}
+	for _, note := range notes {
+		if note.AdminOnly && !canSeeAdminOnly {
+			continue
+		}
+
+		name, ok := authorNames[note.UserID]
+		if !ok {
+			author, err := s.userService.GetUserByID(note.UserID)
+			if err == nil {
+				name = author.Username
+				authorNames[note.UserID] = name
+			} else {
+				s.log.Error("Could not get note author by ID. Error: %v", err)
+			}
+		}
+
+		note.AuthorName = name
+		visibleNotes = append(visibleNotes, note)
+	}
+
+	return visibleNotes, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    fmt.Sprintf("Fetched %d notes", len(visibleNotes)),
+	}
+}
+
+func (s *noteService) UpdateNote(playerID int64, noteID int64, body params.UpdateNoteParams) (*refractor.PlayerNote, *refractor.ServiceResponse) {
+	note, res := s.getNote(playerID, noteID, body.UserMeta)
+	if !res.Success {
+		return nil, res
+	}
+
+	if !canModifyNote(note.UserID, body.UserMeta) {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    config.MessageNoPermission,
+		}
+	}
+
+	args := refractor.UpdateArgs{}
+
+	if body.Body != nil && *body.Body != note.Body {
+		args["Body"] = *body.Body
+		args["EditedAt"] = time.Now().Unix()
+	}
+
+	if body.Pinned != nil {
+		args["Pinned"] = *body.Pinned
+	}
+
+	if body.AdminOnly != nil {
+		if !isAdmin(body.UserMeta) {
+			return nil, &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageNoPermission,
+			}
+		}
+
+		args["AdminOnly"] = *body.AdminOnly
+	}
+
+	if len(args) == 0 {
+		return note, &refractor.ServiceResponse{
+			Success:    true,
+			StatusCode: http.StatusOK,
+			Message:    "No changes were made",
+		}
+	}
+
+	updatedNote, err := s.repo.Update(noteID, args)
+	if err != nil {
+		s.log.Error("Could not update player note ID %d. Error: %v", noteID, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	s.setAuthorName(updatedNote)
+
+	return updatedNote, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Note updated",
+	}
+}
+
+func (s *noteService) DeleteNote(playerID int64, noteID int64, user params.UserMeta) *refractor.ServiceResponse {
+	note, res := s.getNote(playerID, noteID, &user)
+	if !res.Success {
+		return res
+	}
+
+	if !canModifyNote(note.UserID, &user) {
+		return &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    config.MessageNoPermission,
+		}
+	}
+
+	if err := s.repo.Delete(noteID); err != nil {
+		s.log.Error("Could not delete player note ID %d. Error: %v", noteID, err)
+		return refractor.InternalErrorResponse
+	}
+
+	return &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Note deleted",
+	}
+}
+
+// getNote gets a note by ID and makes sure it belongs to the given player and is visible to the user. Notes which
+// the user can't see are reported as not existing.
+func (s *noteService) getNote(playerID int64, noteID int64, user *params.UserMeta) (*refractor.PlayerNote, *refractor.ServiceResponse) {
+	note, err := s.repo.FindByID(noteID)
+	if err != nil && err != refractor.ErrNotFound {
+		s.log.Error("Could not get player note by id %d. Error: %v", noteID, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	if note == nil || note.PlayerID != playerID || (note.AdminOnly && !isAdmin(user)) {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    config.MessageInvalidIDProvided,
+		}
+	}
+
+	return note, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Note fetched",
+	}
+}
+
+func (s *noteService) setAuthorName(note *refractor.PlayerNote) {
+	user, err := s.userService.GetUserByID(note.UserID)
+	if err != nil {
+		s.log.Error("Could not get note author by ID. Error: %v", err)
+		return
+	}
+
+	note.AuthorName = user.Username
+}
+
+func isAdmin(user *params.UserMeta) bool {
+	return perms.UserHasFullAccess(bitperms.PermissionValue(user.Permissions))
+}
+
+// canModifyNote checks if a user can edit or delete a note written by authorID. Authors can modify their own notes
+// and admins can modify anyone's.
+func canModifyNote(authorID int64, user *params.UserMeta) bool {
+	return authorID == user.UserID || isAdmin(user)
+}
@@ -0,0 +1,405 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package note
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/internal/mock"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/internal/player"
+	"github.com/sniddunc/refractor/internal/user"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/pkg/perms"
+	"github.com/sniddunc/refractor/refractor"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func Test_noteService_CreateNote(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	type fields struct {
+		mockPlayers map[int64]*refractor.DBPlayer
+		mockNotes   map[int64]*refractor.PlayerNote
+	}
+	type args struct {
+		playerID int64
+		body     params.CreateNoteParams
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantRes *refractor.ServiceResponse
+	}{
+		{
+			name: "note.createnote.1",
+			fields: fields{
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockNotes: map[int64]*refractor.PlayerNote{},
+			},
+			args: args{
+				playerID: 1,
+				body: params.CreateNoteParams{
+					Body:     "Was warned verbally",
+					Pinned:   true,
+					UserMeta: &params.UserMeta{UserID: 1},
+				},
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Note created",
+			},
+		},
+		{
+			name: "note.createnote.2",
+			fields: fields{
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockNotes: map[int64]*refractor.PlayerNote{},
+			},
+			args: args{
+				playerID: 1,
+				body: params.CreateNoteParams{
+					Body:      "Suspected alt of another player",
+					AdminOnly: true,
+					UserMeta:  &params.UserMeta{UserID: 1, Permissions: perms.FULL_ACCESS},
+				},
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Note created",
+			},
+		},
+		{
+			name: "note.createnote.3",
+			fields: fields{
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockNotes: map[int64]*refractor.PlayerNote{},
+			},
+			args: args{
+				playerID: 1,
+				body: params.CreateNoteParams{
+					Body:      "Suspected alt of another player",
+					AdminOnly: true,
+					UserMeta:  &params.UserMeta{UserID: 1},
+				},
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageNoPermission,
+			},
+		},
+		{
+			name: "note.createnote.4",
+			fields: fields{
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockNotes: map[int64]*refractor.PlayerNote{},
+			},
+			args: args{
+				playerID: 2,
+				body: params.CreateNoteParams{
+					Body:     "Was warned verbally",
+					UserMeta: &params.UserMeta{UserID: 1},
+				},
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			playerService := player.NewPlayerService(mock.NewMockPlayerRepository(tt.fields.mockPlayers), testLogger)
+			userService := user.NewUserService(mock.NewMockUserRepository(mock.GetMockUsers()), testLogger)
+			noteService := NewNoteService(mock.NewMockNoteRepository(tt.fields.mockNotes), playerService, userService,
+				testLogger)
+
+			note, res := noteService.CreateNote(tt.args.playerID, tt.args.body)
+
+			assert.True(t, tt.wantRes.Equals(res), "tt.wantRes = %v and res = %v should be equal", tt.wantRes, res)
+
+			if res.Success {
+				assert.Equal(t, "tester", note.AuthorName, "Author name should be set")
+				assert.Equal(t, tt.args.body.Pinned, note.Pinned, "Pinned should be equal")
+				assert.Equal(t, tt.args.body.AdminOnly, note.AdminOnly, "AdminOnly should be equal")
+			}
+		})
+	}
+}
+
+func Test_noteService_GetPlayerNotes(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	type fields struct {
+		mockPlayers map[int64]*refractor.DBPlayer
+		mockNotes   map[int64]*refractor.PlayerNote
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		user        params.UserMeta
+		wantNoteIDs []int64
+	}{
+		{
+			name: "note.getplayernotes.1",
+			fields: fields{
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockNotes: map[int64]*refractor.PlayerNote{
+					1: {NoteID: 1, PlayerID: 1, UserID: 1, Body: "Was warned verbally"},
+					2: {NoteID: 2, PlayerID: 1, UserID: 1, Body: "Suspected alt of another player", AdminOnly: true},
+					3: {NoteID: 3, PlayerID: 1, UserID: 2, Body: "Plays on the EU server", Pinned: true},
+				},
+			},
+			user:        params.UserMeta{UserID: 1, Permissions: perms.FULL_ACCESS},
+			wantNoteIDs: []int64{3, 2, 1},
+		},
+		{
+			name: "note.getplayernotes.2",
+			fields: fields{
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockNotes: map[int64]*refractor.PlayerNote{
+					1: {NoteID: 1, PlayerID: 1, UserID: 1, Body: "Was warned verbally"},
+					2: {NoteID: 2, PlayerID: 1, UserID: 1, Body: "Suspected alt of another player", AdminOnly: true},
+					3: {NoteID: 3, PlayerID: 1, UserID: 2, Body: "Plays on the EU server", Pinned: true},
+				},
+			},
+			user:        params.UserMeta{UserID: 1},
+			wantNoteIDs: []int64{3, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			playerService := player.NewPlayerService(mock.NewMockPlayerRepository(tt.fields.mockPlayers), testLogger)
+			userService := user.NewUserService(mock.NewMockUserRepository(mock.GetMockUsers()), testLogger)
+			noteService := NewNoteService(mock.NewMockNoteRepository(tt.fields.mockNotes), playerService, userService,
+				testLogger)
+
+			notes, res := noteService.GetPlayerNotes(1, tt.user)
+			assert.True(t, res.Success, "GetPlayerNotes should succeed. Message: %s", res.Message)
+
+			var noteIDs []int64
+			for _, note := range notes {
+				noteIDs = append(noteIDs, note.NoteID)
+			}
+
+			assert.Equal(t, tt.wantNoteIDs, noteIDs, "Pinned notes should come first and admin only notes should be hidden from non-admins")
+		})
+	}
+}
+
+func Test_noteService_UpdateNote(t *testing.T) {
+	pinned := true
+	adminOnly := true
+
+	testLogger, _ := log.NewLogger(true, false)
+
+	type fields struct {
+		mockPlayers map[int64]*refractor.DBPlayer
+		mockNotes   map[int64]*refractor.PlayerNote
+	}
+	type args struct {
+		noteID int64
+		body   params.UpdateNoteParams
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantRes *refractor.ServiceResponse
+	}{
+		{
+			name: "note.updatenote.1",
+			fields: fields{
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockNotes: map[int64]*refractor.PlayerNote{
+					1: {NoteID: 1, PlayerID: 1, UserID: 1, Body: "Was warned verbally"},
+				},
+			},
+			args: args{
+				noteID: 1,
+				body: params.UpdateNoteParams{
+					Pinned:   &pinned,
+					UserMeta: &params.UserMeta{UserID: 1},
+				},
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Note updated",
+			},
+		},
+		{
+			name: "note.updatenote.2",
+			fields: fields{
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockNotes: map[int64]*refractor.PlayerNote{
+					3: {NoteID: 3, PlayerID: 1, UserID: 2, Body: "Plays on the EU server", Pinned: true},
+				},
+			},
+			args: args{
+				noteID: 3,
+				body: params.UpdateNoteParams{
+					Pinned:   &pinned,
+					UserMeta: &params.UserMeta{UserID: 1},
+				},
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageNoPermission,
+			},
+		},
+		{
+			name: "note.updatenote.3",
+			fields: fields{
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockNotes: map[int64]*refractor.PlayerNote{
+					1: {NoteID: 1, PlayerID: 1, UserID: 1, Body: "Was warned verbally"},
+				},
+			},
+			args: args{
+				noteID: 1,
+				body: params.UpdateNoteParams{
+					AdminOnly: &adminOnly,
+					UserMeta:  &params.UserMeta{UserID: 1},
+				},
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageNoPermission,
+			},
+		},
+		{
+			name: "note.updatenote.4",
+			fields: fields{
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockNotes: map[int64]*refractor.PlayerNote{
+					2: {NoteID: 2, PlayerID: 1, UserID: 1, Body: "Suspected alt of another player", AdminOnly: true},
+				},
+			},
+			args: args{
+				noteID: 2,
+				body: params.UpdateNoteParams{
+					Pinned:   &pinned,
+					UserMeta: &params.UserMeta{UserID: 1},
+				},
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			},
+		},
+		{
+			name: "note.updatenote.5",
+			fields: fields{
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockNotes: map[int64]*refractor.PlayerNote{
+					3: {NoteID: 3, PlayerID: 1, UserID: 2, Body: "Plays on the EU server", Pinned: true},
+				},
+			},
+			args: args{
+				noteID: 3,
+				body: params.UpdateNoteParams{
+					AdminOnly: &adminOnly,
+					UserMeta:  &params.UserMeta{UserID: 1, Permissions: perms.SUPER_ADMIN},
+				},
+			},
+			wantRes: &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Note updated",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			playerService := player.NewPlayerService(mock.NewMockPlayerRepository(tt.fields.mockPlayers), testLogger)
+			userService := user.NewUserService(mock.NewMockUserRepository(mock.GetMockUsers()), testLogger)
+			noteService := NewNoteService(mock.NewMockNoteRepository(tt.fields.mockNotes), playerService, userService,
+				testLogger)
+
+			_, res := noteService.UpdateNote(1, tt.args.noteID, tt.args.body)
+
+			assert.True(t, tt.wantRes.Equals(res), "tt.wantRes = %v and res = %v should be equal", tt.wantRes, res)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/pkg/config"
+	"net/url"
+	"strings"
+)
+
+// CreateAppealParams holds the data we expect when opening an appeal against an infraction
+type CreateAppealParams struct {
+	InfractionID int64  `json:"infractionId" form:"infractionId"`
+	Statement    string `json:"statement" form:"statement"`
+	*UserMeta
+}
+
+func (body *CreateAppealParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	body.Statement = strings.TrimSpace(body.Statement)
+
+	if body.InfractionID < 1 {
+		errors.Set("infractionId", config.MessageInvalidIDProvided)
+	}
+
+	if len(body.Statement) < config.AppealStatementMinLen || len(body.Statement) > config.AppealStatementMaxLen {
+		errors.Set("statement", fmt.Sprintf("Statement must be between %d and %d characters in length",
+			config.AppealStatementMinLen, config.AppealStatementMaxLen))
+	}
+
+	return len(errors) == 0, errors
+}
+
+// AssignAppealReviewerParams holds the data we expect when assigning a reviewer to an appeal
+type AssignAppealReviewerParams struct {
+	ReviewerID int64 `json:"reviewerId" form:"reviewerId"`
+	*UserMeta
+}
+
+func (body *AssignAppealReviewerParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	if body.ReviewerID < 1 {
+		errors.Set("reviewerId", config.MessageInvalidIDProvided)
+	}
+
+	return len(errors) == 0, errors
+}
+
+// AppealNoteParams holds the data we expect when adding notes to an appeal or deciding on it
+type AppealNoteParams struct {
+	Notes string `json:"notes" form:"notes"`
+	*UserMeta
+}
+
+func (body *AppealNoteParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	body.Notes = strings.TrimSpace(body.Notes)
+
+	if len(body.Notes) < config.AppealNotesMinLen || len(body.Notes) > config.AppealNotesMaxLen {
+		errors.Set("notes", fmt.Sprintf("Notes must be between %d and %d characters in length",
+			config.AppealNotesMinLen, config.AppealNotesMaxLen))
+	}
+
+	return len(errors) == 0, errors
+}
@@ -0,0 +1,113 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestCreateAppealParams_Validate(t *testing.T) {
+	type fields struct {
+		InfractionID int64
+		Statement    string
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		wantValid bool
+	}{
+		{
+			name: "params.appeal.create.1",
+			fields: fields{
+				InfractionID: 1,
+				Statement:    "I was not cheating",
+			},
+			wantValid: true,
+		},
+		{
+			name: "params.appeal.create.2",
+			fields: fields{
+				InfractionID: 0,
+				Statement:    "   ",
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.appeal.create.3",
+			fields: fields{
+				InfractionID: 1,
+				Statement:    strings.Repeat("a", config.AppealStatementMaxLen+1),
+			},
+			wantValid: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &CreateAppealParams{
+				InfractionID: tt.fields.InfractionID,
+				Statement:    tt.fields.Statement,
+			}
+
+			valid, errors := body.Validate()
+			assert.Equal(t, tt.wantValid, valid, "Validate returned the wrong values. Errors: %v", errors)
+		})
+	}
+}
+
+func TestSearchAppealsParams_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      SearchAppealsParams
+		wantValid bool
+	}{
+		{
+			name: "params.appeal.search.1",
+			body: SearchAppealsParams{
+				Status:   "OPEN",
+				PlayerID: "1",
+				SearchParams: SearchParams{
+					Limit: 10,
+				},
+			},
+			wantValid: true,
+		},
+		{
+			name: "params.appeal.search.2",
+			body: SearchAppealsParams{
+				Status: "CLOSED",
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.appeal.search.3",
+			body: SearchAppealsParams{
+				ReviewerID: "abc",
+			},
+			wantValid: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, errors := tt.body.Validate()
+			assert.Equal(t, tt.wantValid, valid, "Validate returned the wrong values. Errors: %v", errors)
+		})
+	}
+}
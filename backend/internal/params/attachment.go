@@ -0,0 +1,78 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/pkg/config"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// attachmentContentTypes maps the file extensions which can be uploaded as evidence to the content type they are
+// served with. Files are always served with the content type of their extension rather than whatever the uploader
+// claimed it to be.
+var attachmentContentTypes = map[string]string{
+	".png":    "image/png",
+	".jpg":    "image/jpeg",
+	".jpeg":   "image/jpeg",
+	".gif":    "image/gif",
+	".webp":   "image/webp",
+	".mp4":    "video/mp4",
+	".webm":   "video/webm",
+	".txt":    "text/plain",
+	".log":    "text/plain",
+	".dem":    "application/octet-stream",
+	".replay": "application/octet-stream",
+	".zip":    "application/zip",
+}
+
+// UploadAttachmentParams holds the metadata of an uploaded evidence file. It is populated from the multipart file
+// header rather than bound from the request body.
+type UploadAttachmentParams struct {
+	FileName    string
+	Size        int64
+	ContentType string // set during validation
+	*UserMeta
+}
+
+func (body *UploadAttachmentParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	// Strip any directories the client may have included in the file name
+	body.FileName = strings.TrimSpace(filepath.Base(strings.ReplaceAll(body.FileName, "\\", "/")))
+
+	if body.FileName == "" || body.FileName == "." || body.FileName == "/" {
+		errors.Set("file", "File name is required")
+	} else if len(body.FileName) > config.AttachmentFileNameMaxLen {
+		errors.Set("file", fmt.Sprintf("File name must be no longer than %d characters", config.AttachmentFileNameMaxLen))
+	} else if contentType, ok := attachmentContentTypes[strings.ToLower(filepath.Ext(body.FileName))]; !ok {
+		errors.Set("file", "File type not allowed")
+	} else {
+		body.ContentType = contentType
+	}
+
+	if body.Size < 1 {
+		errors.Set("size", "File is empty")
+	} else if body.Size > config.AttachmentMaxSize {
+		errors.Set("size", fmt.Sprintf("File must be no larger than %d MB", config.AttachmentMaxSize/1024/1024))
+	}
+
+	return len(errors) == 0, errors
+}
@@ -0,0 +1,99 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestUploadAttachmentParams_Validate(t *testing.T) {
+	type fields struct {
+		FileName string
+		Size     int64
+	}
+	tests := []struct {
+		name            string
+		fields          fields
+		wantValid       bool
+		wantFileName    string
+		wantContentType string
+	}{
+		{
+			name: "params.attachment.upload.1",
+			fields: fields{
+				FileName: "Screenshot.PNG",
+				Size:     1024,
+			},
+			wantValid:       true,
+			wantFileName:    "Screenshot.PNG",
+			wantContentType: "image/png",
+		},
+		{
+			name: "params.attachment.upload.2",
+			fields: fields{
+				FileName: "..\\..\\evidence.replay",
+				Size:     1024,
+			},
+			wantValid:       true,
+			wantFileName:    "evidence.replay",
+			wantContentType: "application/octet-stream",
+		},
+		{
+			name: "params.attachment.upload.3",
+			fields: fields{
+				FileName: "payload.html",
+				Size:     1024,
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.attachment.upload.4",
+			fields: fields{
+				FileName: "video.mp4",
+				Size:     config.AttachmentMaxSize + 1,
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.attachment.upload.5",
+			fields: fields{
+				FileName: "",
+				Size:     0,
+			},
+			wantValid: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &UploadAttachmentParams{
+				FileName: tt.fields.FileName,
+				Size:     tt.fields.Size,
+			}
+
+			valid, errors := body.Validate()
+			assert.Equal(t, tt.wantValid, valid, "Validate returned the wrong values. Errors: %v", errors)
+
+			if tt.wantValid {
+				assert.Equal(t, tt.wantFileName, body.FileName, "File names should be equal")
+				assert.Equal(t, tt.wantContentType, body.ContentType, "Content types should be equal")
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/pkg/config"
+	"net/url"
+	"strings"
+)
+
+var validBanImportFormats = []string{"minecraft", "mordhau"}
+
+// ImportBansParams holds the data we expect when importing a ban list. Size is the size of the uploaded ban list and
+// is populated from the multipart file header rather than bound from the request body. If DryRun is true, the ban
+// list is checked for conflicts but nothing is stored.
+type ImportBansParams struct {
+	Format   string
+	ServerID int64
+	DryRun   bool
+	Size     int64
+}
+
+func (body *ImportBansParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	body.Format = strings.ToLower(strings.TrimSpace(body.Format))
+
+	if !containsString(validBanImportFormats, body.Format) {
+		errors.Set("format", fmt.Sprintf("Format must be one of: %s", strings.Join(validBanImportFormats, ", ")))
+	}
+
+	if body.ServerID < 1 {
+		errors.Set("serverId", "Invalid server ID")
+	}
+
+	if body.Size < 1 {
+		errors.Set("file", "File is empty")
+	} else if body.Size > config.BanImportMaxSize {
+		errors.Set("file", fmt.Sprintf("File must be no larger than %d MB", config.BanImportMaxSize/1024/1024))
+	}
+
+	return len(errors) == 0, errors
+}
@@ -0,0 +1,95 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestImportBansParams_Validate(t *testing.T) {
+	type fields struct {
+		Format   string
+		ServerID int64
+		Size     int64
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		wantValid bool
+	}{
+		{
+			name: "params.banimport.1",
+			fields: fields{
+				Format:   "minecraft",
+				ServerID: 1,
+				Size:     1024,
+			},
+			wantValid: true,
+		},
+		{
+			name: "params.banimport.2",
+			fields: fields{
+				Format:   " Mordhau ",
+				ServerID: 1,
+				Size:     config.BanImportMaxSize,
+			},
+			wantValid: true,
+		},
+		{
+			name: "params.banimport.3",
+			fields: fields{
+				Format:   "squad",
+				ServerID: 1,
+				Size:     1024,
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.banimport.4",
+			fields: fields{
+				Format:   "minecraft",
+				ServerID: 0,
+				Size:     0,
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.banimport.5",
+			fields: fields{
+				Format:   "minecraft",
+				ServerID: 1,
+				Size:     config.BanImportMaxSize + 1,
+			},
+			wantValid: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &ImportBansParams{
+				Format:   tt.fields.Format,
+				ServerID: tt.fields.ServerID,
+				Size:     tt.fields.Size,
+			}
+
+			valid, errors := body.Validate()
+			assert.Equal(t, tt.wantValid, valid, "Validate returned the wrong values. Errors: %v", errors)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/pkg/config"
+	"net/url"
+	"strings"
+)
+
+// WhisperPlayerParams holds the data we expect when sending a private message to a player
+type WhisperPlayerParams struct {
+	ServerID int64  `json:"serverId" form:"serverId"`
+	Message  string `json:"message" form:"message"`
+	*UserMeta
+}
+
+func (body *WhisperPlayerParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	body.Message = strings.TrimSpace(body.Message)
+
+	if body.ServerID < 1 {
+		errors.Set("serverId", "A valid server ID is required")
+	}
+
+	if len(body.Message) < config.WhisperMessageMinLen || len(body.Message) > config.WhisperMessageMaxLen {
+		errors.Set("message", fmt.Sprintf("Message must be between %d and %d characters in length",
+			config.WhisperMessageMinLen, config.WhisperMessageMaxLen))
+	}
+
+	return len(errors) == 0, errors
+}
@@ -0,0 +1,80 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestWhisperPlayerParams_Validate(t *testing.T) {
+	type fields struct {
+		ServerID int64
+		Message  string
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		wantValid bool
+	}{
+		{
+			name: "params.whisper.1",
+			fields: fields{
+				ServerID: 1,
+				Message:  "Please stop spawn camping",
+			},
+			wantValid: true,
+		},
+		{
+			name: "params.whisper.2",
+			fields: fields{
+				ServerID: 0,
+				Message:  "Hello",
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.whisper.3",
+			fields: fields{
+				ServerID: 1,
+				Message:  "   ",
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.whisper.4",
+			fields: fields{
+				ServerID: 1,
+				Message:  strings.Repeat("a", 257),
+			},
+			wantValid: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &WhisperPlayerParams{
+				ServerID: tt.fields.ServerID,
+				Message:  tt.fields.Message,
+			}
+
+			valid, errors := body.Validate()
+			assert.Equal(t, tt.wantValid, valid, "Validate returned the wrong values. Errors: %v", errors)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/pkg/config"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var validChatFilterMatchTypes = []string{"WORD", "REGEX"}
+var validChatFilterActionTypes = []string{"ALERT", "WARNING", "MUTE", "KICK"}
+
+// CreateChatFilterRuleParams holds the data we expect when creating a chat filter rule
+type CreateChatFilterRuleParams struct {
+	Pattern        string `json:"pattern" form:"pattern"`
+	MatchType      string `json:"matchType" form:"matchType"`
+	Leetspeak      bool   `json:"leetspeak" form:"leetspeak"`
+	ActionType     string `json:"actionType" form:"actionType"`
+	ActionDuration int    `json:"actionDuration" form:"actionDuration"`
+	ActionReason   string `json:"actionReason" form:"actionReason"`
+}
+
+func (body *CreateChatFilterRuleParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	body.Pattern = strings.TrimSpace(body.Pattern)
+
+	validateChatFilterPattern(body.Pattern, errors)
+	validateChatFilterMatchType(body.MatchType, errors)
+	validateChatFilterActionType(body.ActionType, errors)
+	validateChatFilterActionDuration(body.ActionDuration, errors)
+	validateChatFilterActionReason(body.ActionReason, errors)
+
+	// Checks which depend on more than one field are only run once the fields themselves are valid
+	if len(errors) == 0 {
+		validateChatFilterRuleFields(body.Pattern, body.MatchType, body.ActionType, body.ActionDuration, errors)
+	}
+
+	return len(errors) == 0, errors
+}
+
+// UpdateChatFilterRuleParams holds the data we expect when updating a chat filter rule. Only fields which are set are
+// updated. Since a rule's pattern and action depend on its other fields, the updated rule as a whole is checked by the
+// chat filter service.
+type UpdateChatFilterRuleParams struct {
+	Pattern        *string `json:"pattern" form:"pattern"`
+	MatchType      *string `json:"matchType" form:"matchType"`
+	Leetspeak      *bool   `json:"leetspeak" form:"leetspeak"`
+	ActionType     *string `json:"actionType" form:"actionType"`
+	ActionDuration *int    `json:"actionDuration" form:"actionDuration"`
+	ActionReason   *string `json:"actionReason" form:"actionReason"`
+	Enabled        *bool   `json:"enabled" form:"enabled"`
+}
+
+func (body *UpdateChatFilterRuleParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	if body.Pattern != nil {
+		*body.Pattern = strings.TrimSpace(*body.Pattern)
+		validateChatFilterPattern(*body.Pattern, errors)
+	}
+
+	if body.MatchType != nil {
+		validateChatFilterMatchType(*body.MatchType, errors)
+	}
+
+	if body.ActionType != nil {
+		validateChatFilterActionType(*body.ActionType, errors)
+	}
+
+	if body.ActionDuration != nil {
+		validateChatFilterActionDuration(*body.ActionDuration, errors)
+	}
+
+	if body.ActionReason != nil {
+		validateChatFilterActionReason(*body.ActionReason, errors)
+	}
+
+	return len(errors) == 0, errors
+}
+
+// Field validators shared by the create and update chat filter rule params
+func validateChatFilterPattern(pattern string, errors url.Values) {
+	if len(pattern) < config.ChatFilterPatternMinLen || len(pattern) > config.ChatFilterPatternMaxLen {
+		errors.Set("pattern", fmt.Sprintf("Pattern must be between %d and %d characters in length",
+			config.ChatFilterPatternMinLen, config.ChatFilterPatternMaxLen))
+	}
+}
+
+func validateChatFilterMatchType(matchType string, errors url.Values) {
+	if !containsString(validChatFilterMatchTypes, matchType) {
+		errors.Set("matchType", "Invalid match type. Valid types are: "+strings.Join(validChatFilterMatchTypes, ", "))
+	}
+}
+
+func validateChatFilterActionType(actionType string, errors url.Values) {
+	if !containsString(validChatFilterActionTypes, actionType) {
+		errors.Set("actionType", "Invalid action type. Valid types are: "+strings.Join(validChatFilterActionTypes, ", "))
+	}
+}
+
+func validateChatFilterActionDuration(actionDuration int, errors url.Values) {
+	if actionDuration < 0 || actionDuration > config.InfractionDurationMax {
+		errors.Set("actionDuration", fmt.Sprintf("Action duration must be between 0 and %d minutes",
+			config.InfractionDurationMax))
+	}
+}
+
+func validateChatFilterActionReason(actionReason string, errors url.Values) {
+	if len(actionReason) < config.InfractionReasonMinLen || len(actionReason) > config.InfractionReasonMaxLen {
+		errors.Set("actionReason", fmt.Sprintf("Action reason must be between %d and %d characters in length",
+			config.InfractionReasonMinLen, config.InfractionReasonMaxLen))
+	}
+}
+
+// ValidateChatFilterRule checks the fields of a chat filter rule which depend on each other. It is used to check a
+// rule once an update has been applied to it.
+func ValidateChatFilterRule(pattern string, matchType string, actionType string, actionDuration int) (bool, url.Values) {
+	errors := url.Values{}
+
+	validateChatFilterRuleFields(pattern, matchType, actionType, actionDuration, errors)
+
+	return len(errors) == 0, errors
+}
+
+func validateChatFilterRuleFields(pattern string, matchType string, actionType string, actionDuration int,
+	errors url.Values) {
+	if matchType == "REGEX" {
+		if _, err := regexp.Compile(pattern); err != nil {
+			errors.Set("pattern", "Pattern is not a valid regular expression")
+		}
+	}
+
+	if actionType == "MUTE" && actionDuration < 1 {
+		errors.Set("actionDuration", "Mute rules must have an action duration of at least 1 minute")
+	}
+}
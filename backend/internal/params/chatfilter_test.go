@@ -0,0 +1,124 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestCreateChatFilterRuleParams_Validate(t *testing.T) {
+	type fields struct {
+		Pattern        string
+		MatchType      string
+		Leetspeak      bool
+		ActionType     string
+		ActionDuration int
+		ActionReason   string
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		wantValid bool
+	}{
+		{
+			name: "params.chatfilter.create.1",
+			fields: fields{
+				Pattern:        "badword",
+				MatchType:      "WORD",
+				Leetspeak:      true,
+				ActionType:     "WARNING",
+				ActionDuration: 0,
+				ActionReason:   "Inappropriate language",
+			},
+			wantValid: true,
+		},
+		{
+			name: "params.chatfilter.create.2",
+			fields: fields{
+				Pattern:        "",
+				MatchType:      "",
+				ActionType:     "BAN",
+				ActionDuration: -1,
+				ActionReason:   "",
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.chatfilter.create.3",
+			fields: fields{
+				Pattern:        strings.Repeat("a", config.ChatFilterPatternMaxLen+1),
+				MatchType:      "WORD",
+				ActionType:     "ALERT",
+				ActionDuration: 0,
+				ActionReason:   "Long pattern",
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.chatfilter.create.4",
+			fields: fields{
+				Pattern:        "free\\s+(robux|vbucks)",
+				MatchType:      "REGEX",
+				ActionType:     "MUTE",
+				ActionDuration: 30,
+				ActionReason:   "Scam message",
+			},
+			wantValid: true,
+		},
+		{
+			name: "params.chatfilter.create.5",
+			fields: fields{
+				Pattern:        "free(robux",
+				MatchType:      "REGEX",
+				ActionType:     "KICK",
+				ActionDuration: 0,
+				ActionReason:   "Invalid regex",
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.chatfilter.create.6",
+			fields: fields{
+				Pattern:        "badword",
+				MatchType:      "WORD",
+				ActionType:     "MUTE",
+				ActionDuration: 0,
+				ActionReason:   "Mute without a duration",
+			},
+			wantValid: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &CreateChatFilterRuleParams{
+				Pattern:        tt.fields.Pattern,
+				MatchType:      tt.fields.MatchType,
+				Leetspeak:      tt.fields.Leetspeak,
+				ActionType:     tt.fields.ActionType,
+				ActionDuration: tt.fields.ActionDuration,
+				ActionReason:   tt.fields.ActionReason,
+			}
+
+			valid, errors := body.Validate()
+			assert.Equal(t, tt.wantValid, valid, "Validate returned the wrong values. Errors: %v", errors)
+		})
+	}
+}
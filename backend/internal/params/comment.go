@@ -0,0 +1,69 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/pkg/config"
+	"net/url"
+	"strings"
+)
+
+// CreateCommentParams holds the data we expect when commenting on an infraction. ParentID is only set when replying
+// to another comment.
+type CreateCommentParams struct {
+	ParentID int64  `json:"parentId" form:"parentId"`
+	Body     string `json:"body" form:"body"`
+	*UserMeta
+}
+
+func (body *CreateCommentParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	body.Body = strings.TrimSpace(body.Body)
+
+	if body.ParentID < 0 {
+		errors.Set("parentId", config.MessageInvalidIDProvided)
+	}
+
+	if len(body.Body) < config.CommentBodyMinLen || len(body.Body) > config.CommentBodyMaxLen {
+		errors.Set("body", fmt.Sprintf("Comment must be between %d and %d characters in length",
+			config.CommentBodyMinLen, config.CommentBodyMaxLen))
+	}
+
+	return len(errors) == 0, errors
+}
+
+// UpdateCommentParams holds the data we expect when editing a comment
+type UpdateCommentParams struct {
+	Body string `json:"body" form:"body"`
+	*UserMeta
+}
+
+func (body *UpdateCommentParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	body.Body = strings.TrimSpace(body.Body)
+
+	if len(body.Body) < config.CommentBodyMinLen || len(body.Body) > config.CommentBodyMaxLen {
+		errors.Set("body", fmt.Sprintf("Comment must be between %d and %d characters in length",
+			config.CommentBodyMinLen, config.CommentBodyMaxLen))
+	}
+
+	return len(errors) == 0, errors
+}
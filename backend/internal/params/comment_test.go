@@ -0,0 +1,94 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestCreateCommentParams_Validate(t *testing.T) {
+	type fields struct {
+		ParentID int64
+		Body     string
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		wantValid bool
+		wantBody  string
+	}{
+		{
+			name: "params.comment.create.1",
+			fields: fields{
+				ParentID: 0,
+				Body:     "  Checked the demo, looks legit  ",
+			},
+			wantValid: true,
+			wantBody:  "Checked the demo, looks legit",
+		},
+		{
+			name: "params.comment.create.2",
+			fields: fields{
+				ParentID: 4,
+				Body:     "Agreed",
+			},
+			wantValid: true,
+			wantBody:  "Agreed",
+		},
+		{
+			name: "params.comment.create.3",
+			fields: fields{
+				ParentID: -1,
+				Body:     "Agreed",
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.comment.create.4",
+			fields: fields{
+				Body: "   ",
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.comment.create.5",
+			fields: fields{
+				Body: strings.Repeat("a", config.CommentBodyMaxLen+1),
+			},
+			wantValid: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &CreateCommentParams{
+				ParentID: tt.fields.ParentID,
+				Body:     tt.fields.Body,
+			}
+
+			valid, errors := body.Validate()
+			assert.Equal(t, tt.wantValid, valid, "Validate returned the wrong values. Errors: %v", errors)
+
+			if tt.wantValid {
+				assert.Equal(t, tt.wantBody, body.Body, "Bodies should be equal")
+			}
+		})
+	}
+}
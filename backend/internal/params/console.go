@@ -0,0 +1,75 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/pkg/config"
+	"net/url"
+	"strings"
+)
+
+var validRCONRuleRoles = []string{"ADMIN", "CONSOLE"}
+var validRCONRuleTypes = []string{"ALLOW", "DENY"}
+
+// RCONCommandParams holds the data we expect when running a command through the RCON console
+type RCONCommandParams struct {
+	Command string `json:"command" form:"command"`
+	*UserMeta
+}
+
+func (body *RCONCommandParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	body.Command = strings.TrimSpace(body.Command)
+
+	if len(body.Command) < config.RCONCommandMinLen || len(body.Command) > config.RCONCommandMaxLen {
+		errors.Set("command", fmt.Sprintf("Command must be between %d and %d characters in length",
+			config.RCONCommandMinLen, config.RCONCommandMaxLen))
+	}
+
+	return len(errors) == 0, errors
+}
+
+// CreateRCONCommandRuleParams holds the data we expect when creating an RCON console command rule
+type CreateRCONCommandRuleParams struct {
+	Role   string `json:"role" form:"role"`
+	Prefix string `json:"prefix" form:"prefix"`
+	Type   string `json:"type" form:"type"`
+}
+
+func (body *CreateRCONCommandRuleParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	body.Prefix = strings.TrimSpace(body.Prefix)
+
+	if !containsString(validRCONRuleRoles, body.Role) {
+		errors.Set("role", "Invalid role. Valid roles are: "+strings.Join(validRCONRuleRoles, ", "))
+	}
+
+	if len(body.Prefix) < config.RCONRulePrefixMinLen || len(body.Prefix) > config.RCONRulePrefixMaxLen {
+		errors.Set("prefix", fmt.Sprintf("Prefix must be between %d and %d characters in length",
+			config.RCONRulePrefixMinLen, config.RCONRulePrefixMaxLen))
+	}
+
+	if !containsString(validRCONRuleTypes, body.Type) {
+		errors.Set("type", "Invalid rule type. Valid types are: "+strings.Join(validRCONRuleTypes, ", "))
+	}
+
+	return len(errors) == 0, errors
+}
@@ -0,0 +1,76 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCreateRCONCommandRuleParams_Validate(t *testing.T) {
+	type fields struct {
+		Role   string
+		Prefix string
+		Type   string
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		wantValid bool
+	}{
+		{
+			name: "params.rconrule.create.1",
+			fields: fields{
+				Role:   "CONSOLE",
+				Prefix: "kick",
+				Type:   "ALLOW",
+			},
+			wantValid: true,
+		},
+		{
+			name: "params.rconrule.create.2",
+			fields: fields{
+				Role:   "ADMIN",
+				Prefix: "  stop  ",
+				Type:   "DENY",
+			},
+			wantValid: true,
+		},
+		{
+			name: "params.rconrule.create.3",
+			fields: fields{
+				Role:   "MODERATOR",
+				Prefix: "   ",
+				Type:   "BLOCK",
+			},
+			wantValid: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &CreateRCONCommandRuleParams{
+				Role:   tt.fields.Role,
+				Prefix: tt.fields.Prefix,
+				Type:   tt.fields.Type,
+			}
+
+			valid, errors := body.Validate()
+			assert.Equal(t, tt.wantValid, valid, "Validate returned the wrong values. Errors: %v", errors)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/pkg/config"
+	"net/url"
+	"strings"
+)
+
+var validDiscordInfractionTypes = []string{"WARNING", "MUTE", "KICK", "BAN"}
+
+// CreateDiscordChannelParams holds the data we expect when creating a Discord channel. An empty InfractionType means
+// all infraction types and a ServerID of 0 means all servers.
+type CreateDiscordChannelParams struct {
+	Name           string `json:"name" form:"name"`
+	WebhookURL     string `json:"webhookUrl" form:"webhookUrl"`
+	InfractionType string `json:"infractionType" form:"infractionType"`
+	ServerID       int64  `json:"serverId" form:"serverId"`
+}
+
+func (body *CreateDiscordChannelParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	body.Name = strings.TrimSpace(body.Name)
+	body.WebhookURL = strings.TrimSpace(body.WebhookURL)
+
+	validateDiscordChannelName(body.Name, errors)
+	validateHTTPURL("webhookUrl", body.WebhookURL, errors)
+	validateDiscordInfractionType(body.InfractionType, errors)
+	validateDiscordServerID(body.ServerID, errors)
+
+	return len(errors) == 0, errors
+}
+
+// UpdateDiscordChannelParams holds the data we expect when updating a Discord channel. Only fields which are set are
+// updated.
+type UpdateDiscordChannelParams struct {
+	Name           *string `json:"name" form:"name"`
+	WebhookURL     *string `json:"webhookUrl" form:"webhookUrl"`
+	InfractionType *string `json:"infractionType" form:"infractionType"`
+	ServerID       *int64  `json:"serverId" form:"serverId"`
+	Enabled        *bool   `json:"enabled" form:"enabled"`
+}
+
+func (body *UpdateDiscordChannelParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	if body.Name != nil {
+		*body.Name = strings.TrimSpace(*body.Name)
+		validateDiscordChannelName(*body.Name, errors)
+	}
+
+	if body.WebhookURL != nil {
+		*body.WebhookURL = strings.TrimSpace(*body.WebhookURL)
+		validateHTTPURL("webhookUrl", *body.WebhookURL, errors)
+	}
+
+	if body.InfractionType != nil {
+		validateDiscordInfractionType(*body.InfractionType, errors)
+	}
+
+	if body.ServerID != nil {
+		validateDiscordServerID(*body.ServerID, errors)
+	}
+
+	return len(errors) == 0, errors
+}
+
+// Field validators shared by the create and update Discord channel params
+func validateDiscordChannelName(name string, errors url.Values) {
+	if len(name) < config.DiscordChannelNameMinLen || len(name) > config.DiscordChannelNameMaxLen {
+		errors.Set("name", fmt.Sprintf("Name must be between %d and %d characters in length",
+			config.DiscordChannelNameMinLen, config.DiscordChannelNameMaxLen))
+	}
+}
+
+func validateDiscordInfractionType(infractionType string, errors url.Values) {
+	if infractionType != "" && !containsString(validDiscordInfractionTypes, infractionType) {
+		errors.Set("infractionType", "Invalid infraction type. Valid types are: "+
+			strings.Join(validDiscordInfractionTypes, ", "))
+	}
+}
+
+func validateDiscordServerID(serverID int64, errors url.Values) {
+	if serverID < 0 {
+		errors.Set("serverId", "Invalid server ID")
+	}
+}
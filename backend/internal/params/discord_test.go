@@ -0,0 +1,79 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCreateDiscordChannelParams_Validate(t *testing.T) {
+	type fields struct {
+		Name           string
+		WebhookURL     string
+		InfractionType string
+		ServerID       int64
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		wantValid bool
+	}{
+		{
+			name: "params.discord.create.1",
+			fields: fields{
+				Name:       "Moderation log",
+				WebhookURL: "https://discord.com/api/webhooks/123/abc",
+			},
+			wantValid: true,
+		},
+		{
+			name: "params.discord.create.2",
+			fields: fields{
+				Name:           "Ban log",
+				WebhookURL:     "https://discord.com/api/webhooks/123/abc",
+				InfractionType: "BAN",
+				ServerID:       2,
+			},
+			wantValid: true,
+		},
+		{
+			name: "params.discord.create.3",
+			fields: fields{
+				Name:           "",
+				WebhookURL:     "discord",
+				InfractionType: "WARN",
+				ServerID:       -1,
+			},
+			wantValid: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &CreateDiscordChannelParams{
+				Name:           tt.fields.Name,
+				WebhookURL:     tt.fields.WebhookURL,
+				InfractionType: tt.fields.InfractionType,
+				ServerID:       tt.fields.ServerID,
+			}
+
+			valid, errors := body.Validate()
+			assert.Equal(t, tt.wantValid, valid, "Validate returned the wrong values. Errors: %v", errors)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/pkg/config"
+	"net/url"
+	"strings"
+)
+
+var validEscalationActionTypes = []string{"MUTE", "KICK", "BAN"}
+
+// CreateEscalationPolicyParams holds the data we expect when creating an escalation policy
+type CreateEscalationPolicyParams struct {
+	Name           string `json:"name" form:"name"`
+	TriggerType    string `json:"triggerType" form:"triggerType"`
+	TriggerCount   int    `json:"triggerCount" form:"triggerCount"`
+	TimeWindow     int    `json:"timeWindow" form:"timeWindow"`
+	ActionType     string `json:"actionType" form:"actionType"`
+	ActionDuration int    `json:"actionDuration" form:"actionDuration"`
+	ActionReason   string `json:"actionReason" form:"actionReason"`
+}
+
+func (body *CreateEscalationPolicyParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	body.Name = strings.TrimSpace(body.Name)
+
+	validateEscalationName(body.Name, errors)
+	validateEscalationTriggerType(body.TriggerType, errors)
+	validateEscalationTriggerCount(body.TriggerCount, errors)
+	validateEscalationTimeWindow(body.TimeWindow, errors)
+	validateEscalationActionType(body.ActionType, errors)
+	validateEscalationActionDuration(body.ActionDuration, errors)
+	validateEscalationActionReason(body.ActionReason, errors)
+
+	return len(errors) == 0, errors
+}
+
+// UpdateEscalationPolicyParams holds the data we expect when updating an escalation policy. Only fields which are set
+// are updated.
+type UpdateEscalationPolicyParams struct {
+	Name           *string `json:"name" form:"name"`
+	TriggerType    *string `json:"triggerType" form:"triggerType"`
+	TriggerCount   *int    `json:"triggerCount" form:"triggerCount"`
+	TimeWindow     *int    `json:"timeWindow" form:"timeWindow"`
+	ActionType     *string `json:"actionType" form:"actionType"`
+	ActionDuration *int    `json:"actionDuration" form:"actionDuration"`
+	ActionReason   *string `json:"actionReason" form:"actionReason"`
+	Enabled        *bool   `json:"enabled" form:"enabled"`
+}
+
+func (body *UpdateEscalationPolicyParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	if body.Name != nil {
+		*body.Name = strings.TrimSpace(*body.Name)
+		validateEscalationName(*body.Name, errors)
+	}
+
+	if body.TriggerType != nil {
+		validateEscalationTriggerType(*body.TriggerType, errors)
+	}
+
+	if body.TriggerCount != nil {
+		validateEscalationTriggerCount(*body.TriggerCount, errors)
+	}
+
+	if body.TimeWindow != nil {
+		validateEscalationTimeWindow(*body.TimeWindow, errors)
+	}
+
+	if body.ActionType != nil {
+		validateEscalationActionType(*body.ActionType, errors)
+	}
+
+	if body.ActionDuration != nil {
+		validateEscalationActionDuration(*body.ActionDuration, errors)
+	}
+
+	if body.ActionReason != nil {
+		validateEscalationActionReason(*body.ActionReason, errors)
+	}
+
+	return len(errors) == 0, errors
+}
+
+// Field validators shared by the create and update escalation policy params
+func validateEscalationName(name string, errors url.Values) {
+	if len(name) < config.EscalationPolicyNameMinLen || len(name) > config.EscalationPolicyNameMaxLen {
+		errors.Set("name", fmt.Sprintf("Name must be between %d and %d characters in length",
+			config.EscalationPolicyNameMinLen, config.EscalationPolicyNameMaxLen))
+	}
+}
+
+func validateEscalationTriggerType(triggerType string, errors url.Values) {
+	if !containsString(validInfractionTypes, triggerType) {
+		errors.Set("triggerType", "Invalid trigger type. Valid types are: "+strings.Join(validInfractionTypes, ", "))
+	}
+}
+
+func validateEscalationTriggerCount(triggerCount int, errors url.Values) {
+	if triggerCount < 1 || triggerCount > config.EscalationTriggerCountMax {
+		errors.Set("triggerCount", fmt.Sprintf("Trigger count must be between 1 and %d", config.EscalationTriggerCountMax))
+	}
+}
+
+func validateEscalationTimeWindow(timeWindow int, errors url.Values) {
+	if timeWindow < 0 || timeWindow > config.InfractionDurationMax {
+		errors.Set("timeWindow", fmt.Sprintf("Time window must be between 0 and %d minutes", config.InfractionDurationMax))
+	}
+}
+
+func validateEscalationActionType(actionType string, errors url.Values) {
+	if !containsString(validEscalationActionTypes, actionType) {
+		errors.Set("actionType", "Invalid action type. Valid types are: "+strings.Join(validEscalationActionTypes, ", "))
+	}
+}
+
+func validateEscalationActionDuration(actionDuration int, errors url.Values) {
+	if actionDuration < 0 || actionDuration > config.InfractionDurationMax {
+		errors.Set("actionDuration", fmt.Sprintf("Action duration must be between 0 and %d minutes",
+			config.InfractionDurationMax))
+	}
+}
+
+func validateEscalationActionReason(actionReason string, errors url.Values) {
+	if len(actionReason) < config.InfractionReasonMinLen || len(actionReason) > config.InfractionReasonMaxLen {
+		errors.Set("actionReason", fmt.Sprintf("Action reason must be between %d and %d characters in length",
+			config.InfractionReasonMinLen, config.InfractionReasonMaxLen))
+	}
+}
+
+// containsString checks if value is one of the provided values
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
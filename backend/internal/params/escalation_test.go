@@ -0,0 +1,111 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestCreateEscalationPolicyParams_Validate(t *testing.T) {
+	type fields struct {
+		Name           string
+		TriggerType    string
+		TriggerCount   int
+		TimeWindow     int
+		ActionType     string
+		ActionDuration int
+		ActionReason   string
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		wantValid bool
+	}{
+		{
+			name: "params.escalation.create.1",
+			fields: fields{
+				Name:           "3 warnings in 30 days",
+				TriggerType:    "WARNING",
+				TriggerCount:   3,
+				TimeWindow:     43200,
+				ActionType:     "MUTE",
+				ActionDuration: 60,
+				ActionReason:   "Too many warnings",
+			},
+			wantValid: true,
+		},
+		{
+			name: "params.escalation.create.2",
+			fields: fields{
+				Name:           "",
+				TriggerType:    "",
+				TriggerCount:   0,
+				TimeWindow:     -1,
+				ActionType:     "",
+				ActionDuration: -1,
+				ActionReason:   "",
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.escalation.create.3",
+			fields: fields{
+				Name:           strings.Repeat("a", config.EscalationPolicyNameMaxLen+1),
+				TriggerType:    "MUTE",
+				TriggerCount:   config.EscalationTriggerCountMax + 1,
+				TimeWindow:     0,
+				ActionType:     "BAN",
+				ActionDuration: 1440,
+				ActionReason:   "Muted too often",
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.escalation.create.4",
+			fields: fields{
+				Name:           "Warnings to warning",
+				TriggerType:    "WARNING",
+				TriggerCount:   2,
+				TimeWindow:     0,
+				ActionType:     "WARNING",
+				ActionDuration: 0,
+				ActionReason:   "Warned too often",
+			},
+			wantValid: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &CreateEscalationPolicyParams{
+				Name:           tt.fields.Name,
+				TriggerType:    tt.fields.TriggerType,
+				TriggerCount:   tt.fields.TriggerCount,
+				TimeWindow:     tt.fields.TimeWindow,
+				ActionType:     tt.fields.ActionType,
+				ActionDuration: tt.fields.ActionDuration,
+				ActionReason:   tt.fields.ActionReason,
+			}
+
+			valid, errors := body.Validate()
+			assert.Equal(t, tt.wantValid, valid, "Validate returned the wrong values. Errors: %v", errors)
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"github.com/sniddunc/refractor/pkg/config"
 	"net/url"
+	"strings"
 )
 
 // CreateWarningParams holds the data we expect when creating a new warning
@@ -50,12 +51,14 @@ func (body *CreateWarningParams) Validate() (bool, url.Values) {
 	return len(errors) == 0, errors
 }
 
-// CreateMuteParams holds the data we expect when creating a new kick
+// CreateMuteParams holds the data we expect when creating a new mute.
+// If Enforce is true, the game's mute command will be run on the server through RCON.
 type CreateMuteParams struct {
 	PlayerID int64  `json:"playerId" form:"playerId"`
 	ServerID int64  `json:"serverId" form:"serverId"`
 	Reason   string `json:"reason" form:"reason"`
 	Duration int    `json:"duration" form:"duration"`
+	Enforce  bool   `json:"enforce" form:"enforce"`
 }
 
 func (body *CreateMuteParams) Validate() (bool, url.Values) {
@@ -86,11 +89,13 @@ func (body *CreateMuteParams) Validate() (bool, url.Values) {
 	return len(errors) == 0, errors
 }
 
-// CreateKickParams holds the data we expect when creating a new kick
+// CreateKickParams holds the data we expect when creating a new kick.
+// If Enforce is true, the game's kick command will be run on the server through RCON.
 type CreateKickParams struct {
 	PlayerID int64  `json:"playerId" form:"playerId"`
 	ServerID int64  `json:"serverId" form:"serverId"`
 	Reason   string `json:"reason" form:"reason"`
+	Enforce  bool   `json:"enforce" form:"enforce"`
 }
 
 func (body *CreateKickParams) Validate() (bool, url.Values) {
@@ -113,12 +118,14 @@ func (body *CreateKickParams) Validate() (bool, url.Values) {
 	return len(errors) == 0, errors
 }
 
-// CreateBanParams holds the data we expect when creating a new ban
+// CreateBanParams holds the data we expect when creating a new ban.
+// If Enforce is true, the game's ban command will be run on the server through RCON.
 type CreateBanParams struct {
 	PlayerID int64  `json:"playerId" form:"playerId"`
 	ServerID int64  `json:"serverId" form:"serverId"`
 	Reason   string `json:"reason" form:"reason"`
 	Duration int    `json:"duration" form:"duration"`
+	Enforce  bool   `json:"enforce" form:"enforce"`
 }
 
 func (body *CreateBanParams) Validate() (bool, url.Values) {
@@ -149,6 +156,60 @@ func (body *CreateBanParams) Validate() (bool, url.Values) {
 	return len(errors) == 0, errors
 }
 
+// CreateOfflineBanParams holds the data we expect when banning a player by their game ID. The player does not need to
+// have joined one of our servers before. PlayerName is optional and is only used until the player joins.
+// If Enforce is true, the game's ban command will be run on the server through RCON.
+type CreateOfflineBanParams struct {
+	Game         string `json:"game" form:"game"`
+	PlayerGameID string `json:"playerGameId" form:"playerGameId"`
+	PlayerName   string `json:"playerName" form:"playerName"`
+	ServerID     int64  `json:"serverId" form:"serverId"`
+	Reason       string `json:"reason" form:"reason"`
+	Duration     int    `json:"duration" form:"duration"`
+	Enforce      bool   `json:"enforce" form:"enforce"`
+}
+
+func (body *CreateOfflineBanParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	body.PlayerGameID = strings.TrimSpace(body.PlayerGameID)
+	body.PlayerName = strings.TrimSpace(body.PlayerName)
+
+	if body.Game == "" {
+		errors.Set("game", "Game is a required field")
+	}
+
+	if body.PlayerGameID == "" {
+		errors.Set("playerGameId", "Player game ID is a required field")
+	} else if len(body.PlayerGameID) > config.PlayerGameIDMaxLen {
+		errors.Set("playerGameId", fmt.Sprintf("Player game ID must be no more than %d characters in length", config.PlayerGameIDMaxLen))
+	}
+
+	if len(body.PlayerName) > config.PlayerNameMaxLen {
+		errors.Set("playerName", fmt.Sprintf("Player name must be no more than %d characters in length", config.PlayerNameMaxLen))
+	}
+
+	if body.ServerID < 1 {
+		errors.Set("serverId", "Invalid server ID")
+	}
+
+	if body.Reason == "" {
+		errors.Set("reason", "Reason is a required field")
+	} else if len(body.Reason) < config.InfractionReasonMinLen || len(body.Reason) > config.InfractionReasonMaxLen {
+		errors.Set("reason", fmt.Sprintf("Reason must be between %d and %d characters in length", config.InfractionReasonMinLen, config.InfractionReasonMaxLen))
+	}
+
+	if body.Duration > config.InfractionDurationMax {
+		errors.Set("duration", fmt.Sprintf("The maximum duration a ban can have is %d minutes", config.InfractionDurationMax))
+	}
+
+	if body.Duration < 0 {
+		errors.Set("duration", "Invalid duration")
+	}
+
+	return len(errors) == 0, errors
+}
+
 // UpdateInfractionParams holds the data we expect when updating an infraction
 type UpdateInfractionParams struct {
 	Reason   *string `json:"reason" form:"reason"`
@@ -181,3 +242,37 @@ func (body *UpdateInfractionParams) Validate() (bool, url.Values) {
 
 	return len(errors) == 0, errors
 }
+
+// DeleteInfractionParams holds the data we expect when deleting an infraction. A reason is optional.
+type DeleteInfractionParams struct {
+	Reason string `json:"reason" form:"reason"`
+	*UserMeta
+}
+
+func (body *DeleteInfractionParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	if len(body.Reason) > config.InfractionReasonMaxLen {
+		errors.Set("reason", fmt.Sprintf("Reason must be no longer than %d characters", config.InfractionReasonMaxLen))
+	}
+
+	return len(errors) == 0, errors
+}
+
+// RevokeInfractionParams holds the data we expect when revoking a ban or mute
+type RevokeInfractionParams struct {
+	Reason string `json:"reason" form:"reason"`
+	*UserMeta
+}
+
+func (body *RevokeInfractionParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	if body.Reason == "" {
+		errors.Set("reason", "Reason is a required field")
+	} else if len(body.Reason) < config.InfractionReasonMinLen || len(body.Reason) > config.InfractionReasonMaxLen {
+		errors.Set("reason", fmt.Sprintf("Reason must be between %d and %d characters in length", config.InfractionReasonMinLen, config.InfractionReasonMaxLen))
+	}
+
+	return len(errors) == 0, errors
+}
@@ -322,3 +322,140 @@ func TestCreateBanParams_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateOfflineBanParams_Validate(t *testing.T) {
+	type fields struct {
+		Game         string
+		PlayerGameID string
+		PlayerName   string
+		ServerID     int64
+		Reason       string
+		Duration     int
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		wantValid bool
+	}{
+		{
+			name: "params.infractions.offlineban.1",
+			fields: fields{
+				Game:         "Mordhau",
+				PlayerGameID: "ABCDEF1234567890",
+				PlayerName:   "Player",
+				ServerID:     1,
+				Duration:     0,
+				Reason:       strings.Repeat("a", config.InfractionReasonMinLen),
+			},
+			wantValid: true,
+		},
+		{
+			name: "params.infractions.offlineban.2",
+			fields: fields{
+				Game:         "Minecraft",
+				PlayerGameID: "  c7a4dc4e-5d5c-4a0b-9f3b-2f1a1c9b8e7d  ",
+				ServerID:     1,
+				Duration:     1440,
+				Reason:       strings.Repeat("a", config.InfractionReasonMinLen),
+			},
+			wantValid: true,
+		},
+		{
+			name: "params.infractions.offlineban.3",
+			fields: fields{
+				Game:         "",
+				PlayerGameID: " ",
+				ServerID:     0,
+				Duration:     -1,
+				Reason:       "",
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.infractions.offlineban.4",
+			fields: fields{
+				Game:         "Mordhau",
+				PlayerGameID: strings.Repeat("a", config.PlayerGameIDMaxLen+1),
+				ServerID:     1,
+				Reason:       strings.Repeat("a", config.InfractionReasonMinLen),
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.infractions.offlineban.5",
+			fields: fields{
+				Game:         "Mordhau",
+				PlayerGameID: "ABCDEF1234567890",
+				PlayerName:   strings.Repeat("a", config.PlayerNameMaxLen+1),
+				ServerID:     1,
+				Reason:       strings.Repeat("a", config.InfractionReasonMinLen),
+			},
+			wantValid: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &CreateOfflineBanParams{
+				Game:         tt.fields.Game,
+				PlayerGameID: tt.fields.PlayerGameID,
+				PlayerName:   tt.fields.PlayerName,
+				ServerID:     tt.fields.ServerID,
+				Reason:       tt.fields.Reason,
+				Duration:     tt.fields.Duration,
+			}
+
+			valid, errors := body.Validate()
+			assert.Equal(t, tt.wantValid, valid, "Validate returned the wrong values. Errors: %v", errors)
+		})
+	}
+}
+
+func TestRevokeInfractionParams_Validate(t *testing.T) {
+	type fields struct {
+		Reason string
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		wantValid bool
+	}{
+		{
+			name: "params.infractions.revoke.1",
+			fields: fields{
+				Reason: strings.Repeat("a", config.InfractionReasonMinLen),
+			},
+			wantValid: true,
+		},
+		{
+			name: "params.infractions.revoke.2",
+			fields: fields{
+				Reason: "",
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.infractions.revoke.3",
+			fields: fields{
+				Reason: strings.Repeat("a", config.InfractionReasonMinLen-1),
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.infractions.revoke.4",
+			fields: fields{
+				Reason: strings.Repeat("a", config.InfractionReasonMaxLen+1),
+			},
+			wantValid: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &RevokeInfractionParams{
+				Reason: tt.fields.Reason,
+			}
+
+			valid, errors := body.Validate()
+			assert.Equal(t, tt.wantValid, valid, "Validate returned the wrong values. Errors: %v", errors)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/pkg/config"
+	"net/url"
+	"strings"
+)
+
+// CreateNoteParams holds the data we expect when adding a note to a player
+type CreateNoteParams struct {
+	Body      string `json:"body" form:"body"`
+	Pinned    bool   `json:"pinned" form:"pinned"`
+	AdminOnly bool   `json:"adminOnly" form:"adminOnly"`
+	*UserMeta
+}
+
+func (body *CreateNoteParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	body.Body = strings.TrimSpace(body.Body)
+
+	if len(body.Body) < config.NoteBodyMinLen || len(body.Body) > config.NoteBodyMaxLen {
+		errors.Set("body", fmt.Sprintf("Note must be between %d and %d characters in length",
+			config.NoteBodyMinLen, config.NoteBodyMaxLen))
+	}
+
+	return len(errors) == 0, errors
+}
+
+// UpdateNoteParams holds the data we expect when updating a note. Fields which are not provided are left unchanged.
+type UpdateNoteParams struct {
+	Body      *string `json:"body" form:"body"`
+	Pinned    *bool   `json:"pinned" form:"pinned"`
+	AdminOnly *bool   `json:"adminOnly" form:"adminOnly"`
+	*UserMeta
+}
+
+func (body *UpdateNoteParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	if body.Body != nil {
+		noteBody := strings.TrimSpace(*body.Body)
+		body.Body = &noteBody
+
+		if len(noteBody) < config.NoteBodyMinLen || len(noteBody) > config.NoteBodyMaxLen {
+			errors.Set("body", fmt.Sprintf("Note must be between %d and %d characters in length",
+				config.NoteBodyMinLen, config.NoteBodyMaxLen))
+		}
+	}
+
+	return len(errors) == 0, errors
+}
@@ -0,0 +1,75 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestUpdateNoteParams_Validate(t *testing.T) {
+	stringPtr := func(s string) *string {
+		return &s
+	}
+
+	tests := []struct {
+		name      string
+		body      *string
+		wantValid bool
+		wantBody  *string
+	}{
+		{
+			name:      "params.note.update.1",
+			body:      stringPtr("  Suspected alt  "),
+			wantValid: true,
+			wantBody:  stringPtr("Suspected alt"),
+		},
+		{
+			name:      "params.note.update.2",
+			body:      nil,
+			wantValid: true,
+			wantBody:  nil,
+		},
+		{
+			name:      "params.note.update.3",
+			body:      stringPtr("   "),
+			wantValid: false,
+		},
+		{
+			name:      "params.note.update.4",
+			body:      stringPtr(strings.Repeat("a", config.NoteBodyMaxLen+1)),
+			wantValid: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &UpdateNoteParams{
+				Body: tt.body,
+			}
+
+			valid, errors := body.Validate()
+			assert.Equal(t, tt.wantValid, valid, "Validate returned the wrong values. Errors: %v", errors)
+
+			if tt.wantValid {
+				assert.Equal(t, tt.wantBody, body.Body, "Bodies should be equal")
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/pkg/config"
+	"net/url"
+	"strings"
+)
+
+// WatchPlayerParams holds the data we expect when adding a player to the watchlist. Duration is the number of minutes
+// the player should be watched for. A duration of 0 means the watch never expires.
+type WatchPlayerParams struct {
+	Reason   string `json:"reason" form:"reason"`
+	Duration int    `json:"duration" form:"duration"`
+	*UserMeta
+}
+
+func (body *WatchPlayerParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	body.Reason = strings.TrimSpace(body.Reason)
+
+	if len(body.Reason) > config.WatchReasonMaxLen {
+		errors.Set("reason", fmt.Sprintf("Reason must be no more than %d characters in length",
+			config.WatchReasonMaxLen))
+	}
+
+	if body.Duration < 0 || body.Duration > config.InfractionDurationMax {
+		errors.Set("duration", fmt.Sprintf("Duration must be between 0 and %d minutes", config.InfractionDurationMax))
+	}
+
+	return len(errors) == 0, errors
+}
@@ -0,0 +1,61 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestWatchPlayerParams_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      WatchPlayerParams
+		wantValid bool
+	}{
+		{
+			name: "params.watchplayer.1",
+			body: WatchPlayerParams{
+				Reason:   "Suspected alt account",
+				Duration: 1440,
+			},
+			wantValid: true,
+		},
+		{
+			name:      "params.watchplayer.2",
+			body:      WatchPlayerParams{},
+			wantValid: true,
+		},
+		{
+			name: "params.watchplayer.3",
+			body: WatchPlayerParams{
+				Reason:   strings.Repeat("a", config.WatchReasonMaxLen+1),
+				Duration: -1,
+			},
+			wantValid: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, errors := tt.body.Validate()
+			assert.Equal(t, tt.wantValid, valid, "Validate returned the wrong values. Errors: %v", errors)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/cron"
+	"net/url"
+	"strings"
+)
+
+var validScheduleActionTypes = []string{"COMMAND", "ANNOUNCEMENT"}
+
+// CreateScheduleParams holds the data we expect when creating a schedule. Cron is a five field cron expression and
+// a ServerID of 0 means the schedule runs on every server.
+type CreateScheduleParams struct {
+	Name       string `json:"name" form:"name"`
+	Cron       string `json:"cron" form:"cron"`
+	ActionType string `json:"actionType" form:"actionType"`
+	Payload    string `json:"payload" form:"payload"`
+	ServerID   int64  `json:"serverId" form:"serverId"`
+	*UserMeta
+}
+
+func (body *CreateScheduleParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	body.Name = strings.TrimSpace(body.Name)
+	body.Cron = strings.TrimSpace(body.Cron)
+	body.Payload = strings.TrimSpace(body.Payload)
+
+	validateScheduleName(body.Name, errors)
+	validateScheduleCron(body.Cron, errors)
+	validateScheduleActionType(body.ActionType, errors)
+	validateSchedulePayload(body.Payload, errors)
+	validateScheduleServerID(body.ServerID, errors)
+
+	return len(errors) == 0, errors
+}
+
+// UpdateScheduleParams holds the data we expect when updating a schedule. Only fields which are set are updated.
+type UpdateScheduleParams struct {
+	Name       *string `json:"name" form:"name"`
+	Cron       *string `json:"cron" form:"cron"`
+	ActionType *string `json:"actionType" form:"actionType"`
+	Payload    *string `json:"payload" form:"payload"`
+	ServerID   *int64  `json:"serverId" form:"serverId"`
+	Enabled    *bool   `json:"enabled" form:"enabled"`
+}
+
+func (body *UpdateScheduleParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	if body.Name != nil {
+		*body.Name = strings.TrimSpace(*body.Name)
+		validateScheduleName(*body.Name, errors)
+	}
+
+	if body.Cron != nil {
+		*body.Cron = strings.TrimSpace(*body.Cron)
+		validateScheduleCron(*body.Cron, errors)
+	}
+
+	if body.ActionType != nil {
+		validateScheduleActionType(*body.ActionType, errors)
+	}
+
+	if body.Payload != nil {
+		*body.Payload = strings.TrimSpace(*body.Payload)
+		validateSchedulePayload(*body.Payload, errors)
+	}
+
+	if body.ServerID != nil {
+		validateScheduleServerID(*body.ServerID, errors)
+	}
+
+	return len(errors) == 0, errors
+}
+
+// Field validators shared by the create and update schedule params
+func validateScheduleName(name string, errors url.Values) {
+	if len(name) < config.ScheduleNameMinLen || len(name) > config.ScheduleNameMaxLen {
+		errors.Set("name", fmt.Sprintf("Name must be between %d and %d characters in length",
+			config.ScheduleNameMinLen, config.ScheduleNameMaxLen))
+	}
+}
+
+func validateScheduleCron(expr string, errors url.Values) {
+	if _, err := cron.Parse(expr); err != nil {
+		errors.Set("cron", "Invalid cron expression: "+err.Error())
+	}
+}
+
+func validateScheduleActionType(actionType string, errors url.Values) {
+	if !containsString(validScheduleActionTypes, actionType) {
+		errors.Set("actionType", "Invalid action type. Valid types are: "+strings.Join(validScheduleActionTypes, ", "))
+	}
+}
+
+func validateSchedulePayload(payload string, errors url.Values) {
+	if len(payload) < config.SchedulePayloadMinLen || len(payload) > config.SchedulePayloadMaxLen {
+		errors.Set("payload", fmt.Sprintf("Payload must be between %d and %d characters in length",
+			config.SchedulePayloadMinLen, config.SchedulePayloadMaxLen))
+	}
+}
+
+func validateScheduleServerID(serverID int64, errors url.Values) {
+	if serverID < 0 {
+		errors.Set("serverId", "Invalid server ID")
+	}
+}
@@ -0,0 +1,95 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCreateScheduleParams_Validate(t *testing.T) {
+	type fields struct {
+		Name       string
+		Cron       string
+		ActionType string
+		Payload    string
+		ServerID   int64
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		wantValid bool
+	}{
+		{
+			name: "params.schedule.create.1",
+			fields: fields{
+				Name:       "Rules reminder",
+				Cron:       "*/20 * * * *",
+				ActionType: "ANNOUNCEMENT",
+				Payload:    "Please read the rules in our Discord",
+			},
+			wantValid: true,
+		},
+		{
+			name: "params.schedule.create.2",
+			fields: fields{
+				Name:       "Restart warning",
+				Cron:       "55 4 * * *",
+				ActionType: "COMMAND",
+				Payload:    "Say Server restarting in 5 minutes",
+				ServerID:   1,
+			},
+			wantValid: true,
+		},
+		{
+			name: "params.schedule.create.3",
+			fields: fields{
+				Name:       "Broken",
+				Cron:       "every 20 minutes",
+				ActionType: "COMMAND",
+				Payload:    "Say hi",
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.schedule.create.4",
+			fields: fields{
+				Name:       "",
+				Cron:       "* * * * *",
+				ActionType: "KICK",
+				Payload:    "",
+				ServerID:   -1,
+			},
+			wantValid: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &CreateScheduleParams{
+				Name:       tt.fields.Name,
+				Cron:       tt.fields.Cron,
+				ActionType: tt.fields.ActionType,
+				Payload:    tt.fields.Payload,
+				ServerID:   tt.fields.ServerID,
+			}
+
+			valid, errors := body.Validate()
+			assert.Equal(t, tt.wantValid, valid, "Validate returned the wrong values. Errors: %v", errors)
+		})
+	}
+}
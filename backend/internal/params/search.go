@@ -26,8 +26,8 @@ import (
 )
 
 type SearchParams struct {
-	Offset int `json:"offset" form:"offset"`
-	Limit  int `json:"limit" form:"limit"`
+	Offset int `json:"offset" form:"offset" query:"offset"`
+	Limit  int `json:"limit" form:"limit" query:"limit"`
 }
 
 func (body *SearchParams) Validate() (bool, url.Values) {
@@ -174,3 +174,127 @@ func (body *SearchInfractionsParams) Validate() (bool, url.Values) {
 
 	return len(errors) == 0, errors
 }
+
+type SearchAppealsParams struct {
+	Status       string `json:"status" form:"status"`
+	PlayerID     string `json:"playerId" form:"playerId"`
+	InfractionID string `json:"infractionId" form:"infractionId"`
+	ReviewerID   string `json:"reviewerId" form:"reviewerId"`
+	*ParsedAppealIDs
+	SearchParams
+}
+
+type ParsedAppealIDs struct {
+	PlayerID     int64
+	InfractionID int64
+	ReviewerID   int64
+}
+
+var validAppealStatuses = []string{"OPEN", "UNDER_REVIEW", "ACCEPTED", "DENIED"}
+
+func (body *SearchAppealsParams) Validate() (bool, url.Values) {
+	if ok, errors := body.SearchParams.Validate(); !ok {
+		return ok, errors
+	}
+	body.ParsedAppealIDs = &ParsedAppealIDs{}
+
+	errors := url.Values{}
+
+	// Validate appeal status filter
+	if body.Status != "" && !containsString(validAppealStatuses, body.Status) {
+		errors.Set("status", "Invalid status")
+	}
+
+	// Validate and parse PlayerID
+	if body.PlayerID != "" {
+		playerID, err := strconv.ParseInt(body.PlayerID, 10, 64)
+		if err != nil {
+			errors.Set("playerId", config.MessageInvalidIDProvided)
+		} else {
+			body.ParsedAppealIDs.PlayerID = playerID
+		}
+	}
+
+	// Validate and parse InfractionID
+	if body.InfractionID != "" {
+		infractionID, err := strconv.ParseInt(body.InfractionID, 10, 64)
+		if err != nil {
+			errors.Set("infractionId", config.MessageInvalidIDProvided)
+		} else {
+			body.ParsedAppealIDs.InfractionID = infractionID
+		}
+	}
+
+	// Validate and parse ReviewerID
+	if body.ReviewerID != "" {
+		reviewerID, err := strconv.ParseInt(body.ReviewerID, 10, 64)
+		if err != nil {
+			errors.Set("reviewerId", config.MessageInvalidIDProvided)
+		} else {
+			body.ParsedAppealIDs.ReviewerID = reviewerID
+		}
+	}
+
+	return len(errors) == 0, errors
+}
+
+// SearchChatParams holds the filters we accept when searching the chat log. StartDate and EndDate are unix
+// timestamps and are both inclusive. No filters are required.
+type SearchChatParams struct {
+	PlayerID  string `json:"playerId" form:"playerId"`
+	ServerID  string `json:"serverId" form:"serverId"`
+	StartDate int64  `json:"startDate" form:"startDate"`
+	EndDate   int64  `json:"endDate" form:"endDate"`
+	Query     string `json:"query" form:"query"`
+	*ParsedIDs
+	SearchParams
+}
+
+func (body *SearchChatParams) Validate() (bool, url.Values) {
+	if ok, errors := body.SearchParams.Validate(); !ok {
+		return ok, errors
+	}
+	body.ParsedIDs = &ParsedIDs{}
+
+	errors := url.Values{}
+
+	// Validate and parse PlayerID
+	if body.PlayerID != "" {
+		playerID, err := strconv.ParseInt(body.PlayerID, 10, 64)
+		if err != nil {
+			errors.Set("playerId", config.MessageInvalidIDProvided)
+		} else {
+			body.ParsedIDs.PlayerID = playerID
+		}
+	}
+
+	// Validate and parse ServerID
+	if body.ServerID != "" {
+		serverID, err := strconv.ParseInt(body.ServerID, 10, 64)
+		if err != nil {
+			errors.Set("serverId", config.MessageInvalidIDProvided)
+		} else {
+			body.ParsedIDs.ServerID = serverID
+		}
+	}
+
+	// Validate time range
+	if body.StartDate < 0 {
+		errors.Set("startDate", "Invalid start date")
+	}
+
+	if body.EndDate < 0 {
+		errors.Set("endDate", "Invalid end date")
+	} else if body.EndDate != 0 && body.EndDate < body.StartDate {
+		errors.Set("endDate", "End date must be after the start date")
+	}
+
+	// Validate text query
+	body.Query = strings.TrimSpace(body.Query)
+
+	if len(body.Query) > config.SearchTermMaxLen {
+		errors.Set("query", fmt.Sprintf("Search query must be no longer than %d characters", config.SearchTermMaxLen))
+	}
+
+	return len(errors) == 0, errors
+}
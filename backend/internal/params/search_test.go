@@ -222,3 +222,103 @@ func TestSearchInfractionsParams_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestSearchChatParams_Validate(t *testing.T) {
+	type fields struct {
+		PlayerID     string
+		ServerID     string
+		StartDate    int64
+		EndDate      int64
+		Query        string
+		SearchParams SearchParams
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   bool
+	}{
+		{
+			name: "params.search.chat.validate.1",
+			fields: fields{
+				SearchParams: SearchParams{
+					Offset: config.SearchOffsetMin,
+					Limit:  config.SearchLimitMin,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "params.search.chat.validate.2",
+			fields: fields{
+				PlayerID:  "4",
+				ServerID:  "1",
+				StartDate: 1600000000,
+				EndDate:   1600086400,
+				Query:     "hacker",
+				SearchParams: SearchParams{
+					Offset: config.SearchOffsetMin,
+					Limit:  config.SearchLimitMax,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "params.search.chat.validate.3",
+			fields: fields{
+				PlayerID: "invalid",
+				SearchParams: SearchParams{
+					Offset: config.SearchOffsetMin,
+					Limit:  config.SearchLimitMin,
+				},
+			},
+			want: false,
+		},
+		{
+			name: "params.search.chat.validate.4",
+			fields: fields{
+				StartDate: 1600086400,
+				EndDate:   1600000000,
+				SearchParams: SearchParams{
+					Offset: config.SearchOffsetMin,
+					Limit:  config.SearchLimitMin,
+				},
+			},
+			want: false,
+		},
+		{
+			name: "params.search.chat.validate.5",
+			fields: fields{
+				Query: strings.Repeat("a", config.SearchTermMaxLen+1),
+				SearchParams: SearchParams{
+					Offset: config.SearchOffsetMin,
+					Limit:  config.SearchLimitMin,
+				},
+			},
+			want: false,
+		},
+		{
+			name: "params.search.chat.validate.6",
+			fields: fields{
+				Query: "hacker",
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &SearchChatParams{
+				PlayerID:     tt.fields.PlayerID,
+				ServerID:     tt.fields.ServerID,
+				StartDate:    tt.fields.StartDate,
+				EndDate:      tt.fields.EndDate,
+				Query:        tt.fields.Query,
+				SearchParams: tt.fields.SearchParams,
+			}
+
+			got, errors := body.Validate()
+			if got != tt.want {
+				t.Errorf("Validate() got = %v, want %v\nErrors: %v", got, tt.want, errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/pkg/config"
+	"net/url"
+)
+
+// UpdateSpamSettingsParams holds the data we expect when updating a server's spam settings. Only fields which are set
+// are updated.
+type UpdateSpamSettingsParams struct {
+	Enabled       *bool `json:"enabled" form:"enabled"`
+	MessageLimit  *int  `json:"messageLimit" form:"messageLimit"`
+	MessageWindow *int  `json:"messageWindow" form:"messageWindow"`
+	RepeatLimit   *int  `json:"repeatLimit" form:"repeatLimit"`
+	CapsPercent   *int  `json:"capsPercent" form:"capsPercent"`
+	CapsMinLength *int  `json:"capsMinLength" form:"capsMinLength"`
+	MuteDuration  *int  `json:"muteDuration" form:"muteDuration"`
+}
+
+func (body *UpdateSpamSettingsParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	if body.MessageLimit != nil && (*body.MessageLimit < 0 || *body.MessageLimit > config.SpamMessageLimitMax) {
+		errors.Set("messageLimit", fmt.Sprintf("Message limit must be between 0 and %d", config.SpamMessageLimitMax))
+	}
+
+	if body.MessageWindow != nil && (*body.MessageWindow < 1 || *body.MessageWindow > config.SpamMessageWindowMax) {
+		errors.Set("messageWindow", fmt.Sprintf("Message window must be between 1 and %d seconds",
+			config.SpamMessageWindowMax))
+	}
+
+	// A repeat limit of 1 would mean every message is a repeat so it is not allowed
+	if body.RepeatLimit != nil && (*body.RepeatLimit < 0 || *body.RepeatLimit == 1 ||
+		*body.RepeatLimit > config.SpamRepeatLimitMax) {
+		errors.Set("repeatLimit", fmt.Sprintf("Repeat limit must be 0 or between 2 and %d", config.SpamRepeatLimitMax))
+	}
+
+	if body.CapsPercent != nil && (*body.CapsPercent < 0 || *body.CapsPercent > 100) {
+		errors.Set("capsPercent", "Caps percent must be between 0 and 100")
+	}
+
+	if body.CapsMinLength != nil && (*body.CapsMinLength < 1 || *body.CapsMinLength > config.SpamCapsMinLengthMax) {
+		errors.Set("capsMinLength", fmt.Sprintf("Caps min length must be between 1 and %d",
+			config.SpamCapsMinLengthMax))
+	}
+
+	if body.MuteDuration != nil && (*body.MuteDuration < 1 || *body.MuteDuration > config.InfractionDurationMax) {
+		errors.Set("muteDuration", fmt.Sprintf("Mute duration must be between 1 and %d minutes",
+			config.InfractionDurationMax))
+	}
+
+	return len(errors) == 0, errors
+}
@@ -0,0 +1,88 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestUpdateSpamSettingsParams_Validate(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	boolPtr := func(b bool) *bool { return &b }
+
+	tests := []struct {
+		name      string
+		body      UpdateSpamSettingsParams
+		wantValid bool
+	}{
+		{
+			name: "params.spam.update.1",
+			body: UpdateSpamSettingsParams{
+				Enabled:       boolPtr(true),
+				MessageLimit:  intPtr(5),
+				MessageWindow: intPtr(10),
+				RepeatLimit:   intPtr(3),
+				CapsPercent:   intPtr(75),
+				CapsMinLength: intPtr(8),
+				MuteDuration:  intPtr(15),
+			},
+			wantValid: true,
+		},
+		{
+			name: "params.spam.update.2",
+			body: UpdateSpamSettingsParams{
+				MessageLimit: intPtr(0),
+				RepeatLimit:  intPtr(0),
+				CapsPercent:  intPtr(0),
+			},
+			wantValid: true,
+		},
+		{
+			name: "params.spam.update.3",
+			body: UpdateSpamSettingsParams{
+				MessageLimit:  intPtr(-1),
+				MessageWindow: intPtr(0),
+				RepeatLimit:   intPtr(1),
+				CapsPercent:   intPtr(101),
+				CapsMinLength: intPtr(0),
+				MuteDuration:  intPtr(0),
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.spam.update.4",
+			body: UpdateSpamSettingsParams{
+				MessageWindow: intPtr(config.SpamMessageWindowMax + 1),
+			},
+			wantValid: false,
+		},
+		{
+			name:      "params.spam.update.5",
+			body:      UpdateSpamSettingsParams{},
+			wantValid: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, errors := tt.body.Validate()
+			assert.Equal(t, tt.wantValid, valid, "Validate returned the wrong values. Errors: %v", errors)
+		})
+	}
+}
@@ -34,6 +34,19 @@ type UserMeta struct {
 	Permissions int64
 }
 
+// CanEditInfraction returns true if the user can modify an infraction, or a comment or attachment on one, which was
+// created by ownerID. Users with full access or EDIT_ANY_INFRACTION can modify anyone's, and users with
+// EDIT_OWN_INFRACTIONS can modify their own.
+func (user *UserMeta) CanEditInfraction(ownerID int64) bool {
+	userPerms := bitperms.PermissionValue(user.Permissions)
+
+	if perms.UserHasFullAccess(userPerms) || userPerms.HasFlag(perms.EDIT_ANY_INFRACTION) {
+		return true
+	}
+
+	return ownerID == user.UserID && userPerms.HasFlag(perms.EDIT_OWN_INFRACTIONS)
+}
+
 // CreateUserParams holds the data we expect when creating a new user.
 type CreateUserParams struct {
 	Email           string `json:"email" form:"email"`
@@ -340,3 +340,54 @@ func TestSetUserPasswordParams_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestUserMeta_CanEditInfraction(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    UserMeta
+		ownerID int64
+		want    bool
+	}{
+		{
+			name:    "params.usermeta.caneditinfraction.1",
+			user:    UserMeta{UserID: 1, Permissions: perms.EDIT_OWN_INFRACTIONS},
+			ownerID: 1,
+			want:    true,
+		},
+		{
+			name:    "params.usermeta.caneditinfraction.2",
+			user:    UserMeta{UserID: 1, Permissions: perms.EDIT_OWN_INFRACTIONS},
+			ownerID: 2,
+			want:    false,
+		},
+		{
+			name:    "params.usermeta.caneditinfraction.3",
+			user:    UserMeta{UserID: 1, Permissions: perms.EDIT_ANY_INFRACTION},
+			ownerID: 2,
+			want:    true,
+		},
+		{
+			name:    "params.usermeta.caneditinfraction.4",
+			user:    UserMeta{UserID: 1, Permissions: perms.FULL_ACCESS},
+			ownerID: 2,
+			want:    true,
+		},
+		{
+			name:    "params.usermeta.caneditinfraction.5",
+			user:    UserMeta{UserID: 1, Permissions: perms.SUPER_ADMIN},
+			ownerID: 2,
+			want:    true,
+		},
+		{
+			name:    "params.usermeta.caneditinfraction.6",
+			user:    UserMeta{UserID: 1, Permissions: perms.LOG_WARNING},
+			ownerID: 1,
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.user.CanEditInfraction(tt.ownerID), "CanEditInfraction results should be equal")
+		})
+	}
+}
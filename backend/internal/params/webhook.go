@@ -0,0 +1,138 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/pkg/config"
+	"net/url"
+	"strings"
+)
+
+var validWebhookEvents = []string{
+	"infraction.create",
+	"infraction.update",
+	"infraction.delete",
+	"player.join",
+	"player.quit",
+	"player.update",
+	"server.online",
+	"server.offline",
+	"chat.message",
+	"watched-player.join",
+}
+
+// CreateWebhookParams holds the data we expect when creating a webhook. If Secret is left empty, one is generated.
+type CreateWebhookParams struct {
+	Name   string   `json:"name" form:"name"`
+	URL    string   `json:"url" form:"url"`
+	Secret string   `json:"secret" form:"secret"`
+	Events []string `json:"events" form:"events"`
+}
+
+func (body *CreateWebhookParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	body.Name = strings.TrimSpace(body.Name)
+	body.URL = strings.TrimSpace(body.URL)
+
+	validateWebhookName(body.Name, errors)
+	validateHTTPURL("url", body.URL, errors)
+	validateWebhookEvents(body.Events, errors)
+
+	if body.Secret != "" {
+		validateWebhookSecret(body.Secret, errors)
+	}
+
+	return len(errors) == 0, errors
+}
+
+// UpdateWebhookParams holds the data we expect when updating a webhook. Only fields which are set are updated.
+type UpdateWebhookParams struct {
+	Name    *string   `json:"name" form:"name"`
+	URL     *string   `json:"url" form:"url"`
+	Secret  *string   `json:"secret" form:"secret"`
+	Events  *[]string `json:"events" form:"events"`
+	Enabled *bool     `json:"enabled" form:"enabled"`
+}
+
+func (body *UpdateWebhookParams) Validate() (bool, url.Values) {
+	errors := url.Values{}
+
+	if body.Name != nil {
+		*body.Name = strings.TrimSpace(*body.Name)
+		validateWebhookName(*body.Name, errors)
+	}
+
+	if body.URL != nil {
+		*body.URL = strings.TrimSpace(*body.URL)
+		validateHTTPURL("url", *body.URL, errors)
+	}
+
+	if body.Secret != nil {
+		validateWebhookSecret(*body.Secret, errors)
+	}
+
+	if body.Events != nil {
+		validateWebhookEvents(*body.Events, errors)
+	}
+
+	return len(errors) == 0, errors
+}
+
+// Field validators shared by the create and update webhook params
+func validateWebhookName(name string, errors url.Values) {
+	if len(name) < config.WebhookNameMinLen || len(name) > config.WebhookNameMaxLen {
+		errors.Set("name", fmt.Sprintf("Name must be between %d and %d characters in length",
+			config.WebhookNameMinLen, config.WebhookNameMaxLen))
+	}
+}
+
+// validateHTTPURL checks that value is an absolute http or https URL. Errors are set under field.
+func validateHTTPURL(field string, value string, errors url.Values) {
+	if len(value) > config.WebhookURLMaxLen {
+		errors.Set(field, fmt.Sprintf("URL must be no more than %d characters in length", config.WebhookURLMaxLen))
+		return
+	}
+
+	parsed, err := url.ParseRequestURI(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		errors.Set(field, "URL must be a valid http or https URL")
+	}
+}
+
+func validateWebhookSecret(secret string, errors url.Values) {
+	if len(secret) < config.WebhookSecretMinLen || len(secret) > config.WebhookSecretMaxLen {
+		errors.Set("secret", fmt.Sprintf("Secret must be between %d and %d characters in length",
+			config.WebhookSecretMinLen, config.WebhookSecretMaxLen))
+	}
+}
+
+func validateWebhookEvents(events []string, errors url.Values) {
+	if len(events) < 1 {
+		errors.Set("events", "At least one event must be selected")
+		return
+	}
+
+	for _, event := range events {
+		if !containsString(validWebhookEvents, event) {
+			errors.Set("events", "Invalid event. Valid events are: "+strings.Join(validWebhookEvents, ", "))
+			return
+		}
+	}
+}
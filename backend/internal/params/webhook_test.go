@@ -0,0 +1,109 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package params
+
+import (
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestCreateWebhookParams_Validate(t *testing.T) {
+	type fields struct {
+		Name   string
+		URL    string
+		Secret string
+		Events []string
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		wantValid bool
+	}{
+		{
+			name: "params.webhook.create.1",
+			fields: fields{
+				Name:   "Discord bot",
+				URL:    "https://example.com/refractor",
+				Events: []string{"infraction.create", "player.join", "watched-player.join"},
+			},
+			wantValid: true,
+		},
+		{
+			name: "params.webhook.create.2",
+			fields: fields{
+				Name:   "",
+				URL:    "not a url",
+				Events: []string{},
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.webhook.create.3",
+			fields: fields{
+				Name:   "Internal tooling",
+				URL:    "ftp://example.com/refractor",
+				Events: []string{"chat.message"},
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.webhook.create.4",
+			fields: fields{
+				Name:   "Internal tooling",
+				URL:    "http://localhost:8080/hook",
+				Events: []string{"server.online", "server.explode"},
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.webhook.create.5",
+			fields: fields{
+				Name:   "Internal tooling",
+				URL:    "http://localhost:8080/hook",
+				Secret: "short",
+				Events: []string{"server.online"},
+			},
+			wantValid: false,
+		},
+		{
+			name: "params.webhook.create.6",
+			fields: fields{
+				Name:   strings.Repeat("a", config.WebhookNameMaxLen+1),
+				URL:    "http://localhost:8080/hook",
+				Secret: strings.Repeat("s", config.WebhookSecretMinLen),
+				Events: []string{"server.online"},
+			},
+			wantValid: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &CreateWebhookParams{
+				Name:   tt.fields.Name,
+				URL:    tt.fields.URL,
+				Secret: tt.fields.Secret,
+				Events: tt.fields.Events,
+			}
+
+			valid, errors := body.Validate()
+			assert.Equal(t, tt.wantValid, valid, "Validate returned the wrong values. Errors: %v", errors)
+		})
+	}
+}
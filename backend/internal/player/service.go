@@ -19,6 +19,7 @@ package player
 
 import (
 	"database/sql"
+	"github.com/sniddunc/refractor/internal/params"
 	"github.com/sniddunc/refractor/pkg/config"
 	"github.com/sniddunc/refractor/pkg/log"
 	"github.com/sniddunc/refractor/refractor"
@@ -55,6 +56,42 @@ func (s *playerService) CreatePlayer(newPlayer *refractor.DBPlayer) (*refractor.
 	}
 }
 
+// GetOrCreatePlaceholder gets the player with the provided game ID. If they aren't stored yet, a placeholder player is
+// created for them so that infractions can be recorded against players who have never joined one of our servers. If
+// name is empty, the game ID is used as the placeholder's name until the player joins.
+func (s *playerService) GetOrCreatePlaceholder(playerGameID string, name string, gameConfig *refractor.GameConfig) (*refractor.Player, *refractor.ServiceResponse) {
+	player, res := s.GetPlayer(refractor.FindArgs{
+		gameConfig.PlayerGameIDField: playerGameID,
+	})
+	if !res.Success || player != nil {
+		return player, res
+	}
+
+	if name == "" {
+		name = playerGameID
+	}
+
+	placeholder := &refractor.DBPlayer{
+		CurrentName: name,
+		Placeholder: true,
+	}
+
+	// Set proper field using reflection
+	r := reflect.ValueOf(placeholder)
+	field := reflect.Indirect(r).FieldByName(gameConfig.PlayerGameIDField)
+	field.Set(reflect.ValueOf(sql.NullString{String: playerGameID, Valid: true}))
+
+	player, res = s.CreatePlayer(placeholder)
+	if player == nil {
+		return nil, res
+	}
+
+	s.log.Info("Created placeholder player ID %d for %s %s", player.PlayerID, gameConfig.PlayerGameIDField,
+		playerGameID)
+
+	return player, res
+}
+
 func (s *playerService) GetPlayerByID(id int64) (*refractor.Player, *refractor.ServiceResponse) {
 	player, err := s.repo.FindByID(id)
 	if err != nil {
@@ -85,10 +122,37 @@ func (s *playerService) GetRecentPlayers() ([]*refractor.Player, *refractor.Serv
 	}
 }
 
-func (s *playerService) SetPlayerWatch(id int64, watch bool) *refractor.ServiceResponse {
-	updated, err := s.repo.Update(id, refractor.UpdateArgs{
-		"Watched": watch,
-	})
+// WatchPlayer adds a player to the watchlist. If the player is already watched, their watch is replaced.
+func (s *playerService) WatchPlayer(id int64, body params.WatchPlayerParams) *refractor.ServiceResponse {
+	now := time.Now().Unix()
+
+	watchExpiresAt := sql.NullInt64{}
+	if body.Duration > 0 {
+		watchExpiresAt = sql.NullInt64{Int64: now + int64(body.Duration)*60, Valid: true}
+	}
+
+	return s.setPlayerWatch(id, refractor.UpdateArgs{
+		"Watched":        true,
+		"WatchReason":    sql.NullString{String: body.Reason, Valid: body.Reason != ""},
+		"WatchedBy":      sql.NullInt64{Int64: body.UserMeta.UserID, Valid: true},
+		"WatchedAt":      sql.NullInt64{Int64: now, Valid: true},
+		"WatchExpiresAt": watchExpiresAt,
+	}, "Player added to the watchlist")
+}
+
+// UnwatchPlayer removes a player from the watchlist.
+func (s *playerService) UnwatchPlayer(id int64) *refractor.ServiceResponse {
+	return s.setPlayerWatch(id, refractor.UpdateArgs{
+		"Watched":        false,
+		"WatchReason":    sql.NullString{},
+		"WatchedBy":      sql.NullInt64{},
+		"WatchedAt":      sql.NullInt64{},
+		"WatchExpiresAt": sql.NullInt64{},
+	}, "Player removed from the watchlist")
+}
+
+func (s *playerService) setPlayerWatch(id int64, args refractor.UpdateArgs, message string) *refractor.ServiceResponse {
+	updated, err := s.repo.Update(id, args)
 	if err != nil {
 		if err == refractor.ErrNotFound {
 			return &refractor.ServiceResponse{
@@ -102,19 +166,13 @@ func (s *playerService) SetPlayerWatch(id int64, watch bool) *refractor.ServiceR
 		return refractor.InternalErrorResponse
 	}
 
-	res := &refractor.ServiceResponse{
+	s.notifyPlayerUpdate(updated)
+
+	return &refractor.ServiceResponse{
 		Success:    true,
 		StatusCode: http.StatusOK,
-		Message:    "Player added to the watchlist",
+		Message:    message,
 	}
-
-	if !watch {
-		res.Message = "Player removed from the watchlist"
-	}
-
-	s.notifyPlayerUpdate(updated)
-
-	return res
 }
 
 func (s *playerService) OnPlayerJoin(serverID int64, playerGameID string, currentName string, gameConfig *refractor.GameConfig) (*refractor.Player, *refractor.ServiceResponse) {
@@ -157,6 +215,12 @@ func (s *playerService) OnPlayerJoin(serverID int64, playerGameID string, curren
 		}
 	}
 
+	// Placeholder players were created by an offline ban before the player had ever joined. Now that they have joined,
+	// the placeholder is given their real name.
+	if foundPlayer.Placeholder {
+		return s.reconcilePlaceholder(foundPlayer, currentName)
+	}
+
 	// If they player was already in storage, check if their name changed.
 	if foundPlayer.CurrentName != currentName {
 		s.log.Info("Updating name for player (%d) %s to %s", foundPlayer.PlayerID, foundPlayer.CurrentName, currentName)
@@ -174,6 +238,35 @@ func (s *playerService) OnPlayerJoin(serverID int64, playerGameID string, curren
 	}
 }
 
+// reconcilePlaceholder replaces a placeholder player's stand-in name with their real name and marks them as a regular
+// player. The stand-in name is not kept as one of their previous names since it was never actually used by them.
+func (s *playerService) reconcilePlaceholder(placeholder *refractor.Player, currentName string) (*refractor.Player, *refractor.ServiceResponse) {
+	if err := s.repo.ReplaceName(placeholder, currentName); err != nil {
+		s.log.Error("Could not replace name of placeholder player ID %d. Error: %v", placeholder.PlayerID, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	updated, err := s.repo.Update(placeholder.PlayerID, refractor.UpdateArgs{
+		"Placeholder": false,
+		"LastSeen":    time.Now().Unix(),
+	})
+	if err != nil {
+		s.log.Error("Could not update placeholder player ID %d. Error: %v", placeholder.PlayerID, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	s.log.Info("Placeholder player ID %d joined for the first time and was reconciled with their name %s",
+		updated.PlayerID, currentName)
+
+	s.notifyPlayerUpdate(updated)
+
+	return updated, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Placeholder player reconciled",
+	}
+}
+
 func (s *playerService) OnPlayerQuit(serverID int64, playerGameID string, gameConfig *refractor.GameConfig) (*refractor.Player, *refractor.ServiceResponse) {
 	foundPlayer, err := s.repo.FindOne(refractor.FindArgs{
 		gameConfig.PlayerGameIDField: playerGameID,
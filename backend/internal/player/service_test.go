@@ -0,0 +1,97 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package player
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/internal/mock"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_playerService_OnPlayerJoin(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+	gameConfig := &refractor.GameConfig{PlayerGameIDField: "PlayFabID"}
+
+	type args struct {
+		playerGameID string
+		currentName  string
+	}
+	tests := []struct {
+		name        string
+		mockPlayers map[int64]*refractor.DBPlayer
+		args        args
+		wantPlayer  *refractor.DBPlayer
+	}{
+		{
+			name:        "player.onplayerjoin.1",
+			mockPlayers: map[int64]*refractor.DBPlayer{},
+			args: args{
+				playerGameID: "ABCDEF",
+				currentName:  "NewPlayer",
+			},
+			wantPlayer: &refractor.DBPlayer{
+				PlayerID:    1,
+				PlayFabID:   sql.NullString{String: "ABCDEF", Valid: true},
+				CurrentName: "NewPlayer",
+				Placeholder: false,
+			},
+		},
+		{
+			name: "player.onplayerjoin.2",
+			mockPlayers: map[int64]*refractor.DBPlayer{
+				1: {
+					PlayerID:    1,
+					PlayFabID:   sql.NullString{String: "ABCDEF", Valid: true},
+					CurrentName: "ABCDEF",
+					Placeholder: true,
+				},
+			},
+			args: args{
+				playerGameID: "ABCDEF",
+				currentName:  "RealName",
+			},
+			wantPlayer: &refractor.DBPlayer{
+				PlayerID:    1,
+				PlayFabID:   sql.NullString{String: "ABCDEF", Valid: true},
+				CurrentName: "RealName",
+				Placeholder: false,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			playerService := NewPlayerService(mock.NewMockPlayerRepository(tt.mockPlayers), testLogger)
+
+			player, res := playerService.OnPlayerJoin(1, tt.args.playerGameID, tt.args.currentName, gameConfig)
+
+			assert.True(t, res.Success, "Join should have been handled successfully")
+			assert.NotNil(t, player, "Player should not be nil")
+			assert.Equal(t, tt.wantPlayer.CurrentName, player.CurrentName, "Current names should be equal")
+			assert.False(t, player.Placeholder, "Player should not be a placeholder")
+			assert.Empty(t, player.PreviousNames, "The placeholder name should not be kept as a previous name")
+
+			stored := tt.mockPlayers[tt.wantPlayer.PlayerID]
+			assert.Equal(t, tt.wantPlayer.PlayFabID, stored.PlayFabID, "Game IDs should be equal")
+			assert.Equal(t, tt.wantPlayer.CurrentName, stored.CurrentName, "Stored names should be equal")
+			assert.Equal(t, tt.wantPlayer.Placeholder, stored.Placeholder, "Placeholder flags should be equal")
+		})
+	}
+}
@@ -42,6 +42,7 @@ func (s *rconService) HandleChatBroadcast(bcast *broadcast.Broadcast, serverID i
 			ServerID:     serverID,
 			PlayerGameID: fields[gameConfig.PlayerGameIDField],
 			Name:         fields["Name"],
+			Channel:      fields["Channel"],
 			Message:      fields["Message"],
 			SentByUser:   false,
 		}
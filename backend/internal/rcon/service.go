@@ -25,11 +25,15 @@ import (
 	"github.com/sniddunc/refractor/pkg/regexutils"
 	"github.com/sniddunc/refractor/refractor"
 	"strconv"
+	"sync"
 	"time"
 )
 
 type rconService struct {
+	// clients is written by the RCON watchdog and disconnect handlers while commands are sent from request handlers,
+	// so it must only be accessed while holding clientsLock.
 	clients                   map[int64]*refractor.RCONClient
+	clientsLock               sync.RWMutex
 	gameService               refractor.GameService
 	playerService             refractor.PlayerService
 	log                       log.Logger
@@ -115,10 +119,12 @@ func (s *rconService) CreateClient(server *refractor.Server) error {
 	}
 
 	// Add to list of clients
+	s.clientsLock.Lock()
 	s.clients[server.ServerID] = &refractor.RCONClient{
 		Server: server,
 		Client: client,
 	}
+	s.clientsLock.Unlock()
 
 	// Get players currently on the server
 	onlinePlayers := s.getOnlinePlayers(server.ServerID, game)
@@ -153,7 +159,7 @@ func (s *rconService) startPlayerListPolling(serverID int64, game refractor.Game
 	for {
 		time.Sleep(game.GetConfig().PlayerListPollingInterval)
 
-		client := s.clients[serverID]
+		client := s.getClient(serverID)
 		if client == nil {
 			s.log.Warn("Player list polling routine could not get the client for server ID %d", serverID)
 			s.log.Warn("Exiting player list polling routine for server ID %d", serverID)
@@ -218,7 +224,7 @@ func (s *rconService) startPlayerListRefreshPoll(serverID int64, game refractor.
 
 		s.log.Info("Player list refresh polling routine running")
 
-		client := s.clients[serverID]
+		client := s.getClient(serverID)
 		if client == nil {
 			s.log.Warn("Player list refresh polling routine could not get the client for server ID %d", serverID)
 			s.log.Warn("Exiting player list refresh polling routine for server ID %d", serverID)
@@ -249,22 +255,94 @@ func (s *rconService) startPlayerListRefreshPoll(serverID int64, game refractor.
 	}
 }
 
+// GetClients returns a copy of the connected clients so that callers can't race with clients being added or removed
 func (s *rconService) GetClients() map[int64]*refractor.RCONClient {
-	return s.clients
+	s.clientsLock.RLock()
+	defer s.clientsLock.RUnlock()
+
+	clients := make(map[int64]*refractor.RCONClient, len(s.clients))
+	for serverID, client := range s.clients {
+		clients[serverID] = client
+	}
+
+	return clients
 }
 
 func (s *rconService) DeleteClient(serverID int64) {
+	s.clientsLock.Lock()
 	delete(s.clients, serverID)
+	s.clientsLock.Unlock()
+}
+
+// getClient returns the connected client of a server, or nil if the server does not have one
+func (s *rconService) getClient(serverID int64) *refractor.RCONClient {
+	s.clientsLock.RLock()
+	defer s.clientsLock.RUnlock()
+
+	return s.clients[serverID]
+}
+
+// ExecCommand runs a command through the RCON client of the given server and returns the server's response.
+// ErrNoRCONClient is returned if the server does not currently have a connected client.
+func (s *rconService) ExecCommand(serverID int64, command string) (string, error) {
+	client := s.getClient(serverID)
+	if client == nil {
+		return "", refractor.ErrNoRCONClient
+	}
+
+	return client.ExecCommand(command)
 }
 
 func (s *rconService) SendChatMessage(msgBody *refractor.ChatSendBody) {
-	client := s.clients[msgBody.ServerID]
+	message := fmt.Sprintf("[%s]: %s", msgBody.Sender, msgBody.Message)
 
-	if _, err := client.ExecCommand(fmt.Sprintf("Say [%s]: %s", msgBody.Sender, msgBody.Message)); err != nil {
+	err := s.execGameCommand(msgBody.ServerID, func(game refractor.Game) string {
+		return game.GetBroadcastChatCommand(refractor.CommandArgs{
+			Message: message,
+		})
+	})
+
+	if err == refractor.ErrUnsupportedCommand {
+		s.log.Warn("Could not send chat message to server %d. Its game does not support broadcast messages",
+			msgBody.ServerID)
+	} else if err != nil {
 		s.log.Error("Could not send chat message to server %d. Error: %v", msgBody.ServerID, err)
 	}
 }
 
+// SendPrivateMessage sends a message to a single player on a server. playerGameID is the player's ID in the server's
+// game, e.g. their PlayFabID on Mordhau servers.
+func (s *rconService) SendPrivateMessage(serverID int64, playerGameID string, message string) error {
+	return s.execGameCommand(serverID, func(game refractor.Game) string {
+		return game.GetPrivateMessageCommand(refractor.CommandArgs{
+			PlayerID: playerGameID,
+			Message:  message,
+		})
+	})
+}
+
+// execGameCommand builds a command for a server's game using buildCommand and runs it on the server. If the game
+// does not support the command, refractor.ErrUnsupportedCommand is returned.
+func (s *rconService) execGameCommand(serverID int64, buildCommand func(game refractor.Game) string) error {
+	client := s.getClient(serverID)
+	if client == nil {
+		return refractor.ErrNoRCONClient
+	}
+
+	game, _ := s.gameService.GetGame(client.Server.Game)
+	if game == nil {
+		return fmt.Errorf("invalid game for server ID %d: %s", serverID, client.Server.Game)
+	}
+
+	command := buildCommand(game)
+	if command == "" {
+		return refractor.ErrUnsupportedCommand
+	}
+
+	_, err := client.ExecCommand(command)
+	return err
+}
+
 // SubscribeJoin adds a function to a slice of functions to be called when a player joins a server
 func (s *rconService) SubscribeJoin(subscriber refractor.BroadcastSubscriber) {
 	s.joinSubscribers = append(s.joinSubscribers, subscriber)
@@ -317,7 +395,7 @@ func (s *rconService) getBroadcastListener(serverID int64, gameConfig *refractor
 
 func (s *rconService) getDisconnectHandler(serverID int64) func(error, bool) {
 	return func(err error, expected bool) {
-		delete(s.clients, serverID)
+		s.DeleteClient(serverID)
 
 		// Notify all subscribers of a server offline event
 		for _, sub := range s.offlineSubscribers {
@@ -332,9 +410,14 @@ type onlinePlayer struct {
 }
 
 func (s *rconService) getOnlinePlayers(serverID int64, game refractor.Game) []*onlinePlayer {
+	client := s.getClient(serverID)
+	if client == nil {
+		return nil
+	}
+
 	playerListCommand := game.GetPlayerListCommand()
 
-	res, err := s.clients[serverID].ExecCommand(playerListCommand)
+	res, err := client.ExecCommand(playerListCommand)
 	if err != nil {
 		s.log.Error("RCON ExecCommand %s failed with error: %v", playerListCommand, err)
 		return nil
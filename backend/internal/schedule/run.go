@@ -0,0 +1,148 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package schedule
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/cron"
+	"github.com/sniddunc/refractor/pkg/textutils"
+	"github.com/sniddunc/refractor/refractor"
+	"time"
+)
+
+// RunDueSchedules runs every enabled schedule which matches the minute now falls in. It is called once a minute by the
+// schedule watchdog.
+//
+// Each schedule's LastRunAt is updated before it is run. Since schedules are stored in the database, this stops a
+// schedule from running twice in the same minute if Refractor is restarted.
+func (s *scheduleService) RunDueSchedules(now time.Time) {
+	schedules, err := s.repo.FindAll()
+	if err != nil {
+		if err != refractor.ErrNotFound {
+			s.log.Error("Could not get schedules to run. Error: %v", err)
+		}
+		return
+	}
+
+	minuteStart := now.Truncate(time.Minute).Unix()
+
+	for _, schedule := range schedules {
+		if !schedule.Enabled || schedule.LastRunAt >= minuteStart {
+			continue
+		}
+
+		parsed, err := cron.Parse(schedule.Cron)
+		if err != nil {
+			s.log.Warn("Schedule ID %d has an invalid cron expression. Error: %v", schedule.ScheduleID, err)
+			continue
+		}
+
+		if !parsed.Matches(now) {
+			continue
+		}
+
+		if _, err := s.repo.Update(schedule.ScheduleID, refractor.UpdateArgs{
+			"LastRunAt": now.Unix(),
+		}); err != nil {
+			s.log.Error("Could not update last run time of schedule ID %d. Error: %v", schedule.ScheduleID, err)
+			continue
+		}
+
+		s.runSchedule(schedule, now)
+	}
+}
+
+// runSchedule runs a schedule on its server, or on every server if it isn't tied to one, and logs each run.
+func (s *scheduleService) runSchedule(schedule *refractor.Schedule, now time.Time) {
+	var servers []*refractor.Server
+
+	if schedule.ServerID != 0 {
+		server, _ := s.serverService.GetServerByID(schedule.ServerID)
+		if server == nil {
+			s.log.Warn("Could not get server ID %d to run schedule ID %d on", schedule.ServerID, schedule.ScheduleID)
+			return
+		}
+
+		servers = append(servers, server)
+	} else {
+		allServers, res := s.serverService.GetAllServers()
+		if !res.Success {
+			s.log.Warn("Could not get servers to run schedule ID %d on", schedule.ScheduleID)
+			return
+		}
+
+		servers = allServers
+	}
+
+	for _, server := range servers {
+		run := &refractor.ScheduleRun{
+			ScheduleID: schedule.ScheduleID,
+			ServerID:   server.ServerID,
+			RanAt:      now.Unix(),
+		}
+
+		command, err := s.getCommand(schedule, server)
+		if err == nil {
+			var output string
+			output, err = s.rconService.ExecCommand(server.ServerID, command)
+
+			run.Output = textutils.Truncate(output, config.ScheduleOutputMaxLen, "")
+		}
+
+		run.Command = command
+		run.Success = err == nil
+
+		if err != nil {
+			run.Error = err.Error()
+
+			// Offline servers are expected so only other errors are logged
+			if err != refractor.ErrNoRCONClient {
+				s.log.Warn("Could not run schedule ID %d on server ID %d. Error: %v", schedule.ScheduleID,
+					server.ServerID, err)
+			}
+		}
+
+		if err := s.runRepo.Create(run); err != nil {
+			s.log.Error("Could not store run of schedule ID %d. Error: %v", schedule.ScheduleID, err)
+		}
+	}
+}
+
+// getCommand returns the command to send to a server when running a schedule. Announcements use the broadcast chat
+// command of the server's game.
+func (s *scheduleService) getCommand(schedule *refractor.Schedule, server *refractor.Server) (string, error) {
+	if schedule.ActionType != refractor.SCHEDULE_ACTION_ANNOUNCEMENT {
+		return schedule.Payload, nil
+	}
+
+	game, _ := s.gameService.GetGame(server.Game)
+	if game == nil {
+		return "", fmt.Errorf("invalid game for server ID %d: %s", server.ServerID, server.Game)
+	}
+
+	command := game.GetBroadcastChatCommand(refractor.CommandArgs{
+		Message: schedule.Payload,
+	})
+
+	if command == "" {
+		return "", refractor.ErrUnsupportedCommand
+	}
+
+	return command, nil
+}
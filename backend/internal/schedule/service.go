@@ -0,0 +1,273 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package schedule
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/cron"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type scheduleService struct {
+	repo          refractor.ScheduleRepository
+	runRepo       refractor.ScheduleRunRepository
+	serverService refractor.ServerService
+	gameService   refractor.GameService
+	rconService   refractor.RCONService
+	log           log.Logger
+}
+
+func NewScheduleService(repo refractor.ScheduleRepository, runRepo refractor.ScheduleRunRepository,
+	serverService refractor.ServerService, gameService refractor.GameService, rconService refractor.RCONService,
+	log log.Logger) refractor.ScheduleService {
+	return &scheduleService{
+		repo:          repo,
+		runRepo:       runRepo,
+		serverService: serverService,
+		gameService:   gameService,
+		rconService:   rconService,
+		log:           log,
+	}
+}
+
+func (s *scheduleService) CreateSchedule(body params.CreateScheduleParams) (*refractor.Schedule, *refractor.ServiceResponse) {
+	if res := s.checkServerExists(body.ServerID); res != nil {
+		return nil, res
+	}
+
+	newSchedule := &refractor.Schedule{
+		Name:       body.Name,
+		Cron:       body.Cron,
+		ActionType: body.ActionType,
+		Payload:    body.Payload,
+		ServerID:   body.ServerID,
+		Enabled:    true,
+		CreatedBy:  body.UserMeta.UserID,
+		CreatedAt:  time.Now().Unix(),
+	}
+
+	if err := s.repo.Create(newSchedule); err != nil {
+		s.log.Error("Could not insert new schedule into repository. Error: %v", err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	setNextRun(newSchedule, time.Now())
+
+	return newSchedule, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Schedule created",
+	}
+}
+
+func (s *scheduleService) GetAllSchedules() ([]*refractor.Schedule, *refractor.ServiceResponse) {
+	schedules, err := s.repo.FindAll()
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return []*refractor.Schedule{}, &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Fetched 0 schedules",
+			}
+		}
+
+		s.log.Error("Could not FindAll schedules from repository. Error: %v", err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	if schedules == nil {
+		schedules = []*refractor.Schedule{}
+	}
+
+	now := time.Now()
+	for _, schedule := range schedules {
+		setNextRun(schedule, now)
+	}
+
+	return schedules, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    fmt.Sprintf("Fetched %d schedules", len(schedules)),
+	}
+}
+
+func (s *scheduleService) UpdateSchedule(id int64, body params.UpdateScheduleParams) (*refractor.Schedule, *refractor.ServiceResponse) {
+	updateArgs := refractor.UpdateArgs{}
+
+	if body.Name != nil {
+		updateArgs["Name"] = *body.Name
+	}
+
+	if body.Cron != nil {
+		updateArgs["Cron"] = *body.Cron
+	}
+
+	if body.ActionType != nil {
+		updateArgs["ActionType"] = *body.ActionType
+	}
+
+	if body.Payload != nil {
+		updateArgs["Payload"] = *body.Payload
+	}
+
+	if body.ServerID != nil {
+		if res := s.checkServerExists(*body.ServerID); res != nil {
+			return nil, res
+		}
+
+		updateArgs["ServerID"] = *body.ServerID
+	}
+
+	if body.Enabled != nil {
+		updateArgs["Enabled"] = *body.Enabled
+	}
+
+	if len(updateArgs) < 1 {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    "No updated values provided",
+		}
+	}
+
+	updatedSchedule, err := s.repo.Update(id, updateArgs)
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return nil, &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			}
+		}
+
+		s.log.Error("Could not update schedule of ID %d in repo. Error: %v", id, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	setNextRun(updatedSchedule, time.Now())
+
+	return updatedSchedule, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Schedule updated",
+	}
+}
+
+func (s *scheduleService) DeleteSchedule(id int64) *refractor.ServiceResponse {
+	if err := s.repo.Delete(id); err != nil {
+		if err == refractor.ErrNotFound {
+			return &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			}
+		}
+
+		s.log.Error("Could not delete schedule with ID %d. Error: %v", id, err)
+		return refractor.InternalErrorResponse
+	}
+
+	return &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Schedule deleted",
+	}
+}
+
+func (s *scheduleService) GetScheduleRuns(id int64, body params.SearchParams) (int, []*refractor.ScheduleRun, *refractor.ServiceResponse) {
+	// Make sure schedule exists
+	if _, err := s.repo.FindByID(id); err != nil {
+		if err == refractor.ErrNotFound {
+			return 0, nil, &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			}
+		}
+
+		s.log.Error("Could not get schedule of ID %d from repo. Error: %v", id, err)
+		return 0, nil, refractor.InternalErrorResponse
+	}
+
+	count, runs, err := s.runRepo.FindManyByScheduleID(id, body.Limit, body.Offset)
+	if err != nil && err != refractor.ErrNotFound {
+		s.log.Error("Could not get runs of schedule ID %d. Error: %v", id, err)
+		return 0, nil, refractor.InternalErrorResponse
+	}
+
+	if runs == nil {
+		runs = []*refractor.ScheduleRun{}
+	}
+
+	return count, runs, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    fmt.Sprintf("Fetched %d schedule runs", len(runs)),
+	}
+}
+
+// checkServerExists returns a failed response if serverID is set but doesn't belong to a server. A server ID of 0 is
+// allowed since it means the schedule runs on every server.
+func (s *scheduleService) checkServerExists(serverID int64) *refractor.ServiceResponse {
+	if serverID == 0 {
+		return nil
+	}
+
+	server, res := s.serverService.GetServerByID(serverID)
+	if !res.Success {
+		return res
+	}
+
+	if server == nil {
+		errors := url.Values{}
+		errors.Set("serverId", "Server does not exist")
+
+		return &refractor.ServiceResponse{
+			Success:          false,
+			StatusCode:       http.StatusBadRequest,
+			ValidationErrors: errors,
+		}
+	}
+
+	return nil
+}
+
+// setNextRun sets the time a schedule will next run at. Disabled schedules and schedules which will never run have a
+// NextRunAt of 0.
+func setNextRun(schedule *refractor.Schedule, now time.Time) {
+	schedule.NextRunAt = 0
+
+	if !schedule.Enabled {
+		return
+	}
+
+	parsed, err := cron.Parse(schedule.Cron)
+	if err != nil {
+		return
+	}
+
+	if next := parsed.Next(now); !next.IsZero() {
+		schedule.NextRunAt = next.Unix()
+	}
+}
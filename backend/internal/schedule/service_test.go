@@ -0,0 +1,185 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package schedule
+
+import (
+	"github.com/sniddunc/refractor/internal/game"
+	"github.com/sniddunc/refractor/internal/mock"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/internal/server"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func getMockServers() map[int64]*refractor.Server {
+	return map[int64]*refractor.Server{
+		1: {ServerID: 1, Name: "Server 1", Game: "TestGame"},
+		2: {ServerID: 2, Name: "Server 2", Game: "TestGame"},
+	}
+}
+
+func Test_scheduleService_CreateSchedule(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	tests := []struct {
+		name       string
+		body       params.CreateScheduleParams
+		wantStatus int
+	}{
+		{
+			name: "schedule.create.1",
+			body: params.CreateScheduleParams{
+				Name:       "Restart warning",
+				Cron:       "55 * * * *",
+				ActionType: refractor.SCHEDULE_ACTION_ANNOUNCEMENT,
+				Payload:    "Server restarts in 5 minutes",
+				ServerID:   1,
+				UserMeta:   &params.UserMeta{UserID: 1},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "schedule.create.2",
+			body: params.CreateScheduleParams{
+				Name:       "Save world",
+				Cron:       "*/10 * * * *",
+				ActionType: refractor.SCHEDULE_ACTION_COMMAND,
+				Payload:    "save-all",
+				ServerID:   0,
+				UserMeta:   &params.UserMeta{UserID: 1},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "schedule.create.3",
+			body: params.CreateScheduleParams{
+				Name:       "Unknown server",
+				Cron:       "0 * * * *",
+				ActionType: refractor.SCHEDULE_ACTION_COMMAND,
+				Payload:    "save-all",
+				ServerID:   5,
+				UserMeta:   &params.UserMeta{UserID: 1},
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameService := game.NewGameService()
+			gameService.AddGame(mock.NewMockGame())
+			serverService := server.NewServerService(mock.NewMockServerRepository(getMockServers()), gameService, testLogger)
+			service := NewScheduleService(mock.NewMockScheduleRepository(map[int64]*refractor.Schedule{}),
+				mock.NewMockScheduleRunRepository(map[int64]*refractor.ScheduleRun{}), serverService,
+				gameService, mock.NewMockRCONService(1), testLogger)
+
+			schedule, res := service.CreateSchedule(tt.body)
+
+			assert.Equal(t, tt.wantStatus, res.StatusCode, "Status codes should match")
+
+			if tt.wantStatus == http.StatusOK {
+				assert.True(t, schedule.Enabled, "New schedules should be enabled")
+				assert.Equal(t, tt.body.UserMeta.UserID, schedule.CreatedBy, "CreatedBy should match the creator")
+				assert.Greater(t, schedule.NextRunAt, time.Now().Unix(), "NextRunAt should be in the future")
+			}
+		})
+	}
+}
+
+func Test_scheduleService_RunDueSchedules(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	now := time.Date(2021, 3, 1, 12, 30, 15, 0, time.Local)
+
+	tests := []struct {
+		name         string
+		schedules    map[int64]*refractor.Schedule
+		wantCommands map[int64][]string
+		wantRuns     int
+		wantFailed   int
+	}{
+		{
+			name: "schedule.rundue.1",
+			schedules: map[int64]*refractor.Schedule{
+				1: {ScheduleID: 1, Cron: "30 12 * * *", ActionType: refractor.SCHEDULE_ACTION_COMMAND,
+					Payload: "save-all", ServerID: 1, Enabled: true},
+			},
+			wantCommands: map[int64][]string{1: {"save-all"}},
+			wantRuns:     1,
+			wantFailed:   0,
+		},
+		{
+			name: "schedule.rundue.2",
+			schedules: map[int64]*refractor.Schedule{
+				1: {ScheduleID: 1, Cron: "*/15 * * * *", ActionType: refractor.SCHEDULE_ACTION_ANNOUNCEMENT,
+					Payload: "Join our Discord!", ServerID: 0, Enabled: true},
+			},
+			wantCommands: map[int64][]string{1: {"mocksay Join our Discord!"}},
+			wantRuns:     2,
+			wantFailed:   1,
+		},
+		{
+			name: "schedule.rundue.3",
+			schedules: map[int64]*refractor.Schedule{
+				1: {ScheduleID: 1, Cron: "31 12 * * *", ActionType: refractor.SCHEDULE_ACTION_COMMAND,
+					Payload: "not due", ServerID: 1, Enabled: true},
+				2: {ScheduleID: 2, Cron: "* * * * *", ActionType: refractor.SCHEDULE_ACTION_COMMAND,
+					Payload: "disabled", ServerID: 1, Enabled: false},
+				3: {ScheduleID: 3, Cron: "* * * * *", ActionType: refractor.SCHEDULE_ACTION_COMMAND,
+					Payload: "already ran", ServerID: 1, Enabled: true, LastRunAt: now.Add(-10 * time.Second).Unix()},
+			},
+			wantCommands: map[int64][]string{},
+			wantRuns:     0,
+			wantFailed:   0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameService := game.NewGameService()
+			gameService.AddGame(mock.NewMockGame())
+			serverService := server.NewServerService(mock.NewMockServerRepository(getMockServers()), gameService, testLogger)
+			rconService := mock.NewMockRCONService(1)
+			runRepo := mock.NewMockScheduleRunRepository(map[int64]*refractor.ScheduleRun{})
+
+			service := NewScheduleService(mock.NewMockScheduleRepository(tt.schedules), runRepo, serverService,
+				gameService, rconService, testLogger)
+
+			service.RunDueSchedules(now)
+
+			assert.Equal(t, tt.wantCommands, rconService.Commands, "Executed commands should match")
+			assert.Equal(t, tt.wantRuns, len(runRepo.Runs), "Number of logged runs should match")
+
+			failed := 0
+			for _, run := range runRepo.Runs {
+				if !run.Success {
+					failed++
+				}
+			}
+
+			assert.Equal(t, tt.wantFailed, failed, "Number of failed runs should match")
+
+			// Running again in the same minute should do nothing
+			service.RunDueSchedules(now.Add(20 * time.Second))
+
+			assert.Equal(t, tt.wantRuns, len(runRepo.Runs), "Schedules should not run twice in the same minute")
+		})
+	}
+}
@@ -29,14 +29,18 @@ import (
 type searchService struct {
 	playerRepo     refractor.PlayerRepository
 	infractionRepo refractor.InfractionRepository
+	appealRepo     refractor.AppealRepository
+	chatRepo       refractor.ChatRepository
 	log            logger.Logger
 }
 
 func NewSearchService(playerRepo refractor.PlayerRepository, infractionRepo refractor.InfractionRepository,
-	log logger.Logger) refractor.SearchService {
+	appealRepo refractor.AppealRepository, chatRepo refractor.ChatRepository, log logger.Logger) refractor.SearchService {
 	return &searchService{
 		playerRepo:     playerRepo,
 		infractionRepo: infractionRepo,
+		appealRepo:     appealRepo,
+		chatRepo:       chatRepo,
 		log:            log,
 	}
 }
@@ -210,3 +214,107 @@ func (s *searchService) SearchInfractions(body params.SearchInfractionsParams) (
 		Message:    fmt.Sprintf("Found %d total results", count),
 	}
 }
+
+func (s *searchService) SearchAppeals(body params.SearchAppealsParams) (int, []*refractor.Appeal, *refractor.ServiceResponse) {
+	searchArgs := refractor.FindArgs{}
+
+	// add defined arguments from body into searchArgs
+	if body.Status != "" {
+		searchArgs["Status"] = body.Status
+	}
+
+	if body.ParsedAppealIDs.PlayerID != 0 {
+		searchArgs["PlayerID"] = body.ParsedAppealIDs.PlayerID
+	}
+
+	if body.ParsedAppealIDs.InfractionID != 0 {
+		searchArgs["InfractionID"] = body.ParsedAppealIDs.InfractionID
+	}
+
+	if body.ParsedAppealIDs.ReviewerID != 0 {
+		searchArgs["ReviewerID"] = body.ParsedAppealIDs.ReviewerID
+	}
+
+	if len(searchArgs) == 0 {
+		return 0, []*refractor.Appeal{}, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    "You must provide at least one search filter",
+		}
+	}
+
+	// Execute search
+	count, appeals, err := s.appealRepo.Search(searchArgs, body.SearchParams.Limit, body.SearchParams.Offset)
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return 0, []*refractor.Appeal{}, &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Found 0 total results",
+			}
+		}
+
+		s.log.Error("Could not search appeals. Error: %v", err)
+		return 0, []*refractor.Appeal{}, refractor.InternalErrorResponse
+	}
+
+	if appeals == nil {
+		appeals = []*refractor.Appeal{}
+	}
+
+	return count, appeals, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    fmt.Sprintf("Found %d total results", count),
+	}
+}
+
+func (s *searchService) SearchChat(body params.SearchChatParams) (int, []*refractor.ChatMessage, *refractor.ServiceResponse) {
+	searchArgs := refractor.FindArgs{}
+
+	// add defined arguments from body into searchArgs
+	if body.ParsedIDs.PlayerID != 0 {
+		searchArgs["PlayerID"] = body.ParsedIDs.PlayerID
+	}
+
+	if body.ParsedIDs.ServerID != 0 {
+		searchArgs["ServerID"] = body.ParsedIDs.ServerID
+	}
+
+	if body.StartDate != 0 {
+		searchArgs["StartDate"] = body.StartDate
+	}
+
+	if body.EndDate != 0 {
+		searchArgs["EndDate"] = body.EndDate
+	}
+
+	if body.Query != "" {
+		searchArgs["Query"] = body.Query
+	}
+
+	// Execute search
+	count, messages, err := s.chatRepo.Search(searchArgs, body.SearchParams.Limit, body.SearchParams.Offset)
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return 0, []*refractor.ChatMessage{}, &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Found 0 total results",
+			}
+		}
+
+		s.log.Error("Could not search chat messages. Error: %v", err)
+		return 0, []*refractor.ChatMessage{}, refractor.InternalErrorResponse
+	}
+
+	if messages == nil {
+		messages = []*refractor.ChatMessage{}
+	}
+
+	return count, messages, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    fmt.Sprintf("Found %d total results", count),
+	}
+}
@@ -0,0 +1,236 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package session
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/broadcast"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"time"
+)
+
+type sessionService struct {
+	repo          refractor.SessionRepository
+	playerService refractor.PlayerService
+	log           log.Logger
+}
+
+func NewSessionService(repo refractor.SessionRepository, playerService refractor.PlayerService, log log.Logger) refractor.SessionService {
+	return &sessionService{
+		repo:          repo,
+		playerService: playerService,
+		log:           log,
+	}
+}
+
+func (s *sessionService) GetPlayerSessions(playerID int64, body params.SearchParams) (int, []*refractor.PlayerSession, *refractor.ServiceResponse) {
+	// Make sure player exists
+	if _, res := s.playerService.GetPlayerByID(playerID); !res.Success {
+		return 0, nil, res
+	}
+
+	count, sessions, err := s.repo.FindManyByPlayerID(playerID, body.Limit, body.Offset)
+	if err != nil && err != refractor.ErrNotFound {
+		s.log.Error("Could not get sessions of player ID %d. Error: %v", playerID, err)
+		return 0, nil, refractor.InternalErrorResponse
+	}
+
+	if sessions == nil {
+		sessions = []*refractor.PlayerSession{}
+	}
+
+	return count, sessions, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    fmt.Sprintf("Fetched %d sessions", len(sessions)),
+	}
+}
+
+func (s *sessionService) GetPlayerPlaytime(playerID int64) (*refractor.PlayerPlaytime, *refractor.ServiceResponse) {
+	// Make sure player exists
+	if _, res := s.playerService.GetPlayerByID(playerID); !res.Success {
+		return nil, res
+	}
+
+	serverPlaytime, err := s.repo.GetPlaytime(playerID)
+	if err != nil && err != refractor.ErrNotFound {
+		s.log.Error("Could not get playtime of player ID %d. Error: %v", playerID, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	playtime := &refractor.PlayerPlaytime{
+		Servers: map[int64]int64{},
+	}
+
+	for serverID, seconds := range serverPlaytime {
+		playtime.Servers[serverID] = seconds
+		playtime.Total += seconds
+	}
+
+	return playtime, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Playtime fetched",
+	}
+}
+
+// CloseAbandonedSessions closes sessions which were left open the last time Refractor stopped. It should be called on
+// startup before any RCON clients are created. Abandoned sessions are closed at the time their player was last seen.
+func (s *sessionService) CloseAbandonedSessions() {
+	closed, err := s.repo.CloseAbandoned()
+	if err != nil {
+		s.log.Error("Could not close abandoned player sessions. Error: %v", err)
+		return
+	}
+
+	if closed > 0 {
+		s.log.Info("Closed %d abandoned player sessions", closed)
+	}
+}
+
+func (s *sessionService) OnPlayerJoin(fields broadcast.Fields, serverID int64, gameConfig *refractor.GameConfig) {
+	player := s.getPlayer(fields[gameConfig.PlayerGameIDField], gameConfig)
+	if player == nil {
+		return
+	}
+
+	now := time.Now().Unix()
+
+	// A missed quit could have left a session open on this server. Close it before opening a new one.
+	s.closePlayerSessions(serverID, player.PlayerID, now)
+
+	s.openSession(player.PlayerID, serverID, fields["Name"], now)
+}
+
+func (s *sessionService) OnPlayerQuit(fields broadcast.Fields, serverID int64, gameConfig *refractor.GameConfig) {
+	player := s.getPlayer(fields[gameConfig.PlayerGameIDField], gameConfig)
+	if player == nil {
+		return
+	}
+
+	s.closePlayerSessions(serverID, player.PlayerID, time.Now().Unix())
+}
+
+// OnServerOffline closes all open sessions on a server since we can no longer tell who is online.
+func (s *sessionService) OnServerOffline(serverID int64) {
+	openSessions, err := s.repo.FindOpenByServerID(serverID)
+	if err != nil && err != refractor.ErrNotFound {
+		s.log.Error("Could not get open sessions on server ID %d. Error: %v", serverID, err)
+		return
+	}
+
+	now := time.Now().Unix()
+
+	for _, session := range openSessions {
+		s.closeSession(session, now)
+	}
+}
+
+// OnPlayerListUpdate brings the open sessions of a server in line with its current player list. Sessions of players
+// who are no longer online are closed, players who are online without an open session get a new one, and the rest
+// have their last seen time refreshed.
+func (s *sessionService) OnPlayerListUpdate(serverID int64, gameConfig *refractor.GameConfig, players []*refractor.Player) {
+	openSessions, err := s.repo.FindOpenByServerID(serverID)
+	if err != nil && err != refractor.ErrNotFound {
+		s.log.Error("Could not get open sessions on server ID %d. Error: %v", serverID, err)
+		return
+	}
+
+	onlinePlayers := map[int64]*refractor.Player{}
+	for _, player := range players {
+		onlinePlayers[player.PlayerID] = player
+	}
+
+	now := time.Now().Unix()
+
+	for _, session := range openSessions {
+		if onlinePlayers[session.PlayerID] == nil {
+			s.closeSession(session, now)
+			continue
+		}
+
+		if err := s.repo.Update(session.SessionID, refractor.UpdateArgs{
+			"LastSeenAt": now,
+		}); err != nil {
+			s.log.Error("Could not update last seen time of session ID %d. Error: %v", session.SessionID, err)
+		}
+
+		delete(onlinePlayers, session.PlayerID)
+	}
+
+	// Any players left over are online but had no open session, most likely because their join was missed
+	for _, player := range onlinePlayers {
+		s.openSession(player.PlayerID, serverID, player.CurrentName, now)
+	}
+}
+
+func (s *sessionService) getPlayer(playerGameID string, gameConfig *refractor.GameConfig) *refractor.Player {
+	player, res := s.playerService.GetPlayer(refractor.FindArgs{
+		gameConfig.PlayerGameIDField: playerGameID,
+	})
+
+	if player == nil {
+		if res.Success {
+			s.log.Warn("Could not find player with %s of %s to track their session", gameConfig.PlayerGameIDField,
+				playerGameID)
+		}
+
+		return nil
+	}
+
+	return player
+}
+
+func (s *sessionService) openSession(playerID int64, serverID int64, name string, startedAt int64) {
+	newSession := &refractor.PlayerSession{
+		PlayerID:   playerID,
+		ServerID:   serverID,
+		Name:       name,
+		StartedAt:  startedAt,
+		LastSeenAt: startedAt,
+	}
+
+	if err := s.repo.Create(newSession); err != nil {
+		s.log.Error("Could not create session for player ID %d on server ID %d. Error: %v", playerID, serverID, err)
+	}
+}
+
+func (s *sessionService) closePlayerSessions(serverID int64, playerID int64, endedAt int64) {
+	openSessions, err := s.repo.FindOpenByServerID(serverID)
+	if err != nil && err != refractor.ErrNotFound {
+		s.log.Error("Could not get open sessions on server ID %d. Error: %v", serverID, err)
+		return
+	}
+
+	for _, session := range openSessions {
+		if session.PlayerID == playerID {
+			s.closeSession(session, endedAt)
+		}
+	}
+}
+
+func (s *sessionService) closeSession(session *refractor.PlayerSession, endedAt int64) {
+	if err := s.repo.Update(session.SessionID, refractor.UpdateArgs{
+		"EndedAt": endedAt,
+	}); err != nil {
+		s.log.Error("Could not close session ID %d. Error: %v", session.SessionID, err)
+	}
+}
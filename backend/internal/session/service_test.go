@@ -0,0 +1,199 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package session
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/internal/mock"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/internal/player"
+	"github.com/sniddunc/refractor/pkg/broadcast"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var testGameConfig = mock.NewMockGame().GetConfig()
+
+func Test_sessionService_JoinQuit(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	playerService := player.NewPlayerService(mock.NewMockPlayerRepository(map[int64]*refractor.DBPlayer{
+		1: {
+			PlayerID:    1,
+			PlayFabID:   sql.NullString{String: "PLAYER1", Valid: true},
+			CurrentName: "PlayerOne",
+		},
+	}), testLogger)
+	sessionRepo := mock.NewMockSessionRepository(map[int64]*refractor.PlayerSession{})
+	sessionService := NewSessionService(sessionRepo, playerService, testLogger)
+
+	fields := broadcast.Fields{
+		"PlayFabID": "PLAYER1",
+		"Name":      "PlayerOneAlt",
+	}
+
+	sessionService.OnPlayerJoin(fields, 1, testGameConfig)
+
+	assert.Len(t, sessionRepo.Sessions, 1, "A session should have been opened")
+	assert.Equal(t, int64(1), sessionRepo.Sessions[1].PlayerID)
+	assert.Equal(t, "PlayerOneAlt", sessionRepo.Sessions[1].Name, "The session should record the name used")
+	assert.Zero(t, sessionRepo.Sessions[1].EndedAt, "The session should be open")
+
+	// A second join without a quit should close the first session before opening another
+	sessionService.OnPlayerJoin(fields, 1, testGameConfig)
+
+	assert.Len(t, sessionRepo.Sessions, 2)
+	assert.NotZero(t, sessionRepo.Sessions[1].EndedAt, "The first session should have been closed")
+	assert.Zero(t, sessionRepo.Sessions[2].EndedAt, "The second session should be open")
+
+	sessionService.OnPlayerQuit(fields, 1, testGameConfig)
+
+	assert.NotZero(t, sessionRepo.Sessions[2].EndedAt, "The second session should have been closed on quit")
+}
+
+func Test_sessionService_OnServerOffline(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	playerService := player.NewPlayerService(mock.NewMockPlayerRepository(map[int64]*refractor.DBPlayer{}), testLogger)
+	sessionRepo := mock.NewMockSessionRepository(map[int64]*refractor.PlayerSession{
+		1: {SessionID: 1, PlayerID: 1, ServerID: 1, StartedAt: 100},
+		2: {SessionID: 2, PlayerID: 2, ServerID: 1, StartedAt: 100},
+		3: {SessionID: 3, PlayerID: 1, ServerID: 2, StartedAt: 100},
+	})
+	sessionService := NewSessionService(sessionRepo, playerService, testLogger)
+
+	sessionService.OnServerOffline(1)
+
+	assert.NotZero(t, sessionRepo.Sessions[1].EndedAt, "Sessions on the offline server should be closed")
+	assert.NotZero(t, sessionRepo.Sessions[2].EndedAt, "Sessions on the offline server should be closed")
+	assert.Zero(t, sessionRepo.Sessions[3].EndedAt, "Sessions on other servers should be left open")
+}
+
+func Test_sessionService_OnPlayerListUpdate(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	playerService := player.NewPlayerService(mock.NewMockPlayerRepository(map[int64]*refractor.DBPlayer{}), testLogger)
+	sessionRepo := mock.NewMockSessionRepository(map[int64]*refractor.PlayerSession{
+		1: {SessionID: 1, PlayerID: 1, ServerID: 1, StartedAt: 100, LastSeenAt: 100},
+	})
+	sessionService := NewSessionService(sessionRepo, playerService, testLogger)
+
+	// Player 1 is gone and player 2 is online without a session
+	sessionService.OnPlayerListUpdate(1, testGameConfig, []*refractor.Player{
+		{PlayerID: 2, CurrentName: "PlayerTwo"},
+	})
+
+	assert.NotZero(t, sessionRepo.Sessions[1].EndedAt, "The session of the player who left should be closed")
+	assert.Len(t, sessionRepo.Sessions, 2, "A session should have been opened for the player who is online")
+	assert.Equal(t, int64(2), sessionRepo.Sessions[2].PlayerID)
+	assert.Equal(t, "PlayerTwo", sessionRepo.Sessions[2].Name)
+
+	// Player 2 is still online so their session should only be refreshed
+	sessionRepo.Sessions[2].LastSeenAt = 0
+
+	sessionService.OnPlayerListUpdate(1, testGameConfig, []*refractor.Player{
+		{PlayerID: 2, CurrentName: "PlayerTwo"},
+	})
+
+	assert.Len(t, sessionRepo.Sessions, 2, "No new session should have been opened")
+	assert.Zero(t, sessionRepo.Sessions[2].EndedAt, "The session should still be open")
+	assert.NotZero(t, sessionRepo.Sessions[2].LastSeenAt, "The last seen time should have been refreshed")
+}
+
+func Test_sessionService_CloseAbandonedSessions(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	playerService := player.NewPlayerService(mock.NewMockPlayerRepository(map[int64]*refractor.DBPlayer{}), testLogger)
+	sessionRepo := mock.NewMockSessionRepository(map[int64]*refractor.PlayerSession{
+		1: {SessionID: 1, PlayerID: 1, ServerID: 1, StartedAt: 100, LastSeenAt: 400},
+		2: {SessionID: 2, PlayerID: 2, ServerID: 1, StartedAt: 100, EndedAt: 200, LastSeenAt: 200},
+	})
+	sessionService := NewSessionService(sessionRepo, playerService, testLogger)
+
+	sessionService.CloseAbandonedSessions()
+
+	assert.Equal(t, int64(400), sessionRepo.Sessions[1].EndedAt, "Abandoned sessions should end when the player was last seen")
+	assert.Equal(t, int64(200), sessionRepo.Sessions[2].EndedAt, "Closed sessions should not be changed")
+}
+
+func Test_sessionService_GetPlayerPlaytime(t *testing.T) {
+	now := time.Now().Unix()
+
+	testLogger, _ := log.NewLogger(true, false)
+
+	playerService := player.NewPlayerService(mock.NewMockPlayerRepository(map[int64]*refractor.DBPlayer{
+		1: {
+			PlayerID:    1,
+			PlayFabID:   sql.NullString{String: "PLAYER1", Valid: true},
+			CurrentName: "PlayerOne",
+		},
+	}), testLogger)
+	sessionRepo := mock.NewMockSessionRepository(map[int64]*refractor.PlayerSession{
+		1: {SessionID: 1, PlayerID: 1, ServerID: 1, StartedAt: 100, EndedAt: 400},
+		2: {SessionID: 2, PlayerID: 1, ServerID: 1, StartedAt: 1000, EndedAt: 1100},
+		3: {SessionID: 3, PlayerID: 1, ServerID: 2, StartedAt: 2000, EndedAt: 2050},
+		4: {SessionID: 4, PlayerID: 2, ServerID: 1, StartedAt: 100, EndedAt: 5000},
+	})
+	sessionService := NewSessionService(sessionRepo, playerService, testLogger)
+
+	playtime, res := sessionService.GetPlayerPlaytime(1)
+
+	assert.True(t, res.Success, "GetPlayerPlaytime should succeed. Message: %s", res.Message)
+	assert.Equal(t, int64(450), playtime.Total)
+	assert.Equal(t, map[int64]int64{1: 400, 2: 50}, playtime.Servers)
+
+	// Open sessions count up to the current time
+	sessionRepo = mock.NewMockSessionRepository(map[int64]*refractor.PlayerSession{
+		1: {SessionID: 1, PlayerID: 1, ServerID: 1, StartedAt: now - 60},
+	})
+	sessionService = NewSessionService(sessionRepo, playerService, testLogger)
+
+	playtime, _ = sessionService.GetPlayerPlaytime(1)
+	assert.GreaterOrEqual(t, playtime.Total, int64(60))
+}
+
+func Test_sessionService_GetPlayerSessions(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	playerService := player.NewPlayerService(mock.NewMockPlayerRepository(map[int64]*refractor.DBPlayer{
+		1: {
+			PlayerID:    1,
+			PlayFabID:   sql.NullString{String: "PLAYER1", Valid: true},
+			CurrentName: "PlayerOne",
+		},
+	}), testLogger)
+	sessionRepo := mock.NewMockSessionRepository(map[int64]*refractor.PlayerSession{
+		1: {SessionID: 1, PlayerID: 1, ServerID: 1, StartedAt: 100, EndedAt: 400},
+		2: {SessionID: 2, PlayerID: 1, ServerID: 1, StartedAt: 1000, EndedAt: 1100},
+		3: {SessionID: 3, PlayerID: 1, ServerID: 2, StartedAt: 2000, EndedAt: 2050},
+	})
+	sessionService := NewSessionService(sessionRepo, playerService, testLogger)
+
+	count, sessions, res := sessionService.GetPlayerSessions(1, params.SearchParams{Offset: 1, Limit: 1})
+
+	assert.True(t, res.Success, "GetPlayerSessions should succeed. Message: %s", res.Message)
+	assert.Equal(t, 3, count, "Count should include all of the player's sessions")
+	assert.Len(t, sessions, 1)
+	assert.Equal(t, int64(2), sessions[0].SessionID, "Sessions should be ordered newest first")
+
+	_, _, res = sessionService.GetPlayerSessions(3, params.SearchParams{Limit: 10})
+	assert.False(t, res.Success, "Sessions of players who don't exist should not be fetched")
+}
@@ -0,0 +1,361 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package spam
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/broadcast"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// infractionReasons holds the reason used for infractions created for each type of spam
+var infractionReasons = map[string]string{
+	refractor.SPAM_REASON_FLOOD:  "Spamming chat (sending messages too quickly)",
+	refractor.SPAM_REASON_REPEAT: "Spamming chat (repeating the same message)",
+	refractor.SPAM_REASON_CAPS:   "Excessive use of caps in chat",
+}
+
+type spamService struct {
+	repo              refractor.SpamSettingsRepository
+	serverService     refractor.ServerService
+	infractionService refractor.InfractionService
+	playerService     refractor.PlayerService
+	websocketService  refractor.WebsocketService
+	systemUserID      int64
+	log               log.Logger
+
+	// settings caches each server's spam settings since they are needed for every chat message. trackers holds the
+	// recent chat activity of each player by server ID and then player game ID. strikes holds the time each player
+	// was last caught spamming by player game ID. Strikes are not cleared when a player leaves so that leaving and
+	// rejoining doesn't reset their escalation.
+	settings map[int64]*refractor.SpamSettings
+	trackers map[int64]map[string]*playerTracker
+	strikes  map[string]time.Time
+	lock     sync.Mutex
+}
+
+// NewSpamService creates a new spam service. Infractions created for spamming players are attributed to the user with
+// the ID systemUserID.
+func NewSpamService(repo refractor.SpamSettingsRepository, serverService refractor.ServerService,
+	infractionService refractor.InfractionService, playerService refractor.PlayerService,
+	websocketService refractor.WebsocketService, systemUserID int64, log log.Logger) refractor.SpamService {
+	return &spamService{
+		repo:              repo,
+		serverService:     serverService,
+		infractionService: infractionService,
+		playerService:     playerService,
+		websocketService:  websocketService,
+		systemUserID:      systemUserID,
+		log:               log,
+		settings:          map[int64]*refractor.SpamSettings{},
+		trackers:          map[int64]map[string]*playerTracker{},
+		strikes:           map[string]time.Time{},
+	}
+}
+
+// getDefaultSettings returns the settings used for servers which have not had their spam settings changed. Spam
+// detection is disabled by default since it takes action against players automatically.
+func getDefaultSettings(serverID int64) *refractor.SpamSettings {
+	return &refractor.SpamSettings{
+		ServerID:      serverID,
+		Enabled:       false,
+		MessageLimit:  config.SpamDefaultMessageLimit,
+		MessageWindow: config.SpamDefaultMessageWindow,
+		RepeatLimit:   config.SpamDefaultRepeatLimit,
+		CapsPercent:   config.SpamDefaultCapsPercent,
+		CapsMinLength: config.SpamDefaultCapsMinLength,
+		MuteDuration:  config.SpamDefaultMuteDuration,
+	}
+}
+
+func (s *spamService) GetServerSettings(serverID int64) (*refractor.SpamSettings, *refractor.ServiceResponse) {
+	if server, _ := s.serverService.GetServerByID(serverID); server == nil {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    config.MessageInvalidIDProvided,
+		}
+	}
+
+	settings, err := s.getSettings(serverID)
+	if err != nil {
+		s.log.Error("Could not get spam settings for server ID %d. Error: %v", serverID, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	return settings, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Fetched spam settings",
+	}
+}
+
+func (s *spamService) UpdateServerSettings(serverID int64, body params.UpdateSpamSettingsParams) (*refractor.SpamSettings, *refractor.ServiceResponse) {
+	if server, _ := s.serverService.GetServerByID(serverID); server == nil {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    config.MessageInvalidIDProvided,
+		}
+	}
+
+	updateArgs := refractor.UpdateArgs{}
+
+	if body.Enabled != nil {
+		updateArgs["Enabled"] = *body.Enabled
+	}
+
+	if body.MessageLimit != nil {
+		updateArgs["MessageLimit"] = *body.MessageLimit
+	}
+
+	if body.MessageWindow != nil {
+		updateArgs["MessageWindow"] = *body.MessageWindow
+	}
+
+	if body.RepeatLimit != nil {
+		updateArgs["RepeatLimit"] = *body.RepeatLimit
+	}
+
+	if body.CapsPercent != nil {
+		updateArgs["CapsPercent"] = *body.CapsPercent
+	}
+
+	if body.CapsMinLength != nil {
+		updateArgs["CapsMinLength"] = *body.CapsMinLength
+	}
+
+	if body.MuteDuration != nil {
+		updateArgs["MuteDuration"] = *body.MuteDuration
+	}
+
+	if len(updateArgs) < 1 {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    "No updated values provided",
+		}
+	}
+
+	// Servers using the default settings don't have any stored settings yet so they are created first
+	if _, err := s.repo.FindByServerID(serverID); err != nil {
+		if err != refractor.ErrNotFound {
+			s.log.Error("Could not get spam settings for server ID %d. Error: %v", serverID, err)
+			return nil, refractor.InternalErrorResponse
+		}
+
+		if err := s.repo.Create(getDefaultSettings(serverID)); err != nil {
+			s.log.Error("Could not create spam settings for server ID %d. Error: %v", serverID, err)
+			return nil, refractor.InternalErrorResponse
+		}
+	}
+
+	updatedSettings, err := s.repo.Update(serverID, updateArgs)
+	if err != nil {
+		s.log.Error("Could not update spam settings for server ID %d. Error: %v", serverID, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	s.lock.Lock()
+	s.settings[serverID] = updatedSettings
+	s.lock.Unlock()
+
+	return updatedSettings, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Spam settings updated",
+	}
+}
+
+// getSettings returns a server's spam settings from the cache, loading them from the repository if they aren't cached.
+func (s *spamService) getSettings(serverID int64) (*refractor.SpamSettings, error) {
+	s.lock.Lock()
+	settings := s.settings[serverID]
+	s.lock.Unlock()
+
+	if settings != nil {
+		return settings, nil
+	}
+
+	settings, err := s.repo.FindByServerID(serverID)
+	if err != nil {
+		if err != refractor.ErrNotFound {
+			return nil, err
+		}
+
+		settings = getDefaultSettings(serverID)
+	}
+
+	s.lock.Lock()
+	s.settings[serverID] = settings
+	s.lock.Unlock()
+
+	return settings, nil
+}
+
+// OnChatReceive checks a chat message for spam. If spam is detected, the player is warned. If they were already warned
+// for spamming within config.SpamStrikeExpiry they are muted instead. Staff are notified over websocket of
+// every detection.
+func (s *spamService) OnChatReceive(msgBody *refractor.ChatReceiveBody, serverID int64, gameConfig *refractor.GameConfig) {
+	if msgBody.SentByUser || msgBody.PlayerGameID == "" {
+		return
+	}
+
+	settings, err := s.getSettings(serverID)
+	if err != nil {
+		s.log.Error("Could not get spam settings for server ID %d. Error: %v", serverID, err)
+		return
+	}
+
+	if !settings.Enabled {
+		return
+	}
+
+	now := time.Now()
+
+	s.lock.Lock()
+	tracker := s.getTracker(serverID, msgBody.PlayerGameID)
+	reason, detail := tracker.check(msgBody.Message, settings, now)
+
+	var actionType string
+	if reason != "" {
+		// Start counting from scratch so that the messages which were just caught don't trigger detection again
+		tracker.reset()
+		actionType = s.addStrike(msgBody.PlayerGameID, now)
+	}
+	s.lock.Unlock()
+
+	if reason == "" {
+		return
+	}
+
+	detection := &refractor.SpamDetection{
+		ServerID: serverID,
+		Name:     msgBody.Name,
+		Message:  msgBody.Message,
+		Reason:   reason,
+		Detail:   detail,
+	}
+
+	player, _ := s.playerService.GetPlayer(refractor.FindArgs{
+		gameConfig.PlayerGameIDField: msgBody.PlayerGameID,
+	})
+
+	if player != nil {
+		detection.PlayerID = player.PlayerID
+		detection.ActionType = actionType
+		detection.InfractionID = s.createInfraction(player.PlayerID, serverID, actionType, reason, settings)
+	} else {
+		s.log.Warn("Spam was detected from the player with %s of %s but they could not be found",
+			gameConfig.PlayerGameIDField, msgBody.PlayerGameID)
+	}
+
+	s.websocketService.Broadcast(&refractor.WebsocketMessage{
+		Type: "chat-spam-detected",
+		Body: detection,
+	})
+}
+
+// OnPlayerQuit stops tracking the chat activity of a player who left a server.
+func (s *spamService) OnPlayerQuit(fields broadcast.Fields, serverID int64, gameConfig *refractor.GameConfig) {
+	playerGameID := fields[gameConfig.PlayerGameIDField]
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.trackers[serverID] != nil {
+		delete(s.trackers[serverID], playerGameID)
+	}
+
+	if strike, ok := s.strikes[playerGameID]; ok && time.Since(strike) > config.SpamStrikeExpiry {
+		delete(s.strikes, playerGameID)
+	}
+}
+
+// OnServerOffline stops tracking the chat activity of all players on a server which went offline.
+func (s *spamService) OnServerOffline(serverID int64) {
+	s.lock.Lock()
+	delete(s.trackers, serverID)
+	s.lock.Unlock()
+}
+
+// getTracker returns the tracker for a player on a server, creating it if it doesn't exist. The caller must hold the
+// service's lock.
+func (s *spamService) getTracker(serverID int64, playerGameID string) *playerTracker {
+	serverTrackers := s.trackers[serverID]
+	if serverTrackers == nil {
+		serverTrackers = map[string]*playerTracker{}
+		s.trackers[serverID] = serverTrackers
+	}
+
+	tracker := serverTrackers[playerGameID]
+	if tracker == nil {
+		tracker = &playerTracker{}
+		serverTrackers[playerGameID] = tracker
+	}
+
+	return tracker
+}
+
+// addStrike records that a player was caught spamming and returns the infraction type they should receive. The caller
+// must hold the service's lock.
+func (s *spamService) addStrike(playerGameID string, now time.Time) string {
+	actionType := refractor.INFRACTION_TYPE_WARNING
+
+	if lastStrike, ok := s.strikes[playerGameID]; ok && now.Sub(lastStrike) <= config.SpamStrikeExpiry {
+		actionType = refractor.INFRACTION_TYPE_MUTE
+	}
+
+	s.strikes[playerGameID] = now
+
+	return actionType
+}
+
+// createInfraction creates and enforces an infraction for a spamming player. It returns the ID of the new infraction
+// or 0 if it could not be created.
+func (s *spamService) createInfraction(playerID int64, serverID int64, actionType string, reason string,
+	settings *refractor.SpamSettings) int64 {
+	newInfraction := &refractor.DBInfraction{
+		PlayerID: playerID,
+		UserID:   s.systemUserID,
+		ServerID: serverID,
+		Type:     actionType,
+		Reason:   sql.NullString{String: infractionReasons[reason], Valid: true},
+	}
+
+	if actionType == refractor.INFRACTION_TYPE_MUTE {
+		newInfraction.Duration = sql.NullInt32{Int32: int32(settings.MuteDuration), Valid: true}
+	}
+
+	infraction, res := s.infractionService.CreateSystemInfraction(newInfraction, true)
+	if !res.Success {
+		s.log.Error("Could not create spam infraction for player ID %d. Message: %s", playerID, res.Message)
+		return 0
+	}
+
+	s.log.Info("Spam detection created infraction ID %d for player ID %d (%s)", infraction.InfractionID, playerID,
+		reason)
+
+	return infraction.InfractionID
+}
@@ -0,0 +1,442 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package spam
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/internal/game"
+	"github.com/sniddunc/refractor/internal/infraction"
+	"github.com/sniddunc/refractor/internal/mock"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/internal/player"
+	"github.com/sniddunc/refractor/internal/server"
+	"github.com/sniddunc/refractor/internal/user"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_spamService_OnChatReceive(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	type fields struct {
+		mockSettings map[int64]*refractor.SpamSettings
+		mockPlayers  map[int64]*refractor.DBPlayer
+		mockServers  map[int64]*refractor.Server
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		playerGameID   string
+		messages       []string
+		wantReasons    []string
+		wantInfraction []string
+	}{
+		{
+			name: "spam.onchatreceive.1",
+			fields: fields{
+				mockSettings: map[int64]*refractor.SpamSettings{
+					1: {
+						ServerID:      1,
+						Enabled:       true,
+						MessageLimit:  4,
+						MessageWindow: 10,
+						RepeatLimit:   3,
+						CapsPercent:   80,
+						CapsMinLength: 10,
+						MuteDuration:  15,
+					},
+				},
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+				},
+			},
+			playerGameID: "ABCDEF",
+			messages:     []string{"hello", "how is everyone", "good game"},
+			wantReasons:  nil,
+		},
+		{
+			name: "spam.onchatreceive.2",
+			fields: fields{
+				mockSettings: map[int64]*refractor.SpamSettings{
+					1: {
+						ServerID:      1,
+						Enabled:       true,
+						MessageLimit:  4,
+						MessageWindow: 10,
+						RepeatLimit:   3,
+						CapsPercent:   80,
+						CapsMinLength: 10,
+						MuteDuration:  15,
+					},
+				},
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+				},
+			},
+			playerGameID:   "ABCDEF",
+			messages:       []string{"one", "two", "three", "four", "five"},
+			wantReasons:    []string{refractor.SPAM_REASON_FLOOD},
+			wantInfraction: []string{refractor.INFRACTION_TYPE_WARNING},
+		},
+		{
+			name: "spam.onchatreceive.3",
+			fields: fields{
+				mockSettings: map[int64]*refractor.SpamSettings{
+					1: {
+						ServerID:      1,
+						Enabled:       true,
+						MessageLimit:  4,
+						MessageWindow: 10,
+						RepeatLimit:   3,
+						CapsPercent:   80,
+						CapsMinLength: 10,
+						MuteDuration:  15,
+					},
+				},
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+				},
+			},
+			playerGameID:   "ABCDEF",
+			messages:       []string{"buy now", "Buy now", "BUY NOW "},
+			wantReasons:    []string{refractor.SPAM_REASON_REPEAT},
+			wantInfraction: []string{refractor.INFRACTION_TYPE_WARNING},
+		},
+		{
+			name: "spam.onchatreceive.4",
+			fields: fields{
+				mockSettings: map[int64]*refractor.SpamSettings{
+					1: {
+						ServerID:      1,
+						Enabled:       true,
+						MessageLimit:  4,
+						MessageWindow: 10,
+						RepeatLimit:   3,
+						CapsPercent:   80,
+						CapsMinLength: 10,
+						MuteDuration:  15,
+					},
+				},
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+				},
+			},
+			playerGameID:   "ABCDEF",
+			messages:       []string{"WHY IS EVERYONE SO BAD", "OK", "STOP TEAMKILLING PLEASE"},
+			wantReasons:    []string{refractor.SPAM_REASON_CAPS, refractor.SPAM_REASON_CAPS},
+			wantInfraction: []string{refractor.INFRACTION_TYPE_WARNING, refractor.INFRACTION_TYPE_MUTE},
+		},
+		{
+			name: "spam.onchatreceive.5",
+			fields: fields{
+				mockSettings: map[int64]*refractor.SpamSettings{
+					1: {
+						ServerID:      1,
+						Enabled:       false,
+						MessageLimit:  1,
+						MessageWindow: 10,
+					},
+				},
+				mockPlayers: map[int64]*refractor.DBPlayer{
+					1: {
+						PlayerID:  1,
+						PlayFabID: sql.NullString{String: "ABCDEF", Valid: true},
+					},
+				},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+				},
+			},
+			playerGameID: "ABCDEF",
+			messages:     []string{"one", "two", "three"},
+			wantReasons:  nil,
+		},
+		{
+			name: "spam.onchatreceive.6",
+			fields: fields{
+				mockSettings: map[int64]*refractor.SpamSettings{
+					1: {
+						ServerID:      1,
+						Enabled:       true,
+						MessageLimit:  4,
+						MessageWindow: 10,
+						RepeatLimit:   3,
+						CapsPercent:   80,
+						CapsMinLength: 10,
+						MuteDuration:  15,
+					},
+				},
+				mockPlayers: map[int64]*refractor.DBPlayer{},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+				},
+			},
+			playerGameID: "UNKNOWN",
+			messages:     []string{"spam", "spam", "spam"},
+			wantReasons:  []string{refractor.SPAM_REASON_REPEAT},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPlayerRepo := mock.NewMockPlayerRepository(tt.fields.mockPlayers)
+			playerService := player.NewPlayerService(mockPlayerRepo, testLogger)
+			mockServerRepo := mock.NewMockServerRepository(tt.fields.mockServers)
+			serverService := server.NewServerService(mockServerRepo, nil, testLogger)
+			userService := user.NewUserService(mock.NewMockUserRepository(mock.GetMockUsers()), testLogger)
+			gameService := game.NewGameService()
+			gameService.AddGame(mock.NewMockGame())
+			mockInfractions := map[int64]*refractor.DBInfraction{}
+			mockInfractionRepo := mock.NewMockInfractionRepository(mockInfractions)
+			infractionService := infraction.NewInfractionService(mockInfractionRepo, playerService, serverService,
+				userService, mock.NewMockRCONService(1), gameService, testLogger)
+			websocketService := mock.NewMockWebsocketService()
+			mockSettingsRepo := mock.NewMockSpamSettingsRepository(tt.fields.mockSettings)
+			spamService := NewSpamService(mockSettingsRepo, serverService, infractionService, playerService,
+				websocketService, 1, testLogger)
+
+			for _, message := range tt.messages {
+				spamService.OnChatReceive(&refractor.ChatReceiveBody{
+					ServerID:     1,
+					PlayerGameID: tt.playerGameID,
+					Name:         "Player",
+					Message:      message,
+				}, 1, mock.NewMockGame().GetConfig())
+			}
+
+			var reasons []string
+			for _, message := range websocketService.Messages {
+				assert.Equal(t, "chat-spam-detected", message.Type, "Websocket message type should match")
+				reasons = append(reasons, message.Body.(*refractor.SpamDetection).Reason)
+			}
+
+			assert.Equal(t, tt.wantReasons, reasons, "Detected spam reasons should match")
+
+			var infractionTypes []string
+			for id := int64(1); id <= int64(len(mockInfractions)); id++ {
+				created := mockInfractions[id]
+
+				assert.True(t, created.SystemAction, "Spam infraction should be a system action")
+				infractionTypes = append(infractionTypes, created.Type)
+			}
+
+			assert.Equal(t, tt.wantInfraction, infractionTypes, "Created infraction types should match")
+		})
+	}
+}
+
+func Test_spamService_UpdateServerSettings(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+	intPtr := func(i int) *int { return &i }
+	boolPtr := func(b bool) *bool { return &b }
+
+	type fields struct {
+		mockSettings map[int64]*refractor.SpamSettings
+		mockServers  map[int64]*refractor.Server
+	}
+	tests := []struct {
+		name         string
+		fields       fields
+		serverID     int64
+		body         params.UpdateSpamSettingsParams
+		wantSuccess  bool
+		wantSettings *refractor.SpamSettings
+	}{
+		{
+			name: "spam.updateserversettings.1",
+			fields: fields{
+				mockSettings: map[int64]*refractor.SpamSettings{
+					1: {
+						ServerID:      1,
+						Enabled:       false,
+						MessageLimit:  4,
+						MessageWindow: 10,
+						RepeatLimit:   3,
+						CapsPercent:   0,
+						CapsMinLength: 10,
+						MuteDuration:  15,
+					},
+				},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+					2: {
+						ServerID: 2,
+						Game:     "TestGame",
+					},
+				},
+			},
+			serverID: 1,
+			body: params.UpdateSpamSettingsParams{
+				Enabled:     boolPtr(true),
+				RepeatLimit: intPtr(5),
+			},
+			wantSuccess: true,
+			wantSettings: &refractor.SpamSettings{
+				ServerID:      1,
+				Enabled:       true,
+				MessageLimit:  4,
+				MessageWindow: 10,
+				RepeatLimit:   5,
+				CapsPercent:   0,
+				CapsMinLength: 10,
+				MuteDuration:  15,
+			},
+		},
+		{
+			name: "spam.updateserversettings.2",
+			fields: fields{
+				mockSettings: map[int64]*refractor.SpamSettings{},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+					2: {
+						ServerID: 2,
+						Game:     "TestGame",
+					},
+				},
+			},
+			serverID: 2,
+			body: params.UpdateSpamSettingsParams{
+				Enabled: boolPtr(true),
+			},
+			wantSuccess: true,
+			wantSettings: func() *refractor.SpamSettings {
+				settings := getDefaultSettings(2)
+				settings.Enabled = true
+				return settings
+			}(),
+		},
+		{
+			name: "spam.updateserversettings.3",
+			fields: fields{
+				mockSettings: map[int64]*refractor.SpamSettings{},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+					2: {
+						ServerID: 2,
+						Game:     "TestGame",
+					},
+				},
+			},
+			serverID: 3,
+			body: params.UpdateSpamSettingsParams{
+				Enabled: boolPtr(true),
+			},
+			wantSuccess: false,
+		},
+		{
+			name: "spam.updateserversettings.4",
+			fields: fields{
+				mockSettings: map[int64]*refractor.SpamSettings{
+					1: {
+						ServerID:      1,
+						Enabled:       false,
+						MessageLimit:  4,
+						MessageWindow: 10,
+						RepeatLimit:   3,
+						CapsPercent:   0,
+						CapsMinLength: 10,
+						MuteDuration:  15,
+					},
+				},
+				mockServers: map[int64]*refractor.Server{
+					1: {
+						ServerID: 1,
+						Game:     "TestGame",
+					},
+					2: {
+						ServerID: 2,
+						Game:     "TestGame",
+					},
+				},
+			},
+			serverID:    1,
+			body:        params.UpdateSpamSettingsParams{},
+			wantSuccess: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockServerRepo := mock.NewMockServerRepository(tt.fields.mockServers)
+			serverService := server.NewServerService(mockServerRepo, nil, testLogger)
+			mockSettingsRepo := mock.NewMockSpamSettingsRepository(tt.fields.mockSettings)
+			spamService := NewSpamService(mockSettingsRepo, serverService, nil, nil, nil, 1, testLogger)
+
+			settings, res := spamService.UpdateServerSettings(tt.serverID, tt.body)
+			assert.Equal(t, tt.wantSuccess, res.Success, "Update success should match. Message: %s", res.Message)
+
+			if tt.wantSuccess {
+				assert.Equal(t, tt.wantSettings, settings, "Updated settings should match")
+
+				fetched, _ := spamService.GetServerSettings(tt.serverID)
+				assert.Equal(t, tt.wantSettings, fetched, "Fetched settings should match the updated settings")
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package spam
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/refractor"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// playerTracker holds a player's recent chat activity on a server
+type playerTracker struct {
+	messageTimes []time.Time
+	lastMessage  string
+	repeatCount  int
+}
+
+// check records a new message and checks it against the server's spam settings. If spam is detected, one of the
+// SPAM_REASON_* values is returned along with a human readable description of what was detected. Otherwise, both
+// return values are empty.
+func (t *playerTracker) check(message string, settings *refractor.SpamSettings, now time.Time) (string, string) {
+	// Record the message's time and forget messages which are outside of the window
+	windowStart := now.Add(-time.Duration(settings.MessageWindow) * time.Second)
+
+	var recentTimes []time.Time
+	for _, messageTime := range t.messageTimes {
+		if messageTime.After(windowStart) {
+			recentTimes = append(recentTimes, messageTime)
+		}
+	}
+
+	t.messageTimes = append(recentTimes, now)
+
+	// Repeated messages are compared case insensitively so that changing the case doesn't get around the check
+	normalised := strings.ToLower(strings.TrimSpace(message))
+	if normalised == t.lastMessage {
+		t.repeatCount++
+	} else {
+		t.lastMessage = normalised
+		t.repeatCount = 1
+	}
+
+	if settings.RepeatLimit > 0 && t.repeatCount >= settings.RepeatLimit {
+		return refractor.SPAM_REASON_REPEAT, fmt.Sprintf("Sent the same message %d times in a row", t.repeatCount)
+	}
+
+	if settings.MessageLimit > 0 && len(t.messageTimes) > settings.MessageLimit {
+		return refractor.SPAM_REASON_FLOOD, fmt.Sprintf("Sent %d messages within %d seconds", len(t.messageTimes),
+			settings.MessageWindow)
+	}
+
+	percent, letters := getCapsPercent(message)
+	if settings.CapsPercent > 0 && letters >= settings.CapsMinLength && percent >= settings.CapsPercent {
+		return refractor.SPAM_REASON_CAPS, fmt.Sprintf("%d%% of the letters in the message were uppercase", percent)
+	}
+
+	return "", ""
+}
+
+// reset clears the tracked chat activity
+func (t *playerTracker) reset() {
+	t.messageTimes = nil
+	t.lastMessage = ""
+	t.repeatCount = 0
+}
+
+// getCapsPercent returns the percentage of letters in a message which are uppercase along with the number of letters.
+func getCapsPercent(message string) (int, int) {
+	letters := 0
+	upper := 0
+
+	for _, char := range message {
+		if !unicode.IsLetter(char) {
+			continue
+		}
+
+		letters++
+
+		if unicode.IsUpper(char) {
+			upper++
+		}
+	}
+
+	if letters == 0 {
+		return 0, 0
+	}
+
+	return upper * 100 / letters, letters
+}
@@ -0,0 +1,105 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package filesystem
+
+import (
+	"fmt"
+	"github.com/sniddunc/refractor/refractor"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type blobStore struct {
+	rootDir string
+}
+
+// NewBlobStore creates a blob store which keeps files in rootDir on the local filesystem. rootDir is created if it
+// does not exist.
+func NewBlobStore(rootDir string) (refractor.BlobStore, error) {
+	if err := os.MkdirAll(rootDir, 0750); err != nil {
+		return nil, err
+	}
+
+	return &blobStore{
+		rootDir: rootDir,
+	}, nil
+}
+
+func (s *blobStore) Put(key string, data io.Reader) (int64, error) {
+	path, err := s.getPath(key)
+	if err != nil {
+		return 0, err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0640)
+	if err != nil {
+		return 0, err
+	}
+
+	written, err := io.Copy(file, data)
+	if err != nil {
+		_ = file.Close()
+		_ = os.Remove(path)
+		return 0, err
+	}
+
+	return written, file.Close()
+}
+
+func (s *blobStore) Get(key string) (io.ReadCloser, error) {
+	path, err := s.getPath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, refractor.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (s *blobStore) Delete(key string) error {
+	path, err := s.getPath(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// getPath returns the path of the file stored under key. Keys containing path separators are rejected so that a key
+// can never point outside of the root directory.
+func (s *blobStore) getPath(key string) (string, error) {
+	if key == "" || key == "." || key == ".." || strings.ContainsAny(key, "/\\") {
+		return "", fmt.Errorf("invalid blob key: %s", key)
+	}
+
+	return filepath.Join(s.rootDir, key), nil
+}
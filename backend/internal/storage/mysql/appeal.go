@@ -0,0 +1,232 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mysql
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/refractor"
+	"time"
+)
+
+type appealRepo struct {
+	db *sql.DB
+}
+
+func NewAppealRepository(db *sql.DB) refractor.AppealRepository {
+	return &appealRepo{
+		db: db,
+	}
+}
+
+func (r *appealRepo) Create(appeal *refractor.DBAppeal) (*refractor.Appeal, error) {
+	if appeal.CreatedAt == 0 {
+		appeal.CreatedAt = time.Now().Unix()
+	}
+
+	if appeal.UpdatedAt == 0 {
+		appeal.UpdatedAt = appeal.CreatedAt
+	}
+
+	query := `
+		INSERT INTO Appeals(InfractionID, PlayerID, UserID, ReviewerID, Status, Statement, DecisionNotes, CreatedAt,
+			UpdatedAt)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+	`
+
+	res, err := r.db.Exec(query, appeal.InfractionID, appeal.PlayerID, appeal.UserID, appeal.ReviewerID, appeal.Status,
+		appeal.Statement, appeal.DecisionNotes, appeal.CreatedAt, appeal.UpdatedAt)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	appeal.AppealID = id
+
+	return appeal.Appeal(), nil
+}
+
+func (r *appealRepo) FindByID(id int64) (*refractor.Appeal, error) {
+	query := "SELECT * FROM Appeals WHERE AppealID = ?;"
+	row := r.db.QueryRow(query, id)
+
+	foundAppeal := &refractor.DBAppeal{}
+	if err := r.scanRow(row, foundAppeal); err != nil {
+		return nil, wrapError(err)
+	}
+
+	return foundAppeal.Appeal(), nil
+}
+
+func (r *appealRepo) FindMany(args refractor.FindArgs) ([]*refractor.Appeal, error) {
+	query, values := buildFindQuery("Appeals", args)
+
+	rows, err := r.db.Query(query, values...)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	var foundAppeals []*refractor.Appeal
+
+	for rows.Next() {
+		appeal := &refractor.DBAppeal{}
+
+		if err := r.scanRows(rows, appeal); err != nil {
+			return nil, wrapError(err)
+		}
+
+		foundAppeals = append(foundAppeals, appeal.Appeal())
+	}
+
+	return foundAppeals, nil
+}
+
+// Update updates an appeal. UpdatedAt is set to the current time on every update.
+func (r *appealRepo) Update(id int64, args refractor.UpdateArgs) (*refractor.Appeal, error) {
+	args["UpdatedAt"] = time.Now().Unix()
+
+	query, values := buildUpdateQuery("Appeals", id, "AppealID", args)
+
+	_, err := r.db.Exec(query, values...)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	// Retrieve updated appeal
+	return r.FindByID(id)
+}
+
+func (r *appealRepo) Search(args refractor.FindArgs, limit int, offset int) (int, []*refractor.Appeal, error) {
+	query := `
+		SELECT * FROM Appeals
+		WHERE
+			(? IS NULL OR Status = ?) AND
+			(? IS NULL OR PlayerID = ?) AND
+			(? IS NULL OR InfractionID = ?) AND
+			(? IS NULL OR ReviewerID = ?)
+		ORDER BY UpdatedAt DESC
+		LIMIT ? OFFSET ?;
+	`
+
+	var (
+		status       = args["Status"]
+		playerID     = args["PlayerID"]
+		infractionID = args["InfractionID"]
+		reviewerID   = args["ReviewerID"]
+	)
+
+	rows, err := r.db.Query(query, status, status, playerID, playerID, infractionID, infractionID, reviewerID,
+		reviewerID, limit, offset)
+	if err != nil {
+		return 0, nil, wrapError(err)
+	}
+
+	var foundAppeals []*refractor.Appeal
+
+	for rows.Next() {
+		appeal := &refractor.DBAppeal{}
+
+		if err := r.scanRows(rows, appeal); err != nil {
+			return 0, nil, wrapError(err)
+		}
+
+		foundAppeals = append(foundAppeals, appeal.Appeal())
+	}
+
+	// Get total number of matches
+	query = `
+		SELECT COUNT(1) AS Count FROM Appeals
+		WHERE
+			(? IS NULL OR Status = ?) AND
+			(? IS NULL OR PlayerID = ?) AND
+			(? IS NULL OR InfractionID = ?) AND
+			(? IS NULL OR ReviewerID = ?);
+	`
+
+	row := r.db.QueryRow(query, status, status, playerID, playerID, infractionID, infractionID, reviewerID, reviewerID)
+
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return 0, nil, wrapError(err)
+	}
+
+	return count, foundAppeals, nil
+}
+
+func (r *appealRepo) CreateEvent(event *refractor.AppealEvent) error {
+	if event.Timestamp == 0 {
+		event.Timestamp = time.Now().Unix()
+	}
+
+	query := "INSERT INTO AppealEvents(AppealID, UserID, Action, Status, Notes, Timestamp) VALUES (?, ?, ?, ?, ?, ?);"
+
+	res, err := r.db.Exec(query, event.AppealID, event.UserID, event.Action, event.Status, event.Notes, event.Timestamp)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	event.EventID = id
+
+	return nil
+}
+
+func (r *appealRepo) FindEvents(appealID int64) ([]*refractor.AppealEvent, error) {
+	query := "SELECT * FROM AppealEvents WHERE AppealID = ? ORDER BY Timestamp ASC, EventID ASC;"
+
+	rows, err := r.db.Query(query, appealID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	var foundEvents []*refractor.AppealEvent
+
+	for rows.Next() {
+		event := &refractor.AppealEvent{}
+
+		var notes sql.NullString
+		if err := rows.Scan(&event.EventID, &event.AppealID, &event.UserID, &event.Action, &event.Status, &notes,
+			&event.Timestamp); err != nil {
+			return nil, wrapError(err)
+		}
+
+		event.Notes = notes.String
+
+		foundEvents = append(foundEvents, event)
+	}
+
+	return foundEvents, nil
+}
+
+// Scan helpers
+func (r *appealRepo) scanRow(row *sql.Row, appeal *refractor.DBAppeal) error {
+	return row.Scan(&appeal.AppealID, &appeal.InfractionID, &appeal.PlayerID, &appeal.UserID, &appeal.ReviewerID,
+		&appeal.Status, &appeal.Statement, &appeal.DecisionNotes, &appeal.CreatedAt, &appeal.UpdatedAt)
+}
+
+func (r *appealRepo) scanRows(rows *sql.Rows, appeal *refractor.DBAppeal) error {
+	return rows.Scan(&appeal.AppealID, &appeal.InfractionID, &appeal.PlayerID, &appeal.UserID, &appeal.ReviewerID,
+		&appeal.Status, &appeal.Statement, &appeal.DecisionNotes, &appeal.CreatedAt, &appeal.UpdatedAt)
+}
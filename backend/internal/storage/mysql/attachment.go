@@ -0,0 +1,157 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mysql
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/refractor"
+	"time"
+)
+
+type attachmentRepo struct {
+	db *sql.DB
+}
+
+func NewAttachmentRepository(db *sql.DB) refractor.AttachmentRepository {
+	return &attachmentRepo{
+		db: db,
+	}
+}
+
+func (r *attachmentRepo) Create(attachment *refractor.Attachment) error {
+	if attachment.Timestamp == 0 {
+		attachment.Timestamp = time.Now().Unix()
+	}
+
+	query := `
+		INSERT INTO Attachments(InfractionID, UserID, FileName, ContentType, Size, StorageKey, Timestamp)
+		VALUES (?, ?, ?, ?, ?, ?, ?);
+	`
+
+	res, err := r.db.Exec(query, attachment.InfractionID, attachment.UserID, attachment.FileName,
+		attachment.ContentType, attachment.Size, attachment.StorageKey, attachment.Timestamp)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	attachment.AttachmentID = id
+
+	return nil
+}
+
+func (r *attachmentRepo) FindByID(id int64) (*refractor.Attachment, error) {
+	query := "SELECT * FROM Attachments WHERE AttachmentID = ?;"
+	row := r.db.QueryRow(query, id)
+
+	foundAttachment := &refractor.Attachment{}
+	if err := r.scanRow(row, foundAttachment); err != nil {
+		return nil, wrapError(err)
+	}
+
+	return foundAttachment, nil
+}
+
+func (r *attachmentRepo) FindManyByInfractionID(infractionID int64) ([]*refractor.Attachment, error) {
+	query := "SELECT * FROM Attachments WHERE InfractionID = ? ORDER BY Timestamp ASC;"
+
+	rows, err := r.db.Query(query, infractionID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	var foundAttachments []*refractor.Attachment
+
+	for rows.Next() {
+		attachment := &refractor.Attachment{}
+
+		if err := r.scanRows(rows, attachment); err != nil {
+			return nil, wrapError(err)
+		}
+
+		foundAttachments = append(foundAttachments, attachment)
+	}
+
+	return foundAttachments, nil
+}
+
+// CountByPlayerID returns the number of attachments on each of a player's infractions, keyed by infraction ID.
+// Infractions without attachments are not included.
+func (r *attachmentRepo) CountByPlayerID(playerID int64) (map[int64]int, error) {
+	query := `
+		SELECT a.InfractionID, COUNT(1) AS Count FROM Attachments a
+		JOIN Infractions i ON a.InfractionID = i.InfractionID
+		WHERE i.PlayerID = ?
+		GROUP BY a.InfractionID;
+	`
+
+	rows, err := r.db.Query(query, playerID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	counts := map[int64]int{}
+
+	for rows.Next() {
+		var infractionID int64
+		var count int
+
+		if err := rows.Scan(&infractionID, &count); err != nil {
+			return nil, wrapError(err)
+		}
+
+		counts[infractionID] = count
+	}
+
+	return counts, nil
+}
+
+func (r *attachmentRepo) Delete(id int64) error {
+	query := "DELETE FROM Attachments WHERE AttachmentID = ?;"
+
+	res, err := r.db.Exec(query, id)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	if rowsAffected <= 0 {
+		return wrapError(sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// Scan helpers
+func (r *attachmentRepo) scanRow(row *sql.Row, attachment *refractor.Attachment) error {
+	return row.Scan(&attachment.AttachmentID, &attachment.InfractionID, &attachment.UserID, &attachment.FileName,
+		&attachment.ContentType, &attachment.Size, &attachment.StorageKey, &attachment.Timestamp)
+}
+
+func (r *attachmentRepo) scanRows(rows *sql.Rows, attachment *refractor.Attachment) error {
+	return rows.Scan(&attachment.AttachmentID, &attachment.InfractionID, &attachment.UserID, &attachment.FileName,
+		&attachment.ContentType, &attachment.Size, &attachment.StorageKey, &attachment.Timestamp)
+}
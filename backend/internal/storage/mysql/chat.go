@@ -0,0 +1,144 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mysql
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/refractor"
+	"strings"
+	"time"
+)
+
+type chatRepo struct {
+	db *sql.DB
+}
+
+func NewChatRepository(db *sql.DB) refractor.ChatRepository {
+	return &chatRepo{
+		db: db,
+	}
+}
+
+func (r *chatRepo) Create(message *refractor.ChatMessage) error {
+	if message.Timestamp == 0 {
+		message.Timestamp = time.Now().Unix()
+	}
+
+	playerID := sql.NullInt64{Int64: message.PlayerID, Valid: message.PlayerID > 0}
+	userID := sql.NullInt64{Int64: message.UserID, Valid: message.UserID > 0}
+
+	query := `
+		INSERT INTO ChatMessages(ServerID, PlayerID, UserID, Name, Channel, Message, Timestamp)
+		VALUES (?, ?, ?, ?, ?, ?, ?);
+	`
+
+	res, err := r.db.Exec(query, message.ServerID, playerID, userID, message.Name, message.Channel, message.Message,
+		message.Timestamp)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	message.MessageID = id
+
+	return nil
+}
+
+// Search gets a page of chat messages matching the provided arguments, newest first, along with the total number of
+// matches. Supported arguments are PlayerID, ServerID, StartDate, EndDate and Query. Query matches any message
+// containing it.
+func (r *chatRepo) Search(args refractor.FindArgs, limit int, offset int) (int, []*refractor.ChatMessage, error) {
+	const filters = `
+		(? IS NULL OR PlayerID = ?) AND
+		(? IS NULL OR ServerID = ?) AND
+		(? IS NULL OR Timestamp >= ?) AND
+		(? IS NULL OR Timestamp <= ?) AND
+		(? IS NULL OR Message LIKE CONCAT('%', ?, '%'))
+	`
+
+	var (
+		playerID  = args["PlayerID"]
+		serverID  = args["ServerID"]
+		startDate = args["StartDate"]
+		endDate   = args["EndDate"]
+		pattern   interface{}
+	)
+
+	if query, ok := args["Query"].(string); ok {
+		pattern = escapeLikePattern(query)
+	}
+
+	filterValues := []interface{}{playerID, playerID, serverID, serverID, startDate, startDate, endDate, endDate,
+		pattern, pattern}
+
+	query := "SELECT * FROM ChatMessages WHERE " + filters + " ORDER BY Timestamp DESC, MessageID DESC LIMIT ? OFFSET ?;"
+
+	rows, err := r.db.Query(query, append(filterValues, limit, offset)...)
+	if err != nil {
+		return 0, nil, wrapError(err)
+	}
+
+	var foundMessages []*refractor.ChatMessage
+
+	for rows.Next() {
+		message := &refractor.ChatMessage{}
+
+		if err := r.scanRows(rows, message); err != nil {
+			return 0, nil, wrapError(err)
+		}
+
+		foundMessages = append(foundMessages, message)
+	}
+
+	// Get total number of matches
+	query = "SELECT COUNT(1) AS Count FROM ChatMessages WHERE " + filters + ";"
+
+	row := r.db.QueryRow(query, filterValues...)
+
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return 0, nil, wrapError(err)
+	}
+
+	return count, foundMessages, nil
+}
+
+// escapeLikePattern escapes the characters which have a special meaning in LIKE patterns
+func escapeLikePattern(pattern string) string {
+	return strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_").Replace(pattern)
+}
+
+// Scan helpers
+func (r *chatRepo) scanRows(rows *sql.Rows, message *refractor.ChatMessage) error {
+	var playerID, userID sql.NullInt64
+
+	if err := rows.Scan(&message.MessageID, &message.ServerID, &playerID, &userID, &message.Name, &message.Channel,
+		&message.Message, &message.Timestamp); err != nil {
+		return err
+	}
+
+	message.PlayerID = playerID.Int64
+	message.UserID = userID.Int64
+	message.SentByUser = userID.Valid
+
+	return nil
+}
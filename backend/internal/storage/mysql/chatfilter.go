@@ -0,0 +1,133 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mysql
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/refractor"
+)
+
+type chatFilterRuleRepo struct {
+	db *sql.DB
+}
+
+func NewChatFilterRuleRepository(db *sql.DB) refractor.ChatFilterRuleRepository {
+	return &chatFilterRuleRepo{
+		db: db,
+	}
+}
+
+func (r *chatFilterRuleRepo) Create(rule *refractor.ChatFilterRule) error {
+	query := `
+		INSERT INTO ChatFilterRules (Pattern, MatchType, Leetspeak, ActionType, ActionDuration, ActionReason, Enabled)
+		VALUES (?, ?, ?, ?, ?, ?, ?);
+	`
+
+	res, err := r.db.Exec(query, rule.Pattern, rule.MatchType, rule.Leetspeak, rule.ActionType, rule.ActionDuration,
+		rule.ActionReason, rule.Enabled)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	rule.RuleID = id
+
+	return nil
+}
+
+func (r *chatFilterRuleRepo) FindByID(id int64) (*refractor.ChatFilterRule, error) {
+	query := "SELECT * FROM ChatFilterRules WHERE RuleID = ?;"
+	row := r.db.QueryRow(query, id)
+
+	foundRule := &refractor.ChatFilterRule{}
+	if err := r.scanRow(row, foundRule); err != nil {
+		return nil, wrapError(err)
+	}
+
+	return foundRule, nil
+}
+
+func (r *chatFilterRuleRepo) FindAll() ([]*refractor.ChatFilterRule, error) {
+	query := "SELECT * FROM ChatFilterRules;"
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	var foundRules []*refractor.ChatFilterRule
+
+	for rows.Next() {
+		rule := &refractor.ChatFilterRule{}
+
+		if err := r.scanRows(rows, rule); err != nil {
+			return nil, wrapError(err)
+		}
+
+		foundRules = append(foundRules, rule)
+	}
+
+	return foundRules, nil
+}
+
+func (r *chatFilterRuleRepo) Update(id int64, args refractor.UpdateArgs) (*refractor.ChatFilterRule, error) {
+	query, values := buildUpdateQuery("ChatFilterRules", id, "RuleID", args)
+
+	_, err := r.db.Exec(query, values...)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	// Retrieve updated rule
+	return r.FindByID(id)
+}
+
+func (r *chatFilterRuleRepo) Delete(id int64) error {
+	query := "DELETE FROM ChatFilterRules WHERE RuleID = ?;"
+
+	res, err := r.db.Exec(query, id)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	if rowsAffected <= 0 {
+		return wrapError(sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// Scan helpers
+func (r *chatFilterRuleRepo) scanRow(row *sql.Row, rule *refractor.ChatFilterRule) error {
+	return row.Scan(&rule.RuleID, &rule.Pattern, &rule.MatchType, &rule.Leetspeak, &rule.ActionType,
+		&rule.ActionDuration, &rule.ActionReason, &rule.Enabled)
+}
+
+func (r *chatFilterRuleRepo) scanRows(rows *sql.Rows, rule *refractor.ChatFilterRule) error {
+	return rows.Scan(&rule.RuleID, &rule.Pattern, &rule.MatchType, &rule.Leetspeak, &rule.ActionType,
+		&rule.ActionDuration, &rule.ActionReason, &rule.Enabled)
+}
@@ -0,0 +1,110 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"github.com/sniddunc/refractor/refractor"
+)
+
+type chatSnapshotRepo struct {
+	db *sql.DB
+}
+
+func NewChatSnapshotRepository(db *sql.DB) refractor.ChatSnapshotRepository {
+	return &chatSnapshotRepo{
+		db: db,
+	}
+}
+
+// Create stores copies of the provided messages as the chat snapshot of an infraction in a single transaction.
+func (r *chatSnapshotRepo) Create(infractionID int64, messages []*refractor.ChatMessage) error {
+	tx, err := r.db.BeginTx(context.Background(), nil)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	query := `
+		INSERT INTO InfractionChatSnapshots(InfractionID, MessageID, ServerID, PlayerID, UserID, Name, Channel, Message,
+			Timestamp)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+	`
+
+	for _, message := range messages {
+		playerID := sql.NullInt64{Int64: message.PlayerID, Valid: message.PlayerID > 0}
+		userID := sql.NullInt64{Int64: message.UserID, Valid: message.UserID > 0}
+
+		if _, err := tx.Exec(query, infractionID, message.MessageID, message.ServerID, playerID, userID, message.Name,
+			message.Channel, message.Message, message.Timestamp); err != nil {
+			_ = tx.Rollback()
+			return wrapError(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return wrapError(err)
+	}
+
+	return nil
+}
+
+// FindByInfractionID gets the chat snapshot of an infraction, oldest message first.
+func (r *chatSnapshotRepo) FindByInfractionID(infractionID int64) ([]*refractor.ChatMessage, error) {
+	query := `
+		SELECT MessageID, ServerID, PlayerID, UserID, Name, Channel, Message, Timestamp
+		FROM InfractionChatSnapshots
+		WHERE InfractionID = ?
+		ORDER BY Timestamp ASC, MessageID ASC;
+	`
+
+	rows, err := r.db.Query(query, infractionID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	var foundMessages []*refractor.ChatMessage
+
+	for rows.Next() {
+		message := &refractor.ChatMessage{}
+
+		if err := r.scanRows(rows, message); err != nil {
+			return nil, wrapError(err)
+		}
+
+		foundMessages = append(foundMessages, message)
+	}
+
+	return foundMessages, nil
+}
+
+// Scan helpers
+func (r *chatSnapshotRepo) scanRows(rows *sql.Rows, message *refractor.ChatMessage) error {
+	var playerID, userID sql.NullInt64
+
+	if err := rows.Scan(&message.MessageID, &message.ServerID, &playerID, &userID, &message.Name, &message.Channel,
+		&message.Message, &message.Timestamp); err != nil {
+		return err
+	}
+
+	message.PlayerID = playerID.Int64
+	message.UserID = userID.Int64
+	message.SentByUser = userID.Valid
+
+	return nil
+}
@@ -0,0 +1,154 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mysql
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/refractor"
+	"time"
+)
+
+type commentRepo struct {
+	db *sql.DB
+}
+
+func NewCommentRepository(db *sql.DB) refractor.CommentRepository {
+	return &commentRepo{
+		db: db,
+	}
+}
+
+func (r *commentRepo) Create(comment *refractor.Comment) error {
+	if comment.Timestamp == 0 {
+		comment.Timestamp = time.Now().Unix()
+	}
+
+	parentID := sql.NullInt64{Int64: comment.ParentID, Valid: comment.ParentID > 0}
+
+	query := "INSERT INTO InfractionComments(InfractionID, ParentID, UserID, Body, Timestamp) VALUES (?, ?, ?, ?, ?);"
+
+	res, err := r.db.Exec(query, comment.InfractionID, parentID, comment.UserID, comment.Body, comment.Timestamp)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	comment.CommentID = id
+
+	return nil
+}
+
+func (r *commentRepo) FindByID(id int64) (*refractor.Comment, error) {
+	query := "SELECT * FROM InfractionComments WHERE CommentID = ?;"
+	row := r.db.QueryRow(query, id)
+
+	foundComment := &refractor.Comment{}
+	if err := r.scanRow(row, foundComment); err != nil {
+		return nil, wrapError(err)
+	}
+
+	return foundComment, nil
+}
+
+func (r *commentRepo) FindManyByInfractionID(infractionID int64) ([]*refractor.Comment, error) {
+	query := "SELECT * FROM InfractionComments WHERE InfractionID = ? ORDER BY Timestamp ASC, CommentID ASC;"
+
+	rows, err := r.db.Query(query, infractionID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	var foundComments []*refractor.Comment
+
+	for rows.Next() {
+		comment := &refractor.Comment{}
+
+		if err := r.scanRows(rows, comment); err != nil {
+			return nil, wrapError(err)
+		}
+
+		foundComments = append(foundComments, comment)
+	}
+
+	return foundComments, nil
+}
+
+func (r *commentRepo) Update(id int64, args refractor.UpdateArgs) (*refractor.Comment, error) {
+	query, values := buildUpdateQuery("InfractionComments", id, "CommentID", args)
+
+	_, err := r.db.Exec(query, values...)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	// Retrieve updated comment
+	return r.FindByID(id)
+}
+
+func (r *commentRepo) Delete(id int64) error {
+	query := "DELETE FROM InfractionComments WHERE CommentID = ?;"
+
+	res, err := r.db.Exec(query, id)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	if rowsAffected <= 0 {
+		return wrapError(sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// Scan helpers
+func (r *commentRepo) scanRow(row *sql.Row, comment *refractor.Comment) error {
+	var parentID, editedAt sql.NullInt64
+
+	if err := row.Scan(&comment.CommentID, &comment.InfractionID, &parentID, &comment.UserID, &comment.Body,
+		&comment.Timestamp, &editedAt); err != nil {
+		return err
+	}
+
+	comment.ParentID = parentID.Int64
+	comment.EditedAt = editedAt.Int64
+
+	return nil
+}
+
+func (r *commentRepo) scanRows(rows *sql.Rows, comment *refractor.Comment) error {
+	var parentID, editedAt sql.NullInt64
+
+	if err := rows.Scan(&comment.CommentID, &comment.InfractionID, &parentID, &comment.UserID, &comment.Body,
+		&comment.Timestamp, &editedAt); err != nil {
+		return err
+	}
+
+	comment.ParentID = parentID.Int64
+	comment.EditedAt = editedAt.Int64
+
+	return nil
+}
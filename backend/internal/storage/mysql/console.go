@@ -0,0 +1,162 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mysql
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/refractor"
+)
+
+type rconCommandRuleRepo struct {
+	db *sql.DB
+}
+
+func NewRCONCommandRuleRepository(db *sql.DB) refractor.RCONCommandRuleRepository {
+	return &rconCommandRuleRepo{
+		db: db,
+	}
+}
+
+func (r *rconCommandRuleRepo) Create(rule *refractor.RCONCommandRule) error {
+	query := "INSERT INTO RCONCommandRules (Role, Prefix, Type) VALUES (?, ?, ?);"
+
+	res, err := r.db.Exec(query, rule.Role, rule.Prefix, rule.Type)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	rule.RuleID = id
+
+	return nil
+}
+
+func (r *rconCommandRuleRepo) FindAll() ([]*refractor.RCONCommandRule, error) {
+	query := "SELECT * FROM RCONCommandRules;"
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	var foundRules []*refractor.RCONCommandRule
+
+	for rows.Next() {
+		rule := &refractor.RCONCommandRule{}
+
+		if err := rows.Scan(&rule.RuleID, &rule.Role, &rule.Prefix, &rule.Type); err != nil {
+			return nil, wrapError(err)
+		}
+
+		foundRules = append(foundRules, rule)
+	}
+
+	return foundRules, nil
+}
+
+func (r *rconCommandRuleRepo) Delete(id int64) error {
+	query := "DELETE FROM RCONCommandRules WHERE RuleID = ?;"
+
+	res, err := r.db.Exec(query, id)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	if rowsAffected <= 0 {
+		return wrapError(sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+type rconCommandLogRepo struct {
+	db *sql.DB
+}
+
+func NewRCONCommandLogRepository(db *sql.DB) refractor.RCONCommandLogRepository {
+	return &rconCommandLogRepo{
+		db: db,
+	}
+}
+
+func (r *rconCommandLogRepo) Create(log *refractor.RCONCommandLog) error {
+	query := `
+		INSERT INTO RCONCommandLogs (UserID, ServerID, Command, Output, Allowed, Success, Error, Timestamp)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+	`
+
+	res, err := r.db.Exec(query, log.UserID, log.ServerID, log.Command, log.Output, log.Allowed, log.Success, log.Error,
+		log.Timestamp)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	log.LogID = id
+
+	return nil
+}
+
+// FindManyByServerID gets a page of the commands run on a server, newest first, along with the total number of
+// commands run on it.
+func (r *rconCommandLogRepo) FindManyByServerID(serverID int64, limit int, offset int) (int, []*refractor.RCONCommandLog, error) {
+	query := "SELECT * FROM RCONCommandLogs WHERE ServerID = ? ORDER BY LogID DESC LIMIT ? OFFSET ?;"
+
+	rows, err := r.db.Query(query, serverID, limit, offset)
+	if err != nil {
+		return 0, nil, wrapError(err)
+	}
+
+	var foundLogs []*refractor.RCONCommandLog
+
+	for rows.Next() {
+		log := &refractor.RCONCommandLog{}
+
+		if err := rows.Scan(&log.LogID, &log.UserID, &log.ServerID, &log.Command, &log.Output, &log.Allowed,
+			&log.Success, &log.Error, &log.Timestamp); err != nil {
+			return 0, nil, wrapError(err)
+		}
+
+		foundLogs = append(foundLogs, log)
+	}
+
+	// Get total number of logged commands
+	query = "SELECT COUNT(1) AS Count FROM RCONCommandLogs WHERE ServerID = ?;"
+
+	row := r.db.QueryRow(query, serverID)
+
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return 0, nil, wrapError(err)
+	}
+
+	return count, foundLogs, nil
+}
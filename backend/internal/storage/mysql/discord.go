@@ -0,0 +1,130 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mysql
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/refractor"
+)
+
+type discordChannelRepo struct {
+	db *sql.DB
+}
+
+func NewDiscordChannelRepository(db *sql.DB) refractor.DiscordChannelRepository {
+	return &discordChannelRepo{
+		db: db,
+	}
+}
+
+func (r *discordChannelRepo) Create(channel *refractor.DiscordChannel) error {
+	query := "INSERT INTO DiscordChannels (Name, WebhookURL, InfractionType, ServerID, Enabled) VALUES (?, ?, ?, ?, ?);"
+
+	res, err := r.db.Exec(query, channel.Name, channel.WebhookURL, channel.InfractionType, channel.ServerID,
+		channel.Enabled)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	channel.ChannelID = id
+
+	return nil
+}
+
+func (r *discordChannelRepo) FindByID(id int64) (*refractor.DiscordChannel, error) {
+	query := "SELECT * FROM DiscordChannels WHERE ChannelID = ?;"
+	row := r.db.QueryRow(query, id)
+
+	foundChannel := &refractor.DiscordChannel{}
+	if err := r.scanRow(row, foundChannel); err != nil {
+		return nil, wrapError(err)
+	}
+
+	return foundChannel, nil
+}
+
+func (r *discordChannelRepo) FindAll() ([]*refractor.DiscordChannel, error) {
+	query := "SELECT * FROM DiscordChannels;"
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	var foundChannels []*refractor.DiscordChannel
+
+	for rows.Next() {
+		channel := &refractor.DiscordChannel{}
+
+		if err := r.scanRows(rows, channel); err != nil {
+			return nil, wrapError(err)
+		}
+
+		foundChannels = append(foundChannels, channel)
+	}
+
+	return foundChannels, nil
+}
+
+func (r *discordChannelRepo) Update(id int64, args refractor.UpdateArgs) (*refractor.DiscordChannel, error) {
+	query, values := buildUpdateQuery("DiscordChannels", id, "ChannelID", args)
+
+	_, err := r.db.Exec(query, values...)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	// Retrieve updated channel
+	return r.FindByID(id)
+}
+
+func (r *discordChannelRepo) Delete(id int64) error {
+	query := "DELETE FROM DiscordChannels WHERE ChannelID = ?;"
+
+	res, err := r.db.Exec(query, id)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	if rowsAffected <= 0 {
+		return wrapError(sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// Scan helpers
+func (r *discordChannelRepo) scanRow(row *sql.Row, channel *refractor.DiscordChannel) error {
+	return row.Scan(&channel.ChannelID, &channel.Name, &channel.WebhookURL, &channel.InfractionType, &channel.ServerID,
+		&channel.Enabled)
+}
+
+func (r *discordChannelRepo) scanRows(rows *sql.Rows, channel *refractor.DiscordChannel) error {
+	return rows.Scan(&channel.ChannelID, &channel.Name, &channel.WebhookURL, &channel.InfractionType, &channel.ServerID,
+		&channel.Enabled)
+}
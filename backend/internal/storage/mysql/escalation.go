@@ -0,0 +1,134 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mysql
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/refractor"
+)
+
+type escalationPolicyRepo struct {
+	db *sql.DB
+}
+
+func NewEscalationPolicyRepository(db *sql.DB) refractor.EscalationPolicyRepository {
+	return &escalationPolicyRepo{
+		db: db,
+	}
+}
+
+func (r *escalationPolicyRepo) Create(policy *refractor.EscalationPolicy) error {
+	query := `
+		INSERT INTO EscalationPolicies (Name, TriggerType, TriggerCount, TimeWindow, ActionType, ActionDuration,
+			ActionReason, Enabled)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+	`
+
+	res, err := r.db.Exec(query, policy.Name, policy.TriggerType, policy.TriggerCount, policy.TimeWindow,
+		policy.ActionType, policy.ActionDuration, policy.ActionReason, policy.Enabled)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	policy.PolicyID = id
+
+	return nil
+}
+
+func (r *escalationPolicyRepo) FindByID(id int64) (*refractor.EscalationPolicy, error) {
+	query := "SELECT * FROM EscalationPolicies WHERE PolicyID = ?;"
+	row := r.db.QueryRow(query, id)
+
+	foundPolicy := &refractor.EscalationPolicy{}
+	if err := r.scanRow(row, foundPolicy); err != nil {
+		return nil, wrapError(err)
+	}
+
+	return foundPolicy, nil
+}
+
+func (r *escalationPolicyRepo) FindAll() ([]*refractor.EscalationPolicy, error) {
+	query := "SELECT * FROM EscalationPolicies;"
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	var foundPolicies []*refractor.EscalationPolicy
+
+	for rows.Next() {
+		policy := &refractor.EscalationPolicy{}
+
+		if err := r.scanRows(rows, policy); err != nil {
+			return nil, wrapError(err)
+		}
+
+		foundPolicies = append(foundPolicies, policy)
+	}
+
+	return foundPolicies, nil
+}
+
+func (r *escalationPolicyRepo) Update(id int64, args refractor.UpdateArgs) (*refractor.EscalationPolicy, error) {
+	query, values := buildUpdateQuery("EscalationPolicies", id, "PolicyID", args)
+
+	_, err := r.db.Exec(query, values...)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	// Retrieve updated policy
+	return r.FindByID(id)
+}
+
+func (r *escalationPolicyRepo) Delete(id int64) error {
+	query := "DELETE FROM EscalationPolicies WHERE PolicyID = ?;"
+
+	res, err := r.db.Exec(query, id)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	if rowsAffected <= 0 {
+		return wrapError(sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// Scan helpers
+func (r *escalationPolicyRepo) scanRow(row *sql.Row, policy *refractor.EscalationPolicy) error {
+	return row.Scan(&policy.PolicyID, &policy.Name, &policy.TriggerType, &policy.TriggerCount, &policy.TimeWindow,
+		&policy.ActionType, &policy.ActionDuration, &policy.ActionReason, &policy.Enabled)
+}
+
+func (r *escalationPolicyRepo) scanRows(rows *sql.Rows, policy *refractor.EscalationPolicy) error {
+	return rows.Scan(&policy.PolicyID, &policy.Name, &policy.TriggerType, &policy.TriggerCount, &policy.TimeWindow,
+		&policy.ActionType, &policy.ActionDuration, &policy.ActionReason, &policy.Enabled)
+}
@@ -20,6 +20,7 @@ package mysql
 import (
 	"database/sql"
 	"github.com/sniddunc/refractor/refractor"
+	"strings"
 	"time"
 )
 
@@ -38,10 +39,23 @@ func (r *infractionRepo) Create(infraction *refractor.DBInfraction) (*refractor.
 		infraction.Timestamp = time.Now().Unix()
 	}
 
-	query := "INSERT INTO Infractions(PlayerID, UserID, ServerID, Type, Reason, Duration, Timestamp, SystemAction) VALUES (?, ?, ?, ?, ?, ?, ?, ?);"
+	if infraction.Enforcement == "" {
+		infraction.Enforcement = refractor.ENFORCEMENT_NONE
+	}
+
+	if infraction.Delivery == "" {
+		infraction.Delivery = refractor.DELIVERY_NONE
+	}
+
+	query := `
+		INSERT INTO Infractions(PlayerID, UserID, ServerID, Type, Reason, Duration, Timestamp, SystemAction,
+			Enforcement, LinkedInfractionID, PolicyID, Delivery)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+	`
 
 	res, err := r.db.Exec(query, infraction.PlayerID, infraction.UserID, infraction.ServerID, infraction.Type,
-		infraction.Reason, infraction.Duration, infraction.Timestamp, infraction.SystemAction)
+		infraction.Reason, infraction.Duration, infraction.Timestamp, infraction.SystemAction, infraction.Enforcement,
+		infraction.LinkedInfractionID, infraction.PolicyID, infraction.Delivery)
 	if err != nil {
 		return nil, wrapError(err)
 	}
@@ -94,8 +108,10 @@ func (r *infractionRepo) FindOne(args refractor.FindArgs) (*refractor.Infraction
 	return foundInfraction.Infraction(), nil
 }
 
+// FindMany finds all infractions matching args. Deleted infractions are never returned.
 func (r *infractionRepo) FindMany(args refractor.FindArgs) ([]*refractor.Infraction, error) {
 	query, values := buildFindQuery("Infractions", args)
+	query = strings.TrimSuffix(query, ";") + " AND DeletedAt IS NULL;"
 
 	rows, err := r.db.Query(query, values...)
 	if err != nil {
@@ -118,7 +134,7 @@ func (r *infractionRepo) FindMany(args refractor.FindArgs) ([]*refractor.Infract
 }
 
 func (r *infractionRepo) FindManyByPlayerID(playerID int64) ([]*refractor.Infraction, error) {
-	query := "SELECT * FROM Infractions WHERE PlayerID = ?;"
+	query := "SELECT * FROM Infractions WHERE PlayerID = ? AND DeletedAt IS NULL;"
 
 	rows, err := r.db.Query(query, playerID)
 	if err != nil {
@@ -214,6 +230,7 @@ func (r *infractionRepo) Search(args refractor.FindArgs, limit int, offset int)
 			FROM Infractions i
 			INNER JOIN Servers s ON i.ServerID = s.ServerID
 			WHERE
+				i.DeletedAt IS NULL AND
 				(? IS NULL OR i.Type = ?) AND
 				(? IS NULL OR i.PlayerID = ?) AND
 				(? IS NULL OR i.UserID = ?) AND
@@ -245,7 +262,10 @@ func (r *infractionRepo) Search(args refractor.FindArgs, limit int, offset int)
 
 		var staffName string
 		if err := rows.Scan(&dbinfr.InfractionID, &dbinfr.PlayerID, &dbinfr.UserID, &dbinfr.ServerID,
-			&dbinfr.Type, &dbinfr.Reason, &dbinfr.Duration, &dbinfr.Timestamp, &dbinfr.SystemAction, &staffName); err != nil {
+			&dbinfr.Type, &dbinfr.Reason, &dbinfr.Duration, &dbinfr.Timestamp, &dbinfr.SystemAction, &dbinfr.Enforcement,
+			&dbinfr.RevokedBy, &dbinfr.RevokedAt, &dbinfr.RevokeReason, &dbinfr.Expired, &dbinfr.LinkedInfractionID,
+			&dbinfr.PolicyID, &dbinfr.DeletedBy, &dbinfr.DeletedAt, &dbinfr.DeleteReason, &dbinfr.Delivery,
+			&dbinfr.DeliveredAt, &staffName); err != nil {
 			return 0, nil, wrapError(err)
 		}
 
@@ -276,6 +296,7 @@ func (r *infractionRepo) Search(args refractor.FindArgs, limit int, offset int)
 		FROM Infractions i
 		INNER JOIN Servers s ON i.ServerID = s.ServerID
 		WHERE
+			i.DeletedAt IS NULL AND
 			(? IS NULL OR i.Type = ?) AND
 			(? IS NULL OR i.PlayerID = ?) AND
 			(? IS NULL OR i.UserID = ?) AND
@@ -300,6 +321,7 @@ func (r *infractionRepo) GetRecent(count int) ([]*refractor.Infraction, error) {
 			u.Username AS StaffName
 		FROM Infractions i
 		INNER JOIN Users u ON u.UserID = i.UserID
+		WHERE i.DeletedAt IS NULL
 		ORDER BY Timestamp DESC LIMIT ?;
 	`
 
@@ -315,7 +337,10 @@ func (r *infractionRepo) GetRecent(count int) ([]*refractor.Infraction, error) {
 
 		var staffName string
 		if err := rows.Scan(&dbinfr.InfractionID, &dbinfr.PlayerID, &dbinfr.UserID, &dbinfr.ServerID,
-			&dbinfr.Type, &dbinfr.Reason, &dbinfr.Duration, &dbinfr.Timestamp, &dbinfr.SystemAction, &staffName); err != nil {
+			&dbinfr.Type, &dbinfr.Reason, &dbinfr.Duration, &dbinfr.Timestamp, &dbinfr.SystemAction, &dbinfr.Enforcement,
+			&dbinfr.RevokedBy, &dbinfr.RevokedAt, &dbinfr.RevokeReason, &dbinfr.Expired, &dbinfr.LinkedInfractionID,
+			&dbinfr.PolicyID, &dbinfr.DeletedBy, &dbinfr.DeletedAt, &dbinfr.DeleteReason, &dbinfr.Delivery,
+			&dbinfr.DeliveredAt, &staffName); err != nil {
 			return nil, wrapError(err)
 		}
 
@@ -342,13 +367,83 @@ func (r *infractionRepo) GetRecent(count int) ([]*refractor.Infraction, error) {
 	return foundInfractions, nil
 }
 
+// FindExpired returns all mutes and bans which have run out by the provided unix timestamp but have not yet been marked
+// as expired. Revoked, deleted and permanent infractions are never returned.
+func (r *infractionRepo) FindExpired(now int64) ([]*refractor.Infraction, error) {
+	query := `
+		SELECT * FROM Infractions
+		WHERE
+			Type IN ("MUTE", "BAN") AND
+			Expired = FALSE AND
+			RevokedAt IS NULL AND
+			DeletedAt IS NULL AND
+			Duration > 0 AND
+			Timestamp + Duration * 60 <= ?;
+	`
+
+	rows, err := r.db.Query(query, now)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	var foundInfractions []*refractor.Infraction
+
+	for rows.Next() {
+		infraction := &refractor.DBInfraction{}
+
+		if err := r.scanRows(rows, infraction); err != nil {
+			return nil, wrapError(err)
+		}
+
+		foundInfractions = append(foundInfractions, infraction.Infraction())
+	}
+
+	return foundInfractions, nil
+}
+
+// FindDeleted returns deleted infractions, most recently deleted first, along with the total number of deleted
+// infractions.
+func (r *infractionRepo) FindDeleted(limit int, offset int) (int, []*refractor.Infraction, error) {
+	query := "SELECT * FROM Infractions WHERE DeletedAt IS NOT NULL ORDER BY DeletedAt DESC LIMIT ? OFFSET ?;"
+
+	rows, err := r.db.Query(query, limit, offset)
+	if err != nil {
+		return 0, nil, wrapError(err)
+	}
+
+	var foundInfractions []*refractor.Infraction
+
+	for rows.Next() {
+		infraction := &refractor.DBInfraction{}
+
+		if err := r.scanRows(rows, infraction); err != nil {
+			return 0, nil, wrapError(err)
+		}
+
+		foundInfractions = append(foundInfractions, infraction.Infraction())
+	}
+
+	query = "SELECT COUNT(1) AS Count FROM Infractions WHERE DeletedAt IS NOT NULL;"
+
+	var count int
+	if err := r.db.QueryRow(query).Scan(&count); err != nil {
+		return 0, nil, wrapError(err)
+	}
+
+	return count, foundInfractions, nil
+}
+
 // Scan helpers
 func (r *infractionRepo) scanRow(row *sql.Row, infr *refractor.DBInfraction) error {
 	return row.Scan(&infr.InfractionID, &infr.PlayerID, &infr.UserID, &infr.ServerID, &infr.Type, &infr.Reason,
-		&infr.Duration, &infr.Timestamp, &infr.SystemAction)
+		&infr.Duration, &infr.Timestamp, &infr.SystemAction, &infr.Enforcement, &infr.RevokedBy, &infr.RevokedAt,
+		&infr.RevokeReason, &infr.Expired, &infr.LinkedInfractionID, &infr.PolicyID, &infr.DeletedBy, &infr.DeletedAt,
+		&infr.DeleteReason, &infr.Delivery, &infr.DeliveredAt)
 }
 
 func (r *infractionRepo) scanRows(row *sql.Rows, infr *refractor.DBInfraction) error {
 	return row.Scan(&infr.InfractionID, &infr.PlayerID, &infr.UserID, &infr.ServerID, &infr.Type, &infr.Reason,
-		&infr.Duration, &infr.Timestamp, &infr.SystemAction)
+		&infr.Duration, &infr.Timestamp, &infr.SystemAction, &infr.Enforcement, &infr.RevokedBy, &infr.RevokedAt,
+		&infr.RevokeReason, &infr.Expired, &infr.LinkedInfractionID, &infr.PolicyID, &infr.DeletedBy, &infr.DeletedAt,
+		&infr.DeleteReason, &infr.Delivery, &infr.DeliveredAt)
 }
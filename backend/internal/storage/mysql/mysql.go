@@ -81,6 +81,11 @@ func Setup(db *sql.DB) error {
 		    MCUUID VARCHAR(36) UNIQUE,
 			LastSeen BIGINT DEFAULT 0,
 		    Watched BOOLEAN DEFAULT FALSE,
+			WatchReason TEXT,
+			WatchedBy INT,
+			WatchedAt INT UNSIGNED,
+			WatchExpiresAt INT UNSIGNED,
+			Placeholder BOOLEAN DEFAULT FALSE,
 			
 			PRIMARY KEY (PlayerID)
 		);
@@ -92,6 +97,21 @@ func Setup(db *sql.DB) error {
 		return fmt.Errorf("could not create Players table. Error: %v", err)
 	}
 
+	// Add player columns which were introduced after the Players table was first released
+	if err := addMissingColumns(tx, "Players", []column{
+		{"WatchReason", "TEXT"},
+		{"WatchedBy", "INT"},
+		{"WatchedAt", "INT UNSIGNED"},
+		{"WatchExpiresAt", "INT UNSIGNED"},
+		{"Placeholder", "BOOLEAN DEFAULT FALSE"},
+	}); err != nil {
+		if err = tx.Rollback(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not upgrade Players table. Error: %v", err)
+	}
+
 	// Create player names table
 	if _, err := tx.Exec(`
 		CREATE TABLE IF NOT EXISTS PlayerNames(
@@ -133,6 +153,18 @@ func Setup(db *sql.DB) error {
 			Duration INT,
 			Timestamp INT UNSIGNED NOT NULL,
 			SystemAction BOOLEAN DEFAULT FALSE,
+			Enforcement ENUM("NONE", "SUCCESS", "FAILED", "UNSUPPORTED") NOT NULL DEFAULT "NONE",
+			RevokedBy INT,
+			RevokedAt INT UNSIGNED,
+			RevokeReason TEXT,
+			Expired BOOLEAN NOT NULL DEFAULT FALSE,
+			LinkedInfractionID INT,
+			PolicyID INT,
+			DeletedBy INT,
+			DeletedAt INT UNSIGNED,
+			DeleteReason TEXT,
+			Delivery ENUM("NONE", "DELIVERED", "QUEUED", "FAILED", "UNSUPPORTED") NOT NULL DEFAULT "NONE",
+			DeliveredAt INT UNSIGNED,
 			
 			PRIMARY KEY (InfractionID),
 			FOREIGN KEY (PlayerID) REFERENCES Players(PlayerID),
@@ -147,9 +179,536 @@ func Setup(db *sql.DB) error {
 		return fmt.Errorf("could not create Infractions table. Error: %v", err)
 	}
 
+	// Add infraction columns which were introduced after the Infractions table was first released
+	if err := addMissingColumns(tx, "Infractions", []column{
+		{"Enforcement", `ENUM("NONE", "SUCCESS", "FAILED", "UNSUPPORTED") NOT NULL DEFAULT "NONE"`},
+		{"RevokedBy", "INT"},
+		{"RevokedAt", "INT UNSIGNED"},
+		{"RevokeReason", "TEXT"},
+		{"Expired", "BOOLEAN NOT NULL DEFAULT FALSE"},
+		{"LinkedInfractionID", "INT"},
+		{"PolicyID", "INT"},
+		{"DeletedBy", "INT"},
+		{"DeletedAt", "INT UNSIGNED"},
+		{"DeleteReason", "TEXT"},
+		{"Delivery", `ENUM("NONE", "DELIVERED", "QUEUED", "FAILED", "UNSUPPORTED") NOT NULL DEFAULT "NONE"`},
+		{"DeliveredAt", "INT UNSIGNED"},
+	}); err != nil {
+		if err = tx.Rollback(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not upgrade Infractions table. Error: %v", err)
+	}
+
+	// Create escalation policies table
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS EscalationPolicies(
+			PolicyID INT NOT NULL AUTO_INCREMENT,
+			Name VARCHAR(64) NOT NULL,
+			TriggerType ENUM("WARNING", "MUTE", "KICK", "BAN") NOT NULL,
+			TriggerCount INT NOT NULL,
+			TimeWindow INT NOT NULL DEFAULT 0,
+			ActionType ENUM("WARNING", "MUTE", "KICK", "BAN") NOT NULL,
+			ActionDuration INT NOT NULL DEFAULT 0,
+			ActionReason TEXT NOT NULL,
+			Enabled BOOLEAN NOT NULL DEFAULT TRUE,
+
+			PRIMARY KEY (PolicyID)
+		);
+	`); err != nil {
+		if err = tx.Rollback(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not create EscalationPolicies table. Error: %v", err)
+	}
+
+	// Create appeals table
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS Appeals(
+			AppealID INT NOT NULL AUTO_INCREMENT,
+			InfractionID INT NOT NULL,
+			PlayerID INT NOT NULL,
+			UserID INT NOT NULL,
+			ReviewerID INT,
+			Status ENUM("OPEN", "UNDER_REVIEW", "ACCEPTED", "DENIED") NOT NULL DEFAULT "OPEN",
+			Statement TEXT NOT NULL,
+			DecisionNotes TEXT,
+			CreatedAt INT UNSIGNED NOT NULL,
+			UpdatedAt INT UNSIGNED NOT NULL,
+
+			PRIMARY KEY (AppealID),
+			FOREIGN KEY (InfractionID) REFERENCES Infractions(InfractionID) ON DELETE CASCADE,
+			FOREIGN KEY (PlayerID) REFERENCES Players(PlayerID),
+			FOREIGN KEY (UserID) REFERENCES Users(UserID),
+			FOREIGN KEY (ReviewerID) REFERENCES Users(UserID)
+		);
+	`); err != nil {
+		if err = tx.Rollback(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not create Appeals table. Error: %v", err)
+	}
+
+	// Create appeal events table
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS AppealEvents(
+			EventID INT NOT NULL AUTO_INCREMENT,
+			AppealID INT NOT NULL,
+			UserID INT NOT NULL,
+			Action ENUM("OPENED", "ASSIGNED", "NOTE", "ACCEPTED", "DENIED") NOT NULL,
+			Status ENUM("OPEN", "UNDER_REVIEW", "ACCEPTED", "DENIED") NOT NULL,
+			Notes TEXT,
+			Timestamp INT UNSIGNED NOT NULL,
+
+			PRIMARY KEY (EventID),
+			FOREIGN KEY (AppealID) REFERENCES Appeals(AppealID) ON DELETE CASCADE,
+			FOREIGN KEY (UserID) REFERENCES Users(UserID)
+		);
+	`); err != nil {
+		if err = tx.Rollback(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not create AppealEvents table. Error: %v", err)
+	}
+
+	// Create infraction revisions tables
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS InfractionRevisions(
+			RevisionID INT NOT NULL AUTO_INCREMENT,
+			InfractionID INT NOT NULL,
+			UserID INT NOT NULL,
+			Timestamp INT UNSIGNED NOT NULL,
+
+			PRIMARY KEY (RevisionID),
+			FOREIGN KEY (InfractionID) REFERENCES Infractions(InfractionID) ON DELETE CASCADE,
+			FOREIGN KEY (UserID) REFERENCES Users(UserID)
+		);
+	`); err != nil {
+		if err = tx.Rollback(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not create InfractionRevisions table. Error: %v", err)
+	}
+
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS InfractionRevisionChanges(
+			RevisionID INT NOT NULL,
+			Field VARCHAR(32) NOT NULL,
+			OldValue TEXT,
+			NewValue TEXT,
+
+			PRIMARY KEY (RevisionID, Field),
+			FOREIGN KEY (RevisionID) REFERENCES InfractionRevisions(RevisionID) ON DELETE CASCADE
+		);
+	`); err != nil {
+		if err = tx.Rollback(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not create InfractionRevisionChanges table. Error: %v", err)
+	}
+
+	// Create attachments table
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS Attachments(
+			AttachmentID INT NOT NULL AUTO_INCREMENT,
+			InfractionID INT NOT NULL,
+			UserID INT NOT NULL,
+			FileName VARCHAR(255) NOT NULL,
+			ContentType VARCHAR(128) NOT NULL,
+			Size BIGINT UNSIGNED NOT NULL,
+			StorageKey VARCHAR(64) NOT NULL,
+			Timestamp INT UNSIGNED NOT NULL,
+
+			PRIMARY KEY (AttachmentID),
+			FOREIGN KEY (InfractionID) REFERENCES Infractions(InfractionID) ON DELETE CASCADE,
+			FOREIGN KEY (UserID) REFERENCES Users(UserID)
+		);
+	`); err != nil {
+		if err = tx.Rollback(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not create Attachments table. Error: %v", err)
+	}
+
+	// Create infraction comments table. Replies are removed along with the comment they reply to.
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS InfractionComments(
+			CommentID INT NOT NULL AUTO_INCREMENT,
+			InfractionID INT NOT NULL,
+			ParentID INT,
+			UserID INT NOT NULL,
+			Body TEXT NOT NULL,
+			Timestamp INT UNSIGNED NOT NULL,
+			EditedAt INT UNSIGNED,
+
+			PRIMARY KEY (CommentID),
+			FOREIGN KEY (InfractionID) REFERENCES Infractions(InfractionID) ON DELETE CASCADE,
+			FOREIGN KEY (ParentID) REFERENCES InfractionComments(CommentID) ON DELETE CASCADE,
+			FOREIGN KEY (UserID) REFERENCES Users(UserID)
+		);
+	`); err != nil {
+		if err = tx.Rollback(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not create InfractionComments table. Error: %v", err)
+	}
+
+	// Create player notes table
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS PlayerNotes(
+			NoteID INT NOT NULL AUTO_INCREMENT,
+			PlayerID INT NOT NULL,
+			UserID INT NOT NULL,
+			Body TEXT NOT NULL,
+			Pinned BOOLEAN NOT NULL DEFAULT FALSE,
+			AdminOnly BOOLEAN NOT NULL DEFAULT FALSE,
+			Timestamp INT UNSIGNED NOT NULL,
+			EditedAt INT UNSIGNED,
+
+			PRIMARY KEY (NoteID),
+			FOREIGN KEY (PlayerID) REFERENCES Players(PlayerID) ON DELETE CASCADE,
+			FOREIGN KEY (UserID) REFERENCES Users(UserID)
+		);
+	`); err != nil {
+		if err = tx.Rollback(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not create PlayerNotes table. Error: %v", err)
+	}
+
+	// Create player sessions table
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS PlayerSessions(
+			SessionID INT NOT NULL AUTO_INCREMENT,
+			PlayerID INT NOT NULL,
+			ServerID INT NOT NULL,
+			Name VARCHAR(128) CHARACTER SET utf8mb4 NOT NULL,
+			StartedAt INT UNSIGNED NOT NULL,
+			EndedAt INT UNSIGNED,
+			LastSeenAt INT UNSIGNED NOT NULL,
+
+			PRIMARY KEY (SessionID),
+			INDEX (PlayerID, StartedAt),
+			INDEX (ServerID, EndedAt),
+			FOREIGN KEY (PlayerID) REFERENCES Players(PlayerID) ON DELETE CASCADE,
+			FOREIGN KEY (ServerID) REFERENCES Servers(ServerID) ON DELETE CASCADE
+		);
+	`); err != nil {
+		if err = tx.Rollback(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not create PlayerSessions table. Error: %v", err)
+	}
+
+	// Create chat messages table. PlayerID is set for messages sent by players and UserID for messages sent by
+	// Refractor users.
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS ChatMessages(
+			MessageID INT NOT NULL AUTO_INCREMENT,
+			ServerID INT NOT NULL,
+			PlayerID INT,
+			UserID INT,
+			Name VARCHAR(128) CHARACTER SET utf8mb4 NOT NULL,
+			Channel VARCHAR(64) NOT NULL DEFAULT '',
+			Message TEXT CHARACTER SET utf8mb4 NOT NULL,
+			Timestamp INT UNSIGNED NOT NULL,
+
+			PRIMARY KEY (MessageID),
+			INDEX (ServerID, Timestamp),
+			INDEX (PlayerID, Timestamp),
+			INDEX (Timestamp),
+			FOREIGN KEY (ServerID) REFERENCES Servers(ServerID) ON DELETE CASCADE,
+			FOREIGN KEY (PlayerID) REFERENCES Players(PlayerID) ON DELETE CASCADE,
+			FOREIGN KEY (UserID) REFERENCES Users(UserID)
+		);
+	`); err != nil {
+		if err = tx.Rollback(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not create ChatMessages table. Error: %v", err)
+	}
+
+	// Create chat filter rules table
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS ChatFilterRules(
+			RuleID INT NOT NULL AUTO_INCREMENT,
+			Pattern VARCHAR(256) CHARACTER SET utf8mb4 NOT NULL,
+			MatchType ENUM("WORD", "REGEX") NOT NULL,
+			Leetspeak BOOLEAN NOT NULL DEFAULT FALSE,
+			ActionType ENUM("ALERT", "WARNING", "MUTE", "KICK") NOT NULL,
+			ActionDuration INT NOT NULL DEFAULT 0,
+			ActionReason TEXT NOT NULL,
+			Enabled BOOLEAN NOT NULL DEFAULT TRUE,
+
+			PRIMARY KEY (RuleID)
+		);
+	`); err != nil {
+		if err = tx.Rollback(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not create ChatFilterRules table. Error: %v", err)
+	}
+
+	// Create spam settings table
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS SpamSettings(
+			ServerID INT NOT NULL,
+			Enabled BOOLEAN NOT NULL DEFAULT FALSE,
+			MessageLimit INT NOT NULL,
+			MessageWindow INT NOT NULL,
+			RepeatLimit INT NOT NULL,
+			CapsPercent INT NOT NULL,
+			CapsMinLength INT NOT NULL,
+			MuteDuration INT NOT NULL,
+
+			PRIMARY KEY (ServerID),
+			FOREIGN KEY (ServerID) REFERENCES Servers(ServerID) ON DELETE CASCADE
+		);
+	`); err != nil {
+		if err = tx.Rollback(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not create SpamSettings table. Error: %v", err)
+	}
+
+	// Create infraction chat snapshots table. Messages are copied rather than referenced so that snapshots are not
+	// affected by changes to the chat log.
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS InfractionChatSnapshots(
+			SnapshotMessageID INT NOT NULL AUTO_INCREMENT,
+			InfractionID INT NOT NULL,
+			MessageID INT NOT NULL,
+			ServerID INT NOT NULL,
+			PlayerID INT,
+			UserID INT,
+			Name VARCHAR(128) CHARACTER SET utf8mb4 NOT NULL,
+			Channel VARCHAR(64) NOT NULL DEFAULT '',
+			Message TEXT CHARACTER SET utf8mb4 NOT NULL,
+			Timestamp INT UNSIGNED NOT NULL,
+
+			PRIMARY KEY (SnapshotMessageID),
+			INDEX (InfractionID),
+			FOREIGN KEY (InfractionID) REFERENCES Infractions(InfractionID) ON DELETE CASCADE
+		);
+	`); err != nil {
+		if err = tx.Rollback(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not create InfractionChatSnapshots table. Error: %v", err)
+	}
+
+	// Create webhooks table
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS Webhooks(
+			WebhookID INT NOT NULL AUTO_INCREMENT,
+			Name VARCHAR(64) NOT NULL,
+			URL VARCHAR(512) NOT NULL,
+			Secret VARCHAR(128) NOT NULL,
+			Events VARCHAR(512) NOT NULL,
+			Enabled BOOLEAN NOT NULL DEFAULT TRUE,
+			CreatedAt INT UNSIGNED NOT NULL,
+
+			PRIMARY KEY (WebhookID)
+		);
+	`); err != nil {
+		if err = tx.Rollback(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not create Webhooks table. Error: %v", err)
+	}
+
+	// Create webhook deliveries table
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS WebhookDeliveries(
+			DeliveryID INT NOT NULL AUTO_INCREMENT,
+			WebhookID INT NOT NULL,
+			Event VARCHAR(32) NOT NULL,
+			Payload MEDIUMTEXT CHARACTER SET utf8mb4 NOT NULL,
+			Status ENUM("PENDING", "SUCCEEDED", "FAILED") NOT NULL DEFAULT "PENDING",
+			Attempts INT NOT NULL DEFAULT 0,
+			ResponseStatus INT NOT NULL DEFAULT 0,
+			Error TEXT NOT NULL,
+			CreatedAt INT UNSIGNED NOT NULL,
+			CompletedAt INT UNSIGNED NOT NULL DEFAULT 0,
+
+			PRIMARY KEY (DeliveryID),
+			INDEX (WebhookID, CreatedAt),
+			FOREIGN KEY (WebhookID) REFERENCES Webhooks(WebhookID) ON DELETE CASCADE
+		);
+	`); err != nil {
+		if err = tx.Rollback(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not create WebhookDeliveries table. Error: %v", err)
+	}
+
+	// Create Discord channels table. ServerID is 0 for channels which receive infractions from every server so it is
+	// not a foreign key.
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS DiscordChannels(
+			ChannelID INT NOT NULL AUTO_INCREMENT,
+			Name VARCHAR(64) NOT NULL,
+			WebhookURL VARCHAR(512) NOT NULL,
+			InfractionType VARCHAR(16) NOT NULL DEFAULT '',
+			ServerID INT NOT NULL DEFAULT 0,
+			Enabled BOOLEAN NOT NULL DEFAULT TRUE,
+
+			PRIMARY KEY (ChannelID)
+		);
+	`); err != nil {
+		if err = tx.Rollback(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not create DiscordChannels table. Error: %v", err)
+	}
+
+	// Create schedules table. ServerID is 0 for schedules which run on every server so it is not a foreign key.
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS Schedules(
+			ScheduleID INT NOT NULL AUTO_INCREMENT,
+			Name VARCHAR(64) NOT NULL,
+			Cron VARCHAR(128) NOT NULL,
+			ActionType ENUM("COMMAND", "ANNOUNCEMENT") NOT NULL,
+			Payload VARCHAR(512) CHARACTER SET utf8mb4 NOT NULL,
+			ServerID INT NOT NULL DEFAULT 0,
+			Enabled BOOLEAN NOT NULL DEFAULT TRUE,
+			CreatedBy INT NOT NULL,
+			CreatedAt INT UNSIGNED NOT NULL,
+			LastRunAt INT UNSIGNED NOT NULL DEFAULT 0,
+
+			PRIMARY KEY (ScheduleID)
+		);
+	`); err != nil {
+		if err = tx.Rollback(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not create Schedules table. Error: %v", err)
+	}
+
+	// Create schedule runs table
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS ScheduleRuns(
+			RunID INT NOT NULL AUTO_INCREMENT,
+			ScheduleID INT NOT NULL,
+			ServerID INT NOT NULL,
+			Command VARCHAR(1024) CHARACTER SET utf8mb4 NOT NULL,
+			Success BOOLEAN NOT NULL,
+			Output TEXT CHARACTER SET utf8mb4 NOT NULL,
+			Error TEXT NOT NULL,
+			RanAt INT UNSIGNED NOT NULL,
+
+			PRIMARY KEY (RunID),
+			INDEX (ScheduleID, RanAt),
+			FOREIGN KEY (ScheduleID) REFERENCES Schedules(ScheduleID) ON DELETE CASCADE
+		);
+	`); err != nil {
+		if err = tx.Rollback(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not create ScheduleRuns table. Error: %v", err)
+	}
+
+	// Create RCON console command rules table
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS RCONCommandRules(
+			RuleID INT NOT NULL AUTO_INCREMENT,
+			Role ENUM("ADMIN", "CONSOLE") NOT NULL,
+			Prefix VARCHAR(64) CHARACTER SET utf8mb4 NOT NULL,
+			Type ENUM("ALLOW", "DENY") NOT NULL,
+
+			PRIMARY KEY (RuleID)
+		);
+	`); err != nil {
+		if err = tx.Rollback(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not create RCONCommandRules table. Error: %v", err)
+	}
+
+	// Create RCON console audit log table. ServerID is not a foreign key so that the log is kept if a server is deleted.
+	if _, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS RCONCommandLogs(
+			LogID INT NOT NULL AUTO_INCREMENT,
+			UserID INT NOT NULL,
+			ServerID INT NOT NULL,
+			Command VARCHAR(512) CHARACTER SET utf8mb4 NOT NULL,
+			Output TEXT CHARACTER SET utf8mb4 NOT NULL,
+			Allowed BOOLEAN NOT NULL,
+			Success BOOLEAN NOT NULL,
+			Error TEXT NOT NULL,
+			Timestamp INT UNSIGNED NOT NULL,
+
+			PRIMARY KEY (LogID),
+			INDEX (ServerID, Timestamp),
+			FOREIGN KEY (UserID) REFERENCES Users(UserID)
+		);
+	`); err != nil {
+		if err = tx.Rollback(); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("could not create RCONCommandLogs table. Error: %v", err)
+	}
+
 	return tx.Commit()
 }
 
+// column holds the name and definition of a table column
+type column struct {
+	name       string
+	definition string
+}
+
+// addMissingColumns adds any of the provided columns which do not yet exist to a table. Since tables are created using
+// CREATE TABLE IF NOT EXISTS, columns added in newer versions of Refractor would otherwise be missing from databases
+// set up by older versions. Columns must be listed in the same order they appear in the table's create statement so
+// that SELECT * queries return them in a consistent order.
+func addMissingColumns(tx *sql.Tx, table string, columns []column) error {
+	query := `
+		SELECT EXISTS(
+			SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+			WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME = ?
+		);
+	`
+
+	for _, col := range columns {
+		var exists bool
+		if err := tx.QueryRow(query, table, col.name).Scan(&exists); err != nil {
+			return err
+		}
+
+		if exists {
+			continue
+		}
+
+		if _, err := tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s;", table, col.name, col.definition)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // MySQL query builder and helper functions
 func wrapError(err error) error {
 	switch err {
@@ -0,0 +1,151 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mysql
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/refractor"
+	"time"
+)
+
+type noteRepo struct {
+	db *sql.DB
+}
+
+func NewNoteRepository(db *sql.DB) refractor.NoteRepository {
+	return &noteRepo{
+		db: db,
+	}
+}
+
+func (r *noteRepo) Create(note *refractor.PlayerNote) error {
+	if note.Timestamp == 0 {
+		note.Timestamp = time.Now().Unix()
+	}
+
+	query := "INSERT INTO PlayerNotes(PlayerID, UserID, Body, Pinned, AdminOnly, Timestamp) VALUES (?, ?, ?, ?, ?, ?);"
+
+	res, err := r.db.Exec(query, note.PlayerID, note.UserID, note.Body, note.Pinned, note.AdminOnly, note.Timestamp)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	note.NoteID = id
+
+	return nil
+}
+
+func (r *noteRepo) FindByID(id int64) (*refractor.PlayerNote, error) {
+	query := "SELECT * FROM PlayerNotes WHERE NoteID = ?;"
+	row := r.db.QueryRow(query, id)
+
+	foundNote := &refractor.PlayerNote{}
+	if err := r.scanRow(row, foundNote); err != nil {
+		return nil, wrapError(err)
+	}
+
+	return foundNote, nil
+}
+
+// FindManyByPlayerID gets all notes on a player. Pinned notes come first, followed by the rest from newest to oldest.
+func (r *noteRepo) FindManyByPlayerID(playerID int64) ([]*refractor.PlayerNote, error) {
+	query := "SELECT * FROM PlayerNotes WHERE PlayerID = ? ORDER BY Pinned DESC, Timestamp DESC, NoteID DESC;"
+
+	rows, err := r.db.Query(query, playerID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	var foundNotes []*refractor.PlayerNote
+
+	for rows.Next() {
+		note := &refractor.PlayerNote{}
+
+		if err := r.scanRows(rows, note); err != nil {
+			return nil, wrapError(err)
+		}
+
+		foundNotes = append(foundNotes, note)
+	}
+
+	return foundNotes, nil
+}
+
+func (r *noteRepo) Update(id int64, args refractor.UpdateArgs) (*refractor.PlayerNote, error) {
+	query, values := buildUpdateQuery("PlayerNotes", id, "NoteID", args)
+
+	_, err := r.db.Exec(query, values...)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	// Retrieve updated note
+	return r.FindByID(id)
+}
+
+func (r *noteRepo) Delete(id int64) error {
+	query := "DELETE FROM PlayerNotes WHERE NoteID = ?;"
+
+	res, err := r.db.Exec(query, id)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	if rowsAffected <= 0 {
+		return wrapError(sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// Scan helpers
+func (r *noteRepo) scanRow(row *sql.Row, note *refractor.PlayerNote) error {
+	var editedAt sql.NullInt64
+
+	if err := row.Scan(&note.NoteID, &note.PlayerID, &note.UserID, &note.Body, &note.Pinned, &note.AdminOnly,
+		&note.Timestamp, &editedAt); err != nil {
+		return err
+	}
+
+	note.EditedAt = editedAt.Int64
+
+	return nil
+}
+
+func (r *noteRepo) scanRows(rows *sql.Rows, note *refractor.PlayerNote) error {
+	var editedAt sql.NullInt64
+
+	if err := rows.Scan(&note.NoteID, &note.PlayerID, &note.UserID, &note.Body, &note.Pinned, &note.AdminOnly,
+		&note.Timestamp, &editedAt); err != nil {
+		return err
+	}
+
+	note.EditedAt = editedAt.Int64
+
+	return nil
+}
@@ -39,9 +39,9 @@ func NewPlayerRepository(db *sql.DB) refractor.PlayerRepository {
 // The following values must be present on the passed in Player reference for Create to function properly:
 // PlayFabID, LastSeen and CurrentName.
 func (r *playerRepo) Create(player *refractor.DBPlayer) error {
-	query := "INSERT INTO Players (PlayFabID, MCUUID, LastSeen) VALUES (?, ?, ?);"
+	query := "INSERT INTO Players (PlayFabID, MCUUID, LastSeen, Placeholder) VALUES (?, ?, ?, ?);"
 
-	res, err := r.db.Exec(query, player.PlayFabID, player.MCUUID, player.LastSeen)
+	res, err := r.db.Exec(query, player.PlayFabID, player.MCUUID, player.LastSeen, player.Placeholder)
 	if err != nil {
 		return wrapError(err)
 	}
@@ -189,6 +189,36 @@ func (r *playerRepo) UpdateName(player *refractor.Player, currentName string) er
 	return nil
 }
 
+// ReplaceName removes all of a player's recorded names and records currentName as their only name. This is used when a
+// placeholder player joins for the first time so that the placeholder name isn't kept as one of their previous names.
+func (r *playerRepo) ReplaceName(player *refractor.Player, currentName string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	if _, err := tx.Exec("DELETE FROM PlayerNames WHERE PlayerID = ?;", player.PlayerID); err != nil {
+		_ = tx.Rollback()
+		return wrapError(err)
+	}
+
+	query := "INSERT INTO PlayerNames (PlayerID, Name, DateRecorded) VALUES (?, ?, ?);"
+
+	if _, err := tx.Exec(query, player.PlayerID, string([]rune(currentName)), time.Now().Unix()); err != nil {
+		_ = tx.Rollback()
+		return wrapError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return wrapError(err)
+	}
+
+	player.CurrentName = currentName
+	player.PreviousNames = nil
+
+	return nil
+}
+
 func (r *playerRepo) FindOne(args refractor.FindArgs) (*refractor.Player, error) {
 	query, values := buildFindQuery("Players", args)
 
@@ -335,9 +365,13 @@ func (r *playerRepo) getPlayerNames(playerID int64) (string, []string, error) {
 
 // Scan helpers
 func (r *playerRepo) scanRow(row *sql.Row, player *refractor.DBPlayer) error {
-	return row.Scan(&player.PlayerID, &player.PlayFabID, &player.MCUUID, &player.LastSeen, &player.Watched)
+	return row.Scan(&player.PlayerID, &player.PlayFabID, &player.MCUUID, &player.LastSeen, &player.Watched,
+		&player.WatchReason, &player.WatchedBy, &player.WatchedAt, &player.WatchExpiresAt,
+		&player.Placeholder)
 }
 
 func (r *playerRepo) scanRows(rows *sql.Rows, player *refractor.DBPlayer) error {
-	return rows.Scan(&player.PlayerID, &player.PlayFabID, &player.MCUUID, &player.LastSeen, &player.Watched)
+	return rows.Scan(&player.PlayerID, &player.PlayFabID, &player.MCUUID, &player.LastSeen, &player.Watched,
+		&player.WatchReason, &player.WatchedBy, &player.WatchedAt, &player.WatchExpiresAt,
+		&player.Placeholder)
 }
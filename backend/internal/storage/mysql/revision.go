@@ -0,0 +1,111 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"github.com/sniddunc/refractor/refractor"
+	"time"
+)
+
+// CreateRevision stores a revision along with all of its changes in a single transaction.
+func (r *infractionRepo) CreateRevision(revision *refractor.InfractionRevision) error {
+	if revision.Timestamp == 0 {
+		revision.Timestamp = time.Now().Unix()
+	}
+
+	tx, err := r.db.BeginTx(context.Background(), nil)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	query := "INSERT INTO InfractionRevisions(InfractionID, UserID, Timestamp) VALUES (?, ?, ?);"
+
+	res, err := tx.Exec(query, revision.InfractionID, revision.UserID, revision.Timestamp)
+	if err != nil {
+		_ = tx.Rollback()
+		return wrapError(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		_ = tx.Rollback()
+		return wrapError(err)
+	}
+
+	query = "INSERT INTO InfractionRevisionChanges(RevisionID, Field, OldValue, NewValue) VALUES (?, ?, ?, ?);"
+
+	for _, change := range revision.Changes {
+		if _, err := tx.Exec(query, id, change.Field, change.OldValue, change.NewValue); err != nil {
+			_ = tx.Rollback()
+			return wrapError(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return wrapError(err)
+	}
+
+	revision.RevisionID = id
+
+	return nil
+}
+
+// FindRevisions gets all revisions of an infraction, oldest first.
+func (r *infractionRepo) FindRevisions(infractionID int64) ([]*refractor.InfractionRevision, error) {
+	query := `
+		SELECT r.RevisionID, r.InfractionID, r.UserID, r.Timestamp, c.Field, c.OldValue, c.NewValue
+		FROM InfractionRevisions r
+		JOIN InfractionRevisionChanges c ON r.RevisionID = c.RevisionID
+		WHERE r.InfractionID = ?
+		ORDER BY r.Timestamp ASC, r.RevisionID ASC;
+	`
+
+	rows, err := r.db.Query(query, infractionID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	var foundRevisions []*refractor.InfractionRevision
+	var current *refractor.InfractionRevision
+
+	// Each row holds a single change, so consecutive rows are grouped into their revisions
+	for rows.Next() {
+		revision := &refractor.InfractionRevision{}
+		change := &refractor.InfractionChange{}
+
+		var oldValue, newValue sql.NullString
+		if err := rows.Scan(&revision.RevisionID, &revision.InfractionID, &revision.UserID, &revision.Timestamp,
+			&change.Field, &oldValue, &newValue); err != nil {
+			return nil, wrapError(err)
+		}
+
+		change.OldValue = oldValue.String
+		change.NewValue = newValue.String
+
+		if current == nil || current.RevisionID != revision.RevisionID {
+			current = revision
+			foundRevisions = append(foundRevisions, current)
+		}
+
+		current.Changes = append(current.Changes, change)
+	}
+
+	return foundRevisions, nil
+}
@@ -0,0 +1,205 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mysql
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/refractor"
+)
+
+type scheduleRepo struct {
+	db *sql.DB
+}
+
+func NewScheduleRepository(db *sql.DB) refractor.ScheduleRepository {
+	return &scheduleRepo{
+		db: db,
+	}
+}
+
+func (r *scheduleRepo) Create(schedule *refractor.Schedule) error {
+	query := `
+		INSERT INTO Schedules (Name, Cron, ActionType, Payload, ServerID, Enabled, CreatedBy, CreatedAt, LastRunAt)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+	`
+
+	res, err := r.db.Exec(query, schedule.Name, schedule.Cron, schedule.ActionType, schedule.Payload, schedule.ServerID,
+		schedule.Enabled, schedule.CreatedBy, schedule.CreatedAt, schedule.LastRunAt)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	schedule.ScheduleID = id
+
+	return nil
+}
+
+func (r *scheduleRepo) FindByID(id int64) (*refractor.Schedule, error) {
+	query := "SELECT * FROM Schedules WHERE ScheduleID = ?;"
+	row := r.db.QueryRow(query, id)
+
+	foundSchedule := &refractor.Schedule{}
+	if err := r.scanRow(row, foundSchedule); err != nil {
+		return nil, wrapError(err)
+	}
+
+	return foundSchedule, nil
+}
+
+func (r *scheduleRepo) FindAll() ([]*refractor.Schedule, error) {
+	query := "SELECT * FROM Schedules;"
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	var foundSchedules []*refractor.Schedule
+
+	for rows.Next() {
+		schedule := &refractor.Schedule{}
+
+		if err := r.scanRows(rows, schedule); err != nil {
+			return nil, wrapError(err)
+		}
+
+		foundSchedules = append(foundSchedules, schedule)
+	}
+
+	return foundSchedules, nil
+}
+
+func (r *scheduleRepo) Update(id int64, args refractor.UpdateArgs) (*refractor.Schedule, error) {
+	query, values := buildUpdateQuery("Schedules", id, "ScheduleID", args)
+
+	_, err := r.db.Exec(query, values...)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	// Retrieve updated schedule
+	return r.FindByID(id)
+}
+
+func (r *scheduleRepo) Delete(id int64) error {
+	query := "DELETE FROM Schedules WHERE ScheduleID = ?;"
+
+	res, err := r.db.Exec(query, id)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	if rowsAffected <= 0 {
+		return wrapError(sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// Scan helpers
+func (r *scheduleRepo) scanRow(row *sql.Row, schedule *refractor.Schedule) error {
+	return row.Scan(&schedule.ScheduleID, &schedule.Name, &schedule.Cron, &schedule.ActionType, &schedule.Payload,
+		&schedule.ServerID, &schedule.Enabled, &schedule.CreatedBy, &schedule.CreatedAt, &schedule.LastRunAt)
+}
+
+func (r *scheduleRepo) scanRows(rows *sql.Rows, schedule *refractor.Schedule) error {
+	return rows.Scan(&schedule.ScheduleID, &schedule.Name, &schedule.Cron, &schedule.ActionType, &schedule.Payload,
+		&schedule.ServerID, &schedule.Enabled, &schedule.CreatedBy, &schedule.CreatedAt, &schedule.LastRunAt)
+}
+
+type scheduleRunRepo struct {
+	db *sql.DB
+}
+
+func NewScheduleRunRepository(db *sql.DB) refractor.ScheduleRunRepository {
+	return &scheduleRunRepo{
+		db: db,
+	}
+}
+
+func (r *scheduleRunRepo) Create(run *refractor.ScheduleRun) error {
+	query := `
+		INSERT INTO ScheduleRuns (ScheduleID, ServerID, Command, Success, Output, Error, RanAt)
+		VALUES (?, ?, ?, ?, ?, ?, ?);
+	`
+
+	res, err := r.db.Exec(query, run.ScheduleID, run.ServerID, run.Command, run.Success, run.Output, run.Error,
+		run.RanAt)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	run.RunID = id
+
+	return nil
+}
+
+// FindManyByScheduleID gets a page of a schedule's runs, newest first, along with the total number of runs.
+func (r *scheduleRunRepo) FindManyByScheduleID(scheduleID int64, limit int, offset int) (int, []*refractor.ScheduleRun, error) {
+	query := "SELECT * FROM ScheduleRuns WHERE ScheduleID = ? ORDER BY RunID DESC LIMIT ? OFFSET ?;"
+
+	rows, err := r.db.Query(query, scheduleID, limit, offset)
+	if err != nil {
+		return 0, nil, wrapError(err)
+	}
+
+	var foundRuns []*refractor.ScheduleRun
+
+	for rows.Next() {
+		run := &refractor.ScheduleRun{}
+
+		if err := r.scanRows(rows, run); err != nil {
+			return 0, nil, wrapError(err)
+		}
+
+		foundRuns = append(foundRuns, run)
+	}
+
+	// Get total number of runs
+	query = "SELECT COUNT(1) AS Count FROM ScheduleRuns WHERE ScheduleID = ?;"
+
+	row := r.db.QueryRow(query, scheduleID)
+
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return 0, nil, wrapError(err)
+	}
+
+	return count, foundRuns, nil
+}
+
+// Scan helpers
+func (r *scheduleRunRepo) scanRows(rows *sql.Rows, run *refractor.ScheduleRun) error {
+	return rows.Scan(&run.RunID, &run.ScheduleID, &run.ServerID, &run.Command, &run.Success, &run.Output, &run.Error,
+		&run.RanAt)
+}
@@ -0,0 +1,189 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mysql
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/refractor"
+	"time"
+)
+
+type sessionRepo struct {
+	db *sql.DB
+}
+
+func NewSessionRepository(db *sql.DB) refractor.SessionRepository {
+	return &sessionRepo{
+		db: db,
+	}
+}
+
+func (r *sessionRepo) Create(session *refractor.PlayerSession) error {
+	if session.StartedAt == 0 {
+		session.StartedAt = time.Now().Unix()
+	}
+
+	if session.LastSeenAt == 0 {
+		session.LastSeenAt = session.StartedAt
+	}
+
+	query := "INSERT INTO PlayerSessions(PlayerID, ServerID, Name, StartedAt, LastSeenAt) VALUES (?, ?, ?, ?, ?);"
+
+	res, err := r.db.Exec(query, session.PlayerID, session.ServerID, session.Name, session.StartedAt,
+		session.LastSeenAt)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	session.SessionID = id
+
+	return nil
+}
+
+func (r *sessionRepo) FindOpenByServerID(serverID int64) ([]*refractor.PlayerSession, error) {
+	query := "SELECT * FROM PlayerSessions WHERE ServerID = ? AND EndedAt IS NULL;"
+
+	rows, err := r.db.Query(query, serverID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	var foundSessions []*refractor.PlayerSession
+
+	for rows.Next() {
+		session := &refractor.PlayerSession{}
+
+		if err := r.scanRows(rows, session); err != nil {
+			return nil, wrapError(err)
+		}
+
+		foundSessions = append(foundSessions, session)
+	}
+
+	return foundSessions, nil
+}
+
+// FindManyByPlayerID gets a page of a player's sessions, newest first, along with the total number of sessions.
+func (r *sessionRepo) FindManyByPlayerID(playerID int64, limit int, offset int) (int, []*refractor.PlayerSession, error) {
+	query := "SELECT * FROM PlayerSessions WHERE PlayerID = ? ORDER BY StartedAt DESC LIMIT ? OFFSET ?;"
+
+	rows, err := r.db.Query(query, playerID, limit, offset)
+	if err != nil {
+		return 0, nil, wrapError(err)
+	}
+
+	var foundSessions []*refractor.PlayerSession
+
+	for rows.Next() {
+		session := &refractor.PlayerSession{}
+
+		if err := r.scanRows(rows, session); err != nil {
+			return 0, nil, wrapError(err)
+		}
+
+		foundSessions = append(foundSessions, session)
+	}
+
+	// Get total number of sessions
+	query = "SELECT COUNT(1) AS Count FROM PlayerSessions WHERE PlayerID = ?;"
+
+	row := r.db.QueryRow(query, playerID)
+
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return 0, nil, wrapError(err)
+	}
+
+	return count, foundSessions, nil
+}
+
+func (r *sessionRepo) Update(id int64, args refractor.UpdateArgs) error {
+	query, values := buildUpdateQuery("PlayerSessions", id, "SessionID", args)
+
+	if _, err := r.db.Exec(query, values...); err != nil {
+		return wrapError(err)
+	}
+
+	return nil
+}
+
+// CloseAbandoned closes every open session at the time its player was last seen. It returns the number of sessions
+// which were closed.
+func (r *sessionRepo) CloseAbandoned() (int64, error) {
+	query := "UPDATE PlayerSessions SET EndedAt = LastSeenAt WHERE EndedAt IS NULL;"
+
+	res, err := r.db.Exec(query)
+	if err != nil {
+		return 0, wrapError(err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return 0, wrapError(err)
+	}
+
+	return rowsAffected, nil
+}
+
+// GetPlaytime returns the number of seconds a player has spent on each server, keyed by server ID. Open sessions are
+// counted up to the current time.
+func (r *sessionRepo) GetPlaytime(playerID int64) (map[int64]int64, error) {
+	query := `
+		SELECT ServerID, SUM(COALESCE(EndedAt, ?) - StartedAt) AS Playtime FROM PlayerSessions
+		WHERE PlayerID = ?
+		GROUP BY ServerID;
+	`
+
+	rows, err := r.db.Query(query, time.Now().Unix(), playerID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	playtime := map[int64]int64{}
+
+	for rows.Next() {
+		var serverID, seconds int64
+
+		if err := rows.Scan(&serverID, &seconds); err != nil {
+			return nil, wrapError(err)
+		}
+
+		playtime[serverID] = seconds
+	}
+
+	return playtime, nil
+}
+
+// Scan helpers
+func (r *sessionRepo) scanRows(rows *sql.Rows, session *refractor.PlayerSession) error {
+	var endedAt sql.NullInt64
+
+	if err := rows.Scan(&session.SessionID, &session.PlayerID, &session.ServerID, &session.Name,
+		&session.StartedAt, &endedAt, &session.LastSeenAt); err != nil {
+		return err
+	}
+
+	session.EndedAt = endedAt.Int64
+
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mysql
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/refractor"
+)
+
+type spamSettingsRepo struct {
+	db *sql.DB
+}
+
+func NewSpamSettingsRepository(db *sql.DB) refractor.SpamSettingsRepository {
+	return &spamSettingsRepo{
+		db: db,
+	}
+}
+
+func (r *spamSettingsRepo) Create(settings *refractor.SpamSettings) error {
+	query := `
+		INSERT INTO SpamSettings (ServerID, Enabled, MessageLimit, MessageWindow, RepeatLimit, CapsPercent,
+			CapsMinLength, MuteDuration)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+	`
+
+	_, err := r.db.Exec(query, settings.ServerID, settings.Enabled, settings.MessageLimit, settings.MessageWindow,
+		settings.RepeatLimit, settings.CapsPercent, settings.CapsMinLength, settings.MuteDuration)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	return nil
+}
+
+func (r *spamSettingsRepo) FindByServerID(serverID int64) (*refractor.SpamSettings, error) {
+	query := "SELECT * FROM SpamSettings WHERE ServerID = ?;"
+	row := r.db.QueryRow(query, serverID)
+
+	settings := &refractor.SpamSettings{}
+	if err := row.Scan(&settings.ServerID, &settings.Enabled, &settings.MessageLimit, &settings.MessageWindow,
+		&settings.RepeatLimit, &settings.CapsPercent, &settings.CapsMinLength, &settings.MuteDuration); err != nil {
+		return nil, wrapError(err)
+	}
+
+	return settings, nil
+}
+
+func (r *spamSettingsRepo) Update(serverID int64, args refractor.UpdateArgs) (*refractor.SpamSettings, error) {
+	query, values := buildUpdateQuery("SpamSettings", serverID, "ServerID", args)
+
+	_, err := r.db.Exec(query, values...)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	// Retrieve updated settings
+	return r.FindByServerID(serverID)
+}
@@ -0,0 +1,247 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package mysql
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/refractor"
+	"strings"
+)
+
+type webhookRepo struct {
+	db *sql.DB
+}
+
+func NewWebhookRepository(db *sql.DB) refractor.WebhookRepository {
+	return &webhookRepo{
+		db: db,
+	}
+}
+
+func (r *webhookRepo) Create(webhook *refractor.Webhook) error {
+	query := "INSERT INTO Webhooks (Name, URL, Secret, Events, Enabled, CreatedAt) VALUES (?, ?, ?, ?, ?, ?);"
+
+	res, err := r.db.Exec(query, webhook.Name, webhook.URL, webhook.Secret, joinEvents(webhook.Events),
+		webhook.Enabled, webhook.CreatedAt)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	webhook.WebhookID = id
+
+	return nil
+}
+
+func (r *webhookRepo) FindByID(id int64) (*refractor.Webhook, error) {
+	query := "SELECT * FROM Webhooks WHERE WebhookID = ?;"
+	row := r.db.QueryRow(query, id)
+
+	foundWebhook := &refractor.Webhook{}
+	if err := r.scanRow(row, foundWebhook); err != nil {
+		return nil, wrapError(err)
+	}
+
+	return foundWebhook, nil
+}
+
+func (r *webhookRepo) FindAll() ([]*refractor.Webhook, error) {
+	query := "SELECT * FROM Webhooks;"
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	var foundWebhooks []*refractor.Webhook
+
+	for rows.Next() {
+		webhook := &refractor.Webhook{}
+
+		if err := r.scanRows(rows, webhook); err != nil {
+			return nil, wrapError(err)
+		}
+
+		foundWebhooks = append(foundWebhooks, webhook)
+	}
+
+	return foundWebhooks, nil
+}
+
+func (r *webhookRepo) Update(id int64, args refractor.UpdateArgs) (*refractor.Webhook, error) {
+	// Events are stored as a comma separated list
+	if events, ok := args["Events"].([]string); ok {
+		args["Events"] = joinEvents(events)
+	}
+
+	query, values := buildUpdateQuery("Webhooks", id, "WebhookID", args)
+
+	_, err := r.db.Exec(query, values...)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	// Retrieve updated webhook
+	return r.FindByID(id)
+}
+
+func (r *webhookRepo) Delete(id int64) error {
+	query := "DELETE FROM Webhooks WHERE WebhookID = ?;"
+
+	res, err := r.db.Exec(query, id)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	if rowsAffected <= 0 {
+		return wrapError(sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+func joinEvents(events []string) string {
+	return strings.Join(events, ",")
+}
+
+func splitEvents(events string) []string {
+	if events == "" {
+		return []string{}
+	}
+
+	return strings.Split(events, ",")
+}
+
+// Scan helpers
+func (r *webhookRepo) scanRow(row *sql.Row, webhook *refractor.Webhook) error {
+	var events string
+
+	if err := row.Scan(&webhook.WebhookID, &webhook.Name, &webhook.URL, &webhook.Secret, &events, &webhook.Enabled,
+		&webhook.CreatedAt); err != nil {
+		return err
+	}
+
+	webhook.Events = splitEvents(events)
+
+	return nil
+}
+
+func (r *webhookRepo) scanRows(rows *sql.Rows, webhook *refractor.Webhook) error {
+	var events string
+
+	if err := rows.Scan(&webhook.WebhookID, &webhook.Name, &webhook.URL, &webhook.Secret, &events, &webhook.Enabled,
+		&webhook.CreatedAt); err != nil {
+		return err
+	}
+
+	webhook.Events = splitEvents(events)
+
+	return nil
+}
+
+type webhookDeliveryRepo struct {
+	db *sql.DB
+}
+
+func NewWebhookDeliveryRepository(db *sql.DB) refractor.WebhookDeliveryRepository {
+	return &webhookDeliveryRepo{
+		db: db,
+	}
+}
+
+func (r *webhookDeliveryRepo) Create(delivery *refractor.WebhookDelivery) error {
+	query := `
+		INSERT INTO WebhookDeliveries (WebhookID, Event, Payload, Status, Attempts, ResponseStatus, Error, CreatedAt,
+			CompletedAt)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+	`
+
+	res, err := r.db.Exec(query, delivery.WebhookID, delivery.Event, delivery.Payload, delivery.Status,
+		delivery.Attempts, delivery.ResponseStatus, delivery.Error, delivery.CreatedAt, delivery.CompletedAt)
+	if err != nil {
+		return wrapError(err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return wrapError(err)
+	}
+
+	delivery.DeliveryID = id
+
+	return nil
+}
+
+func (r *webhookDeliveryRepo) Update(id int64, args refractor.UpdateArgs) error {
+	query, values := buildUpdateQuery("WebhookDeliveries", id, "DeliveryID", args)
+
+	if _, err := r.db.Exec(query, values...); err != nil {
+		return wrapError(err)
+	}
+
+	return nil
+}
+
+// FindManyByWebhookID gets a page of a webhook's deliveries, newest first, along with the total number of deliveries.
+func (r *webhookDeliveryRepo) FindManyByWebhookID(webhookID int64, limit int, offset int) (int, []*refractor.WebhookDelivery, error) {
+	query := "SELECT * FROM WebhookDeliveries WHERE WebhookID = ? ORDER BY DeliveryID DESC LIMIT ? OFFSET ?;"
+
+	rows, err := r.db.Query(query, webhookID, limit, offset)
+	if err != nil {
+		return 0, nil, wrapError(err)
+	}
+
+	var foundDeliveries []*refractor.WebhookDelivery
+
+	for rows.Next() {
+		delivery := &refractor.WebhookDelivery{}
+
+		if err := r.scanRows(rows, delivery); err != nil {
+			return 0, nil, wrapError(err)
+		}
+
+		foundDeliveries = append(foundDeliveries, delivery)
+	}
+
+	// Get total number of deliveries
+	query = "SELECT COUNT(1) AS Count FROM WebhookDeliveries WHERE WebhookID = ?;"
+
+	row := r.db.QueryRow(query, webhookID)
+
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return 0, nil, wrapError(err)
+	}
+
+	return count, foundDeliveries, nil
+}
+
+// Scan helpers
+func (r *webhookDeliveryRepo) scanRows(rows *sql.Rows, delivery *refractor.WebhookDelivery) error {
+	return rows.Scan(&delivery.DeliveryID, &delivery.WebhookID, &delivery.Event, &delivery.Payload, &delivery.Status,
+		&delivery.Attempts, &delivery.ResponseStatus, &delivery.Error, &delivery.CreatedAt, &delivery.CompletedAt)
+}
@@ -18,6 +18,7 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package summary
 
 import (
+	"github.com/sniddunc/refractor/internal/params"
 	"github.com/sniddunc/refractor/pkg/log"
 	"github.com/sniddunc/refractor/refractor"
 	"net/http"
@@ -26,19 +27,23 @@ import (
 type summaryService struct {
 	playerService     refractor.PlayerService
 	infractionService refractor.InfractionService
+	attachmentService refractor.AttachmentService
+	noteService       refractor.NoteService
 	log               log.Logger
 }
 
 func NewSummaryService(playerService refractor.PlayerService, infractionService refractor.InfractionService,
-	log log.Logger) refractor.SummaryService {
+	attachmentService refractor.AttachmentService, noteService refractor.NoteService, log log.Logger) refractor.SummaryService {
 	return &summaryService{
 		playerService:     playerService,
 		infractionService: infractionService,
+		attachmentService: attachmentService,
+		noteService:       noteService,
 		log:               log,
 	}
 }
 
-func (s *summaryService) GetPlayerSummary(playerID int64) (*refractor.PlayerSummary, *refractor.ServiceResponse) {
+func (s *summaryService) GetPlayerSummary(playerID int64, user params.UserMeta) (*refractor.PlayerSummary, *refractor.ServiceResponse) {
 	player, res := s.playerService.GetPlayerByID(playerID)
 	if !res.Success || player == nil {
 		return nil, res
@@ -76,13 +81,28 @@ func (s *summaryService) GetPlayerSummary(playerID int64) (*refractor.PlayerSumm
 		}
 	}
 
+	attachmentCounts, res := s.attachmentService.GetPlayerAttachmentCounts(playerID)
+	if !res.Success {
+		return nil, res
+	}
+
+	notes, res := s.noteService.GetPlayerNotes(playerID, user)
+	if !res.Success {
+		return nil, res
+	}
+
 	// Build player summary
 	playerSummary := &refractor.PlayerSummary{
-		Warnings: warnings,
-		Mutes:    mutes,
-		Kicks:    kicks,
-		Bans:     bans,
-		Player:   player,
+		Warnings:   warnings,
+		Mutes:      mutes,
+		Kicks:      kicks,
+		Bans:       bans,
+		ActiveMute: refractor.GetLongestActive(mutes),
+		ActiveBan:  refractor.GetLongestActive(bans),
+
+		AttachmentCounts: attachmentCounts,
+		Notes:            notes,
+		Player:           player,
 	}
 
 	return playerSummary, &refractor.ServiceResponse{
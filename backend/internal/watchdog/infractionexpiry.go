@@ -0,0 +1,47 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package watchdog
+
+import (
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"time"
+)
+
+// StartInfractionExpiryWatchdog starts a watchdog for timed mutes and bans. Once a mute or ban has run out, this
+// watchdog marks it as expired and notifies connected users through an "infraction-expired" websocket message.
+func StartInfractionExpiryWatchdog(infractionService refractor.InfractionService,
+	websocketService refractor.WebsocketService, log log.Logger) {
+	for {
+		// Run every 30 seconds
+		time.Sleep(time.Second * 30)
+
+		expiredInfractions, res := infractionService.ExpireInfractions()
+		if !res.Success {
+			log.Warn("Watchdog could not expire infractions. Message: %s", res.Message)
+			continue
+		}
+
+		for _, infraction := range expiredInfractions {
+			websocketService.Broadcast(&refractor.WebsocketMessage{
+				Type: "infraction-expired",
+				Body: infraction,
+			})
+		}
+	}
+}
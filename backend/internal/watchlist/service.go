@@ -0,0 +1,103 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package watchlist
+
+import (
+	"github.com/sniddunc/refractor/pkg/broadcast"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"time"
+)
+
+type watchlistService struct {
+	playerService    refractor.PlayerService
+	serverService    refractor.ServerService
+	userService      refractor.UserService
+	websocketService refractor.WebsocketService
+	joinSubscribers  []refractor.WatchedPlayerJoinSubscriber
+	log              log.Logger
+}
+
+func NewWatchlistService(playerService refractor.PlayerService, serverService refractor.ServerService,
+	userService refractor.UserService, websocketService refractor.WebsocketService,
+	log log.Logger) refractor.WatchlistService {
+	return &watchlistService{
+		playerService:    playerService,
+		serverService:    serverService,
+		userService:      userService,
+		websocketService: websocketService,
+		log:              log,
+	}
+}
+
+// OnPlayerJoin alerts staff if the joining player is on the watchlist. Players whose watch has expired are not
+// considered watched so no alert is sent for them.
+func (s *watchlistService) OnPlayerJoin(fields broadcast.Fields, serverID int64, gameConfig *refractor.GameConfig) {
+	playerGameID := fields[gameConfig.PlayerGameIDField]
+
+	player, res := s.playerService.GetPlayer(refractor.FindArgs{
+		gameConfig.PlayerGameIDField: playerGameID,
+	})
+	if !res.Success {
+		s.log.Warn("Could not get player with %s of %s to check the watchlist", gameConfig.PlayerGameIDField,
+			playerGameID)
+		return
+	}
+
+	if player == nil || !player.Watched {
+		return
+	}
+
+	alert := &refractor.WatchedPlayerJoin{
+		PlayerID:       player.PlayerID,
+		Name:           player.CurrentName,
+		ServerID:       serverID,
+		WatchReason:    player.WatchReason,
+		WatchedBy:      player.WatchedBy,
+		WatchedAt:      player.WatchedAt,
+		WatchExpiresAt: player.WatchExpiresAt,
+		JoinedAt:       time.Now().Unix(),
+	}
+
+	if server, _ := s.serverService.GetServerByID(serverID); server != nil {
+		alert.ServerName = server.Name
+	}
+
+	if player.WatchedBy != 0 {
+		if user, res := s.userService.GetUserByID(player.WatchedBy); res == nil {
+			alert.WatchedByName = user.Username
+		}
+	}
+
+	s.websocketService.Broadcast(&refractor.WebsocketMessage{
+		Type: "watched-player-join",
+		Body: alert,
+	})
+
+	s.notifyWatchedPlayerJoin(alert)
+}
+
+func (s *watchlistService) SubscribeWatchedPlayerJoin(sub refractor.WatchedPlayerJoinSubscriber) {
+	s.joinSubscribers = append(s.joinSubscribers, sub)
+}
+
+func (s *watchlistService) notifyWatchedPlayerJoin(alert *refractor.WatchedPlayerJoin) {
+	for _, sub := range s.joinSubscribers {
+		sub(alert)
+	}
+}
@@ -0,0 +1,149 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package watchlist
+
+import (
+	"database/sql"
+	"github.com/sniddunc/refractor/internal/mock"
+	"github.com/sniddunc/refractor/internal/player"
+	"github.com/sniddunc/refractor/internal/server"
+	"github.com/sniddunc/refractor/internal/user"
+	"github.com/sniddunc/refractor/pkg/broadcast"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_watchlistService_OnPlayerJoin(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name       string
+		mockPlayer *refractor.DBPlayer
+		joinID     string
+		wantAlert  bool
+		wantNotify bool
+	}{
+		{
+			name: "watchlist.onplayerjoin.1",
+			mockPlayer: &refractor.DBPlayer{
+				PlayerID:    1,
+				PlayFabID:   sql.NullString{String: "ABCDEF", Valid: true},
+				CurrentName: "Player",
+				Watched:     true,
+				WatchReason: sql.NullString{String: "Suspected cheating", Valid: true},
+				WatchedBy:   sql.NullInt64{Int64: 1, Valid: true},
+				WatchedAt:   sql.NullInt64{Int64: now - 60, Valid: true},
+			},
+			joinID:     "ABCDEF",
+			wantAlert:  true,
+			wantNotify: true,
+		},
+		{
+			name: "watchlist.onplayerjoin.2",
+			mockPlayer: &refractor.DBPlayer{
+				PlayerID:    1,
+				PlayFabID:   sql.NullString{String: "ABCDEF", Valid: true},
+				CurrentName: "Player",
+				Watched:     false,
+			},
+			joinID:     "ABCDEF",
+			wantAlert:  false,
+			wantNotify: false,
+		},
+		{
+			name: "watchlist.onplayerjoin.3",
+			mockPlayer: &refractor.DBPlayer{
+				PlayerID:       1,
+				PlayFabID:      sql.NullString{String: "ABCDEF", Valid: true},
+				CurrentName:    "Player",
+				Watched:        true,
+				WatchReason:    sql.NullString{String: "Suspected cheating", Valid: true},
+				WatchedBy:      sql.NullInt64{Int64: 1, Valid: true},
+				WatchedAt:      sql.NullInt64{Int64: now - 120, Valid: true},
+				WatchExpiresAt: sql.NullInt64{Int64: now - 60, Valid: true},
+			},
+			joinID:     "ABCDEF",
+			wantAlert:  false,
+			wantNotify: false,
+		},
+		{
+			name: "watchlist.onplayerjoin.4",
+			mockPlayer: &refractor.DBPlayer{
+				PlayerID:    1,
+				PlayFabID:   sql.NullString{String: "ABCDEF", Valid: true},
+				CurrentName: "Player",
+				Watched:     true,
+			},
+			joinID:     "UNKNOWN",
+			wantAlert:  false,
+			wantNotify: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			playerService := player.NewPlayerService(mock.NewMockPlayerRepository(map[int64]*refractor.DBPlayer{
+				1: tt.mockPlayer,
+			}), testLogger)
+			serverService := server.NewServerService(mock.NewMockServerRepository(map[int64]*refractor.Server{
+				1: {
+					ServerID: 1,
+					Name:     "Test Server",
+					Game:     "TestGame",
+				},
+			}), nil, testLogger)
+			userService := user.NewUserService(mock.NewMockUserRepository(mock.GetMockUsers()), testLogger)
+			websocketService := mock.NewMockWebsocketService()
+			watchlistService := NewWatchlistService(playerService, serverService, userService, websocketService,
+				testLogger)
+
+			var notified []*refractor.WatchedPlayerJoin
+			watchlistService.SubscribeWatchedPlayerJoin(func(alert *refractor.WatchedPlayerJoin) {
+				notified = append(notified, alert)
+			})
+
+			watchlistService.OnPlayerJoin(broadcast.Fields{
+				"PlayFabID": tt.joinID,
+				"Name":      "Player",
+			}, 1, mock.NewMockGame().GetConfig())
+
+			if !tt.wantAlert {
+				assert.Empty(t, websocketService.Messages, "No websocket message should have been sent")
+			} else {
+				assert.Equal(t, 1, len(websocketService.Messages), "One websocket message should have been sent")
+				assert.Equal(t, "watched-player-join", websocketService.Messages[0].Type, "Message type should match")
+
+				alert := websocketService.Messages[0].Body.(*refractor.WatchedPlayerJoin)
+				assert.Equal(t, int64(1), alert.PlayerID, "Alert player ID should match")
+				assert.Equal(t, "Test Server", alert.ServerName, "Alert server name should match")
+				assert.Equal(t, tt.mockPlayer.WatchReason.String, alert.WatchReason, "Alert reason should match")
+				assert.Equal(t, "tester", alert.WatchedByName, "Alert watched by name should match")
+			}
+
+			if !tt.wantNotify {
+				assert.Empty(t, notified, "Subscribers should not have been notified")
+			} else {
+				assert.Equal(t, 1, len(notified), "Subscribers should have been notified once")
+				assert.Equal(t, int64(1), notified[0].PlayerID, "Notified alert player ID should match")
+			}
+		})
+	}
+}
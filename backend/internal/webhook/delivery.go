@@ -0,0 +1,203 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Headers sent with every delivery. The signature header holds "sha256=" followed by the hex encoded HMAC-SHA256 of
+// the request body, keyed with the webhook's secret.
+const (
+	headerEvent     = "X-Refractor-Event"
+	headerDelivery  = "X-Refractor-Delivery"
+	headerSignature = "X-Refractor-Signature"
+)
+
+func (s *webhookService) loadWebhooks() {
+	webhooks, err := s.repo.FindAll()
+	if err != nil && err != refractor.ErrNotFound {
+		s.log.Error("Could not load webhooks. Error: %v", err)
+		return
+	}
+
+	// Copies are cached so that deliveries in progress aren't affected by later changes to a webhook
+	var cached []*refractor.Webhook
+
+	for _, webhook := range webhooks {
+		webhookCopy := *webhook
+		cached = append(cached, &webhookCopy)
+	}
+
+	s.webhooksLock.Lock()
+	s.webhooks = cached
+	s.webhooksLoaded = true
+	s.webhooksLock.Unlock()
+}
+
+// getSubscribedWebhooks returns the enabled webhooks which are subscribed to event
+func (s *webhookService) getSubscribedWebhooks(event string) []*refractor.Webhook {
+	s.webhooksLock.RLock()
+	loaded := s.webhooksLoaded
+	s.webhooksLock.RUnlock()
+
+	if !loaded {
+		s.loadWebhooks()
+	}
+
+	s.webhooksLock.RLock()
+	defer s.webhooksLock.RUnlock()
+
+	var subscribed []*refractor.Webhook
+
+	for _, webhook := range s.webhooks {
+		if webhook.SubscribedTo(event) {
+			subscribed = append(subscribed, webhook)
+		}
+	}
+
+	return subscribed
+}
+
+// dispatch records a delivery of event to each subscribed webhook and starts sending them. Deliveries are sent from
+// their own goroutines so that slow or unreachable webhooks don't hold up the subscriber which triggered the event.
+func (s *webhookService) dispatch(event string, data interface{}) {
+	webhooks := s.getSubscribedWebhooks(event)
+	if len(webhooks) < 1 {
+		return
+	}
+
+	now := time.Now().Unix()
+
+	payload, err := json.Marshal(&refractor.WebhookPayload{
+		Event:     event,
+		Timestamp: now,
+		Data:      data,
+	})
+	if err != nil {
+		s.log.Error("Could not marshal %s webhook payload. Error: %v", event, err)
+		return
+	}
+
+	for _, webhook := range webhooks {
+		delivery := &refractor.WebhookDelivery{
+			WebhookID: webhook.WebhookID,
+			Event:     event,
+			Payload:   string(payload),
+			Status:    refractor.WEBHOOK_DELIVERY_PENDING,
+			CreatedAt: now,
+		}
+
+		if err := s.deliveryRepo.Create(delivery); err != nil {
+			s.log.Error("Could not store %s delivery for webhook ID %d. Error: %v", event, webhook.WebhookID, err)
+			continue
+		}
+
+		go s.deliver(webhook, delivery)
+	}
+}
+
+// deliver sends a delivery to a webhook, retrying failed attempts with an exponential backoff. The delivery is updated
+// after every attempt so that the delivery history shows the progress of deliveries still being retried.
+func (s *webhookService) deliver(webhook *refractor.Webhook, delivery *refractor.WebhookDelivery) {
+	delay := s.retryDelay
+
+	for attempt := 1; attempt <= s.maxAttempts; attempt++ {
+		statusCode, err := s.send(webhook, delivery)
+
+		updateArgs := refractor.UpdateArgs{
+			"Attempts":       attempt,
+			"ResponseStatus": statusCode,
+			"Error":          "",
+		}
+
+		if err != nil {
+			updateArgs["Error"] = err.Error()
+		}
+
+		done := err == nil || attempt == s.maxAttempts
+
+		if done {
+			updateArgs["Status"] = refractor.WEBHOOK_DELIVERY_SUCCEEDED
+			updateArgs["CompletedAt"] = time.Now().Unix()
+
+			if err != nil {
+				updateArgs["Status"] = refractor.WEBHOOK_DELIVERY_FAILED
+				s.log.Warn("Giving up on delivery ID %d to webhook ID %d after %d attempts. Error: %v",
+					delivery.DeliveryID, webhook.WebhookID, attempt, err)
+			}
+		}
+
+		if err := s.deliveryRepo.Update(delivery.DeliveryID, updateArgs); err != nil {
+			s.log.Error("Could not update webhook delivery ID %d. Error: %v", delivery.DeliveryID, err)
+		}
+
+		if done {
+			return
+		}
+
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// send makes a single delivery attempt. The response status code is returned along with an error if the request
+// failed or the webhook responded with a non 2xx status.
+func (s *webhookService) send(webhook *refractor.Webhook, delivery *refractor.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Refractor-Webhook")
+	req.Header.Set(headerEvent, delivery.Event)
+	req.Header.Set(headerDelivery, strconv.FormatInt(delivery.DeliveryID, 10))
+	req.Header.Set(headerSignature, "sha256="+signPayload(webhook.Secret, body))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+
+	return res.StatusCode, nil
+}
+
+// signPayload returns the hex encoded HMAC-SHA256 of payload keyed with secret
+func signPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,306 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/broadcast"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type webhookService struct {
+	repo          refractor.WebhookRepository
+	deliveryRepo  refractor.WebhookDeliveryRepository
+	playerService refractor.PlayerService
+	client        *http.Client
+	maxAttempts   int
+	retryDelay    time.Duration
+	log           log.Logger
+
+	// Webhooks are cached since they are checked for every event, including every chat message. The cache is reloaded
+	// whenever a webhook is changed.
+	webhooks       []*refractor.Webhook
+	webhooksLoaded bool
+	webhooksLock   sync.RWMutex
+}
+
+func NewWebhookService(repo refractor.WebhookRepository, deliveryRepo refractor.WebhookDeliveryRepository,
+	playerService refractor.PlayerService, log log.Logger) refractor.WebhookService {
+	return &webhookService{
+		repo:          repo,
+		deliveryRepo:  deliveryRepo,
+		playerService: playerService,
+		client:        &http.Client{Timeout: config.WebhookRequestTimeout},
+		maxAttempts:   config.WebhookMaxAttempts,
+		retryDelay:    config.WebhookRetryDelay,
+		log:           log,
+	}
+}
+
+func (s *webhookService) CreateWebhook(body params.CreateWebhookParams) (*refractor.Webhook, *refractor.ServiceResponse) {
+	secret := body.Secret
+	if secret == "" {
+		generated, err := generateSecret()
+		if err != nil {
+			s.log.Error("Could not generate webhook secret. Error: %v", err)
+			return nil, refractor.InternalErrorResponse
+		}
+
+		secret = generated
+	}
+
+	newWebhook := &refractor.Webhook{
+		Name:      body.Name,
+		URL:       body.URL,
+		Secret:    secret,
+		Events:    body.Events,
+		Enabled:   true,
+		CreatedAt: time.Now().Unix(),
+	}
+
+	if err := s.repo.Create(newWebhook); err != nil {
+		s.log.Error("Could not insert new webhook into repository. Error: %v", err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	s.loadWebhooks()
+
+	return newWebhook, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Webhook created",
+	}
+}
+
+func (s *webhookService) GetAllWebhooks() ([]*refractor.Webhook, *refractor.ServiceResponse) {
+	webhooks, err := s.repo.FindAll()
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return []*refractor.Webhook{}, &refractor.ServiceResponse{
+				Success:    true,
+				StatusCode: http.StatusOK,
+				Message:    "Fetched 0 webhooks",
+			}
+		}
+
+		s.log.Error("Could not FindAll webhooks from repository. Error: %v", err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	if webhooks == nil {
+		webhooks = []*refractor.Webhook{}
+	}
+
+	return webhooks, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    fmt.Sprintf("Fetched %d webhooks", len(webhooks)),
+	}
+}
+
+func (s *webhookService) UpdateWebhook(id int64, body params.UpdateWebhookParams) (*refractor.Webhook, *refractor.ServiceResponse) {
+	updateArgs := refractor.UpdateArgs{}
+
+	if body.Name != nil {
+		updateArgs["Name"] = *body.Name
+	}
+
+	if body.URL != nil {
+		updateArgs["URL"] = *body.URL
+	}
+
+	if body.Secret != nil {
+		updateArgs["Secret"] = *body.Secret
+	}
+
+	if body.Events != nil {
+		updateArgs["Events"] = *body.Events
+	}
+
+	if body.Enabled != nil {
+		updateArgs["Enabled"] = *body.Enabled
+	}
+
+	if len(updateArgs) < 1 {
+		return nil, &refractor.ServiceResponse{
+			Success:    false,
+			StatusCode: http.StatusBadRequest,
+			Message:    "No updated values provided",
+		}
+	}
+
+	updatedWebhook, err := s.repo.Update(id, updateArgs)
+	if err != nil {
+		if err == refractor.ErrNotFound {
+			return nil, &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			}
+		}
+
+		s.log.Error("Could not update webhook of ID %d in repo. Error: %v", id, err)
+		return nil, refractor.InternalErrorResponse
+	}
+
+	s.loadWebhooks()
+
+	return updatedWebhook, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Webhook updated",
+	}
+}
+
+func (s *webhookService) DeleteWebhook(id int64) *refractor.ServiceResponse {
+	if err := s.repo.Delete(id); err != nil {
+		if err == refractor.ErrNotFound {
+			return &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			}
+		}
+
+		s.log.Error("Could not delete webhook with ID %d. Error: %v", id, err)
+		return refractor.InternalErrorResponse
+	}
+
+	s.loadWebhooks()
+
+	return &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    "Webhook deleted",
+	}
+}
+
+func (s *webhookService) GetWebhookDeliveries(id int64, body params.SearchParams) (int, []*refractor.WebhookDelivery, *refractor.ServiceResponse) {
+	// Make sure webhook exists
+	if _, err := s.repo.FindByID(id); err != nil {
+		if err == refractor.ErrNotFound {
+			return 0, nil, &refractor.ServiceResponse{
+				Success:    false,
+				StatusCode: http.StatusBadRequest,
+				Message:    config.MessageInvalidIDProvided,
+			}
+		}
+
+		s.log.Error("Could not get webhook of ID %d from repo. Error: %v", id, err)
+		return 0, nil, refractor.InternalErrorResponse
+	}
+
+	count, deliveries, err := s.deliveryRepo.FindManyByWebhookID(id, body.Limit, body.Offset)
+	if err != nil && err != refractor.ErrNotFound {
+		s.log.Error("Could not get deliveries of webhook ID %d. Error: %v", id, err)
+		return 0, nil, refractor.InternalErrorResponse
+	}
+
+	if deliveries == nil {
+		deliveries = []*refractor.WebhookDelivery{}
+	}
+
+	return count, deliveries, &refractor.ServiceResponse{
+		Success:    true,
+		StatusCode: http.StatusOK,
+		Message:    fmt.Sprintf("Fetched %d webhook deliveries", len(deliveries)),
+	}
+}
+
+func (s *webhookService) OnInfractionCreate(infraction *refractor.Infraction) {
+	s.dispatch(refractor.WEBHOOK_EVENT_INFRACTION_CREATE, infraction)
+}
+
+func (s *webhookService) OnInfractionUpdate(infraction *refractor.Infraction) {
+	s.dispatch(refractor.WEBHOOK_EVENT_INFRACTION_UPDATE, infraction)
+}
+
+func (s *webhookService) OnInfractionDelete(infraction *refractor.Infraction) {
+	s.dispatch(refractor.WEBHOOK_EVENT_INFRACTION_DELETE, infraction)
+}
+
+func (s *webhookService) OnPlayerJoin(fields broadcast.Fields, serverID int64, gameConfig *refractor.GameConfig) {
+	s.dispatchPlayerEvent(refractor.WEBHOOK_EVENT_PLAYER_JOIN, fields, serverID, gameConfig)
+}
+
+func (s *webhookService) OnPlayerQuit(fields broadcast.Fields, serverID int64, gameConfig *refractor.GameConfig) {
+	s.dispatchPlayerEvent(refractor.WEBHOOK_EVENT_PLAYER_QUIT, fields, serverID, gameConfig)
+}
+
+func (s *webhookService) OnPlayerUpdate(updated *refractor.Player) {
+	s.dispatch(refractor.WEBHOOK_EVENT_PLAYER_UPDATE, updated)
+}
+
+func (s *webhookService) OnServerOnline(serverID int64) {
+	s.dispatch(refractor.WEBHOOK_EVENT_SERVER_ONLINE, &refractor.WebhookServerEvent{ServerID: serverID})
+}
+
+func (s *webhookService) OnServerOffline(serverID int64) {
+	s.dispatch(refractor.WEBHOOK_EVENT_SERVER_OFFLINE, &refractor.WebhookServerEvent{ServerID: serverID})
+}
+
+func (s *webhookService) OnChatReceive(msgBody *refractor.ChatReceiveBody, serverID int64, gameConfig *refractor.GameConfig) {
+	s.dispatch(refractor.WEBHOOK_EVENT_CHAT, msgBody)
+}
+
+func (s *webhookService) OnWatchedPlayerJoin(alert *refractor.WatchedPlayerJoin) {
+	s.dispatch(refractor.WEBHOOK_EVENT_WATCHED_PLAYER_JOIN, alert)
+}
+
+// dispatchPlayerEvent sends a player join or quit event. The player's Refractor ID is looked up so that receivers
+// don't need to know about game specific IDs, but only if a webhook is subscribed to the event.
+func (s *webhookService) dispatchPlayerEvent(event string, fields broadcast.Fields, serverID int64,
+	gameConfig *refractor.GameConfig) {
+	if len(s.getSubscribedWebhooks(event)) < 1 {
+		return
+	}
+
+	data := &refractor.WebhookPlayerEvent{
+		ServerID:     serverID,
+		PlayerGameID: fields[gameConfig.PlayerGameIDField],
+		Name:         fields["Name"],
+	}
+
+	player, res := s.playerService.GetPlayer(refractor.FindArgs{
+		gameConfig.PlayerGameIDField: data.PlayerGameID,
+	})
+	if res.Success && player != nil {
+		data.PlayerID = player.PlayerID
+	}
+
+	s.dispatch(event, data)
+}
+
+// generateSecret creates a random hex encoded secret for webhooks created without one
+func generateSecret() (string, error) {
+	secret := make([]byte, config.WebhookSecretByteCount)
+
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
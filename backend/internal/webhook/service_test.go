@@ -0,0 +1,220 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/sniddunc/refractor/internal/mock"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/internal/player"
+	"github.com/sniddunc/refractor/pkg/config"
+	"github.com/sniddunc/refractor/pkg/log"
+	"github.com/sniddunc/refractor/refractor"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_webhookService_Deliveries(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+	secret := "0123456789abcdef0123456789abcdef"
+
+	tests := []struct {
+		name         string
+		events       []string
+		enabled      bool
+		failFirst    int
+		wantRequests int
+		wantStatus   string
+		wantAttempts int
+	}{
+		{
+			name:         "webhook.deliveries.1",
+			events:       []string{refractor.WEBHOOK_EVENT_SERVER_ONLINE},
+			enabled:      true,
+			failFirst:    0,
+			wantRequests: 1,
+			wantStatus:   refractor.WEBHOOK_DELIVERY_SUCCEEDED,
+			wantAttempts: 1,
+		},
+		{
+			name:         "webhook.deliveries.2",
+			events:       []string{refractor.WEBHOOK_EVENT_SERVER_ONLINE, refractor.WEBHOOK_EVENT_CHAT},
+			enabled:      true,
+			failFirst:    2,
+			wantRequests: 3,
+			wantStatus:   refractor.WEBHOOK_DELIVERY_SUCCEEDED,
+			wantAttempts: 3,
+		},
+		{
+			name:         "webhook.deliveries.3",
+			events:       []string{refractor.WEBHOOK_EVENT_SERVER_ONLINE},
+			enabled:      true,
+			failFirst:    10,
+			wantRequests: 3,
+			wantStatus:   refractor.WEBHOOK_DELIVERY_FAILED,
+			wantAttempts: 3,
+		},
+		{
+			name:         "webhook.deliveries.4",
+			events:       []string{refractor.WEBHOOK_EVENT_SERVER_OFFLINE},
+			enabled:      true,
+			wantRequests: 0,
+		},
+		{
+			name:         "webhook.deliveries.5",
+			events:       []string{refractor.WEBHOOK_EVENT_SERVER_ONLINE},
+			enabled:      false,
+			wantRequests: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lock sync.Mutex
+			requests := 0
+
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := ioutil.ReadAll(r.Body)
+
+				mac := hmac.New(sha256.New, []byte(secret))
+				mac.Write(body)
+				assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), r.Header.Get("X-Refractor-Signature"),
+					"Signature should match")
+				assert.Equal(t, refractor.WEBHOOK_EVENT_SERVER_ONLINE, r.Header.Get("X-Refractor-Event"),
+					"Event header should match")
+
+				payload := &refractor.WebhookPayload{Data: &refractor.WebhookServerEvent{}}
+				assert.Nil(t, json.Unmarshal(body, payload), "Payload should be valid JSON")
+				assert.Equal(t, int64(1), payload.Data.(*refractor.WebhookServerEvent).ServerID,
+					"Payload server ID should match")
+
+				lock.Lock()
+				requests++
+				fail := requests <= tt.failFirst
+				lock.Unlock()
+
+				if fail {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer receiver.Close()
+
+			deliveryRepo := mock.NewMockWebhookDeliveryRepository(map[int64]*refractor.WebhookDelivery{})
+			playerService := player.NewPlayerService(mock.NewMockPlayerRepository(map[int64]*refractor.DBPlayer{}),
+				testLogger)
+			service := NewWebhookService(mock.NewMockWebhookRepository(map[int64]*refractor.Webhook{
+				1: {
+					WebhookID: 1,
+					Name:      "Test",
+					URL:       receiver.URL,
+					Secret:    secret,
+					Events:    tt.events,
+					Enabled:   tt.enabled,
+				},
+			}), deliveryRepo, playerService, testLogger).(*webhookService)
+			service.maxAttempts = 3
+			service.retryDelay = time.Millisecond
+
+			service.OnServerOnline(1)
+
+			// Deliveries are recorded before they are sent, so nothing is sent if nothing was recorded
+			if tt.wantRequests < 1 {
+				assert.Empty(t, deliveryRepo.Deliveries, "No deliveries should have been recorded")
+				return
+			}
+
+			// Deliveries are sent in the background, so wait for the receiver to handle the last attempt
+			var delivery *refractor.WebhookDelivery
+			completed := assert.Eventually(t, func() bool {
+				count, deliveries, _ := deliveryRepo.FindManyByWebhookID(1, 10, 0)
+				if count != 1 || deliveries[0].CompletedAt == 0 {
+					return false
+				}
+
+				delivery = deliveries[0]
+				return true
+			}, 5*time.Second, time.Millisecond, "One delivery should have been completed")
+			if !completed {
+				return
+			}
+
+			lock.Lock()
+			assert.Equal(t, tt.wantRequests, requests, "Number of requests should match")
+			lock.Unlock()
+
+			assert.Equal(t, tt.wantStatus, delivery.Status, "Delivery status should match")
+			assert.Equal(t, tt.wantAttempts, delivery.Attempts, "Delivery attempts should match")
+		})
+	}
+}
+
+func Test_webhookService_CreateWebhook(t *testing.T) {
+	testLogger, _ := log.NewLogger(true, false)
+
+	tests := []struct {
+		name       string
+		body       params.CreateWebhookParams
+		wantSecret string
+	}{
+		{
+			name: "webhook.createwebhook.1",
+			body: params.CreateWebhookParams{
+				Name:   "Test",
+				URL:    "https://example.com/hook",
+				Secret: "0123456789abcdef",
+				Events: []string{refractor.WEBHOOK_EVENT_CHAT},
+			},
+			wantSecret: "0123456789abcdef",
+		},
+		{
+			name: "webhook.createwebhook.2",
+			body: params.CreateWebhookParams{
+				Name:   "Test",
+				URL:    "https://example.com/hook",
+				Events: []string{refractor.WEBHOOK_EVENT_CHAT},
+			},
+			wantSecret: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewWebhookService(mock.NewMockWebhookRepository(map[int64]*refractor.Webhook{}),
+				mock.NewMockWebhookDeliveryRepository(map[int64]*refractor.WebhookDelivery{}), nil, testLogger)
+
+			webhook, res := service.CreateWebhook(tt.body)
+			assert.True(t, res.Success, "Webhook should have been created")
+			assert.True(t, webhook.Enabled, "Webhook should be enabled")
+
+			if tt.wantSecret != "" {
+				assert.Equal(t, tt.wantSecret, webhook.Secret, "Secret should match")
+			} else {
+				assert.Equal(t, config.WebhookSecretByteCount*2, len(webhook.Secret), "A secret should have been generated")
+			}
+		})
+	}
+}
@@ -26,20 +26,22 @@ import (
 )
 
 type websocketService struct {
-	pool                *websocket.Pool
-	userService         refractor.UserService
-	playerService       refractor.PlayerService
-	log                 log.Logger
-	chatSendSubscribers []refractor.ChatSendSubscriber
+	pool                   *websocket.Pool
+	userService            refractor.UserService
+	playerService          refractor.PlayerService
+	log                    log.Logger
+	chatSendSubscribers    []refractor.ChatSendSubscriber
+	whisperSendSubscribers []refractor.WhisperSendSubscriber
 }
 
 func NewWebsocketService(playerService refractor.PlayerService, userService refractor.UserService, log log.Logger) refractor.WebsocketService {
 	return &websocketService{
-		pool:                websocket.NewPool(log),
-		playerService:       playerService,
-		userService:         userService,
-		log:                 log,
-		chatSendSubscribers: []refractor.ChatSendSubscriber{},
+		pool:                   websocket.NewPool(log),
+		playerService:          playerService,
+		userService:            userService,
+		log:                    log,
+		chatSendSubscribers:    []refractor.ChatSendSubscriber{},
+		whisperSendSubscribers: []refractor.WhisperSendSubscriber{},
 	}
 }
 
@@ -48,7 +50,7 @@ func (s *websocketService) Broadcast(message *refractor.WebsocketMessage) {
 }
 
 func (s *websocketService) CreateClient(userID int64, conn net.Conn) {
-	client := websocket.NewClient(userID, conn, s.pool, s.log, s.sendChatHandler)
+	client := websocket.NewClient(userID, conn, s.pool, s.log, s.sendChatHandler, s.sendWhisperHandler)
 
 	s.pool.Register <- client
 	client.Read()
@@ -64,6 +66,7 @@ func (s *websocketService) sendChatHandler(msgBody *websocket.SendChatBody) {
 
 	transformed := &refractor.ChatSendBody{
 		ServerID:   msgBody.ServerID,
+		UserID:     user.UserID,
 		Message:    msgBody.Message,
 		Sender:     user.Username,
 		SentByUser: true,
@@ -74,6 +77,19 @@ func (s *websocketService) sendChatHandler(msgBody *websocket.SendChatBody) {
 	}
 }
 
+func (s *websocketService) sendWhisperHandler(msgBody *websocket.SendWhisperBody) {
+	transformed := &refractor.WhisperSendBody{
+		ServerID: msgBody.ServerID,
+		PlayerID: msgBody.PlayerID,
+		UserID:   msgBody.UserID,
+		Message:  msgBody.Message,
+	}
+
+	for _, sub := range s.whisperSendSubscribers {
+		sub(transformed)
+	}
+}
+
 func (s *websocketService) StartPool() {
 	s.pool.Start()
 }
@@ -153,3 +169,7 @@ func (s *websocketService) OnServerOffline(serverID int64) {
 func (s *websocketService) SubscribeChatSend(subscriber refractor.ChatSendSubscriber) {
 	s.chatSendSubscribers = append(s.chatSendSubscribers, subscriber)
 }
+
+func (s *websocketService) SubscribeWhisperSend(subscriber refractor.WhisperSendSubscriber) {
+	s.whisperSendSubscribers = append(s.whisperSendSubscribers, subscriber)
+}
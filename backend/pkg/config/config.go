@@ -17,7 +17,10 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 
 package config
 
-import "math"
+import (
+	"math"
+	"time"
+)
 
 var (
 	// Auth
@@ -26,6 +29,11 @@ var (
 	PasswordMinLen = 8
 	PasswordMaxLen = 80
 
+	// System user which automated actions are attributed to. The username is longer than UsernameMaxLen so that it
+	// can never clash with a regular user.
+	SystemUserUsername = "Refractor System User"
+	SystemUserEmail    = "system@refractor.internal"
+
 	// Server
 	ServerNameMinLen     = 1
 	ServerNameMaxLen     = 32
@@ -40,6 +48,90 @@ var (
 	InfractionDurationMax        = math.MaxInt32
 	RecentInfractionsReturnCount = 20
 
+	// Escalation policies
+	EscalationPolicyNameMinLen = 1
+	EscalationPolicyNameMaxLen = 64
+	EscalationTriggerCountMax  = 100
+	EscalationMaxDepth         = 5 // the max number of escalations which can be chained off a single infraction
+
+	// Chat filter
+	ChatFilterPatternMinLen = 1
+	ChatFilterPatternMaxLen = 256
+
+	// Spam detection. The defaults are used for servers which have not had their spam settings changed.
+	SpamMessageLimitMax      = 100
+	SpamMessageWindowMax     = 3600 // seconds
+	SpamRepeatLimitMax       = 100
+	SpamCapsMinLengthMax     = 256
+	SpamStrikeExpiry         = 30 * time.Minute // how long after a spam warning further spam results in a mute
+	SpamDefaultMessageLimit  = 6
+	SpamDefaultMessageWindow = 10
+	SpamDefaultRepeatLimit   = 3
+	SpamDefaultCapsPercent   = 80
+	SpamDefaultCapsMinLength = 10
+	SpamDefaultMuteDuration  = 10
+
+	// Chat snapshots attached to infractions
+	ChatSnapshotSize   = 20
+	ChatSnapshotMaxAge = time.Hour // messages older than this when the infraction is created are left out
+
+	// Private messages sent to players
+	WhisperMessageMinLen = 1
+	WhisperMessageMaxLen = 256
+
+	// Outgoing webhooks. Failed deliveries are retried after WebhookRetryDelay, doubling after each attempt.
+	WebhookNameMinLen      = 1
+	WebhookNameMaxLen      = 64
+	WebhookURLMaxLen       = 512
+	WebhookSecretMinLen    = 16
+	WebhookSecretMaxLen    = 128
+	WebhookRequestTimeout  = 10 * time.Second
+	WebhookMaxAttempts     = 5
+	WebhookRetryDelay      = 10 * time.Second
+	WebhookSecretByteCount = 32 // length of generated secrets before hex encoding
+
+	// Discord channels
+	DiscordChannelNameMinLen = 1
+	DiscordChannelNameMaxLen = 64
+	DiscordRequestTimeout    = 10 * time.Second
+	DiscordUsername          = "Refractor"
+	DiscordFieldValueMaxLen  = 1024 // longer embed field values are rejected by Discord
+
+	// Scheduled commands and announcements
+	ScheduleNameMinLen    = 1
+	ScheduleNameMaxLen    = 64
+	SchedulePayloadMinLen = 1
+	SchedulePayloadMaxLen = 512
+	ScheduleOutputMaxLen  = 4096 // longer command output is truncated before being stored
+
+	// RCON console
+	RCONCommandMinLen       = 1
+	RCONCommandMaxLen       = 512
+	RCONRulePrefixMinLen    = 1
+	RCONRulePrefixMaxLen    = 64
+	RCONConsoleOutputMaxLen = 8192 // longer command output is truncated before being stored
+
+	// Appeals
+	AppealStatementMinLen = 1
+	AppealStatementMaxLen = 4096
+	AppealNotesMinLen     = 1
+	AppealNotesMaxLen     = 4096
+
+	// Comments
+	CommentBodyMinLen = 1
+	CommentBodyMaxLen = 2048
+
+	// Player notes
+	NoteBodyMinLen = 1
+	NoteBodyMaxLen = 2048
+
+	// Attachments
+	AttachmentMaxSize        = int64(20 * 1024 * 1024) // 20 MB
+	AttachmentFileNameMaxLen = 255
+
+	// Ban imports
+	BanImportMaxSize = int64(10 * 1024 * 1024) // 10 MB
+
 	// Search
 	SearchTermMinLen = 1
 	SearchTermMaxLen = 64
@@ -50,4 +142,7 @@ var (
 
 	// Players
 	RecentPlayersMaxSize = 22
+	WatchReasonMaxLen    = 1024
+	PlayerGameIDMaxLen   = 36 // long enough for a hyphenated Minecraft UUID
+	PlayerNameMaxLen     = 128
 )
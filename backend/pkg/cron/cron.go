@@ -0,0 +1,217 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+// Package cron parses standard five field cron expressions: minute, hour, day of month, month and day of week.
+//
+// Each field may be a wildcard (*), a number, a range (1-5), a step (*/15 or 0-30/10) or a comma separated list of
+// any of these. Only numeric values are supported. Days of the week run from 0 (Sunday) to 6, and 7 is also accepted
+// as Sunday. As with most cron implementations, if both the day of month and day of week are restricted then a time
+// matches if either of them match.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression. Each field is stored as a bitset of the values it matches.
+type Schedule struct {
+	minutes     uint64
+	hours       uint64
+	daysOfMonth uint64
+	months      uint64
+	daysOfWeek  uint64
+
+	// anyDayOfMonth and anyDayOfWeek are true if the field was a wildcard. They decide how the day fields combine.
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+type bounds struct {
+	name string
+	min  int
+	max  int
+}
+
+var fieldBounds = []bounds{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// Parse parses a five field cron expression.
+func Parse(expr string) (*Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(fieldBounds) {
+		return nil, fmt.Errorf("expected %d fields but found %d", len(fieldBounds), len(fields))
+	}
+
+	var bits [5]uint64
+
+	for i, field := range fields {
+		fieldBits, err := parseField(field, fieldBounds[i])
+		if err != nil {
+			return nil, err
+		}
+
+		bits[i] = fieldBits
+	}
+
+	// Treat 7 as Sunday
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = (bits[4] | 1) &^ (1 << 7)
+	}
+
+	return &Schedule{
+		minutes:       bits[0],
+		hours:         bits[1],
+		daysOfMonth:   bits[2],
+		months:        bits[3],
+		daysOfWeek:    bits[4],
+		anyDayOfMonth: strings.HasPrefix(fields[2], "*"),
+		anyDayOfWeek:  strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// parseField parses a single field into a bitset of the values it matches.
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		start, end, step := b.min, b.max, 1
+		rangePart := part
+
+		if i := strings.Index(part, "/"); i >= 0 {
+			parsedStep, err := strconv.Atoi(part[i+1:])
+			if err != nil || parsedStep < 1 {
+				return 0, fmt.Errorf("invalid step in %s field: %s", b.name, part)
+			}
+
+			step = parsedStep
+			rangePart = part[:i]
+		}
+
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			i := strings.Index(rangePart, "-")
+
+			var err error
+			if start, err = parseValue(rangePart[:i], b); err != nil {
+				return 0, err
+			}
+
+			if end, err = parseValue(rangePart[i+1:], b); err != nil {
+				return 0, err
+			}
+
+			if start > end {
+				return 0, fmt.Errorf("invalid range in %s field: %s", b.name, rangePart)
+			}
+		default:
+			value, err := parseValue(rangePart, b)
+			if err != nil {
+				return 0, err
+			}
+
+			start = value
+
+			// A single value only matches itself unless it is the start of a step, e.g. 5/15
+			if step == 1 {
+				end = value
+			}
+		}
+
+		for value := start; value <= end; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+
+	return bits, nil
+}
+
+func parseValue(value string, b bounds) (int, error) {
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value in %s field: %s", b.name, value)
+	}
+
+	if parsed < b.min || parsed > b.max {
+		return 0, fmt.Errorf("%s must be between %d and %d", b.name, b.min, b.max)
+	}
+
+	return parsed, nil
+}
+
+// Matches returns true if the schedule matches the minute t falls in.
+func (s *Schedule) Matches(t time.Time) bool {
+	return s.minutes&(1<<uint(t.Minute())) != 0 &&
+		s.hours&(1<<uint(t.Hour())) != 0 &&
+		s.months&(1<<uint(t.Month())) != 0 &&
+		s.matchesDay(t)
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dayOfMonth := s.daysOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := s.daysOfWeek&(1<<uint(t.Weekday())) != 0
+
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+
+	return dayOfMonth || dayOfWeek
+}
+
+// Next returns the start of the first minute after t which the schedule matches. The zero time is returned if the
+// schedule doesn't match any time in the next five years, e.g. for 30 February.
+func (s *Schedule) Next(t time.Time) time.Time {
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	limit := next.AddDate(5, 0, 0)
+	loc := next.Location()
+
+	for next.Before(limit) {
+		year, month, day := next.Date()
+
+		if s.months&(1<<uint(month)) == 0 {
+			next = time.Date(year, month+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !s.matchesDay(next) {
+			next = time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if s.hours&(1<<uint(next.Hour())) == 0 {
+			next = time.Date(year, month, day, next.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+
+		if s.minutes&(1<<uint(next.Minute())) == 0 {
+			next = next.Add(time.Minute)
+			continue
+		}
+
+		return next
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,118 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package cron
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr bool
+	}{
+		{name: "cron.parse.1", expr: "*/20 * * * *", wantErr: false},
+		{name: "cron.parse.2", expr: "55 4 * * *", wantErr: false},
+		{name: "cron.parse.3", expr: "0 9-17/2 1,15 * 1-5", wantErr: false},
+		{name: "cron.parse.4", expr: "0 0 * * 7", wantErr: false},
+		{name: "cron.parse.5", expr: "* * * *", wantErr: true},
+		{name: "cron.parse.6", expr: "60 * * * *", wantErr: true},
+		{name: "cron.parse.7", expr: "*/0 * * * *", wantErr: true},
+		{name: "cron.parse.8", expr: "0 17-9 * * *", wantErr: true},
+		{name: "cron.parse.9", expr: "0 0 0 * *", wantErr: true},
+		{name: "cron.parse.10", expr: "a * * * *", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			assert.Equal(t, tt.wantErr, err != nil, "Parse error should match. Error: %v", err)
+		})
+	}
+}
+
+func TestSchedule_Matches(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		time time.Time
+		want bool
+	}{
+		{name: "cron.matches.1", expr: "*/20 * * * *", time: time.Date(2021, 3, 10, 10, 40, 30, 0, time.UTC), want: true},
+		{name: "cron.matches.2", expr: "*/20 * * * *", time: time.Date(2021, 3, 10, 10, 41, 0, 0, time.UTC), want: false},
+		{name: "cron.matches.3", expr: "55 4 * * *", time: time.Date(2021, 3, 10, 4, 55, 0, 0, time.UTC), want: true},
+		{name: "cron.matches.4", expr: "55 4 * * *", time: time.Date(2021, 3, 10, 16, 55, 0, 0, time.UTC), want: false},
+		// 14 March 2021 is a Sunday
+		{name: "cron.matches.5", expr: "0 12 * * 7", time: time.Date(2021, 3, 14, 12, 0, 0, 0, time.UTC), want: true},
+		{name: "cron.matches.6", expr: "0 12 * * 1-5", time: time.Date(2021, 3, 14, 12, 0, 0, 0, time.UTC), want: false},
+		// Restricted day of month and day of week match if either matches
+		{name: "cron.matches.7", expr: "0 12 1 * 0", time: time.Date(2021, 3, 14, 12, 0, 0, 0, time.UTC), want: true},
+		{name: "cron.matches.8", expr: "0 12 1 * 1", time: time.Date(2021, 3, 14, 12, 0, 0, 0, time.UTC), want: false},
+		{name: "cron.matches.9", expr: "5/15 * * * *", time: time.Date(2021, 3, 14, 12, 35, 0, 0, time.UTC), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := Parse(tt.expr)
+			assert.Nil(t, err, "Expression should have been parsed")
+			assert.Equal(t, tt.want, schedule.Matches(tt.time), "Matches result should match")
+		})
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{
+			name: "cron.next.1",
+			expr: "*/20 * * * *",
+			from: time.Date(2021, 3, 10, 10, 40, 0, 0, time.UTC),
+			want: time.Date(2021, 3, 10, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "cron.next.2",
+			expr: "55 4 * * *",
+			from: time.Date(2021, 12, 31, 5, 0, 0, 0, time.UTC),
+			want: time.Date(2022, 1, 1, 4, 55, 0, 0, time.UTC),
+		},
+		{
+			name: "cron.next.3",
+			expr: "0 0 29 2 *",
+			from: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "cron.next.4",
+			expr: "0 0 30 2 *",
+			from: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+			want: time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := Parse(tt.expr)
+			assert.Nil(t, err, "Expression should have been parsed")
+			assert.Equal(t, tt.want, schedule.Next(tt.from), "Next run should match")
+		})
+	}
+}
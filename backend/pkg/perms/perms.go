@@ -34,6 +34,11 @@ const (
 	DELETE_OWN_INFRACTIONS = int64(0b0000000001000000000000000000000000000000000000000000000000000000)
 	DELETE_ANY_INFRACTION  = int64(0b0000000000100000000000000000000000000000000000000000000000000000)
 
+	MANAGE_ESCALATION_POLICIES = int64(0b0000000000010000000000000000000000000000000000000000000000000000)
+	REVIEW_APPEALS             = int64(0b0000000000001000000000000000000000000000000000000000000000000000)
+	MANAGE_CHAT_FILTERS        = int64(0b0000000000000100000000000000000000000000000000000000000000000000)
+	RCON_CONSOLE               = int64(0b0000000000000010000000000000000000000000000000000000000000000000)
+
 	DEFAULT_PERMS = LOG_WARNING | LOG_MUTE | LOG_KICK | LOG_BAN | EDIT_OWN_INFRACTIONS // 2233785415175766016
 )
 
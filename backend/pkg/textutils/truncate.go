@@ -0,0 +1,38 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package textutils
+
+// Truncate shortens value to at most maxLen characters. If value had to be cut off, the last characters are
+// replaced with ellipsis so that the result including the ellipsis still fits within maxLen.
+func Truncate(value string, maxLen int, ellipsis string) string {
+	runes := []rune(value)
+	if len(runes) <= maxLen {
+		return value
+	}
+
+	if maxLen <= 0 {
+		return ""
+	}
+
+	ellipsisRunes := []rune(ellipsis)
+	if len(ellipsisRunes) >= maxLen {
+		return string(runes[:maxLen])
+	}
+
+	return string(runes[:maxLen-len(ellipsisRunes)]) + ellipsis
+}
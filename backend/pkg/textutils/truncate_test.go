@@ -0,0 +1,82 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package textutils
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTruncate(t *testing.T) {
+	type args struct {
+		value    string
+		maxLen   int
+		ellipsis string
+	}
+
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "textutils.truncate.1",
+			args: args{value: "short", maxLen: 10, ellipsis: "..."},
+			want: "short",
+		},
+		{
+			name: "textutils.truncate.2",
+			args: args{value: "exactly10!", maxLen: 10, ellipsis: "..."},
+			want: "exactly10!",
+		},
+		{
+			name: "textutils.truncate.3",
+			args: args{value: "this value is too long", maxLen: 10, ellipsis: "..."},
+			want: "this va...",
+		},
+		{
+			name: "textutils.truncate.4",
+			args: args{value: "this value is too long", maxLen: 10, ellipsis: ""},
+			want: "this value",
+		},
+		{
+			name: "textutils.truncate.5",
+			args: args{value: "ééééééé", maxLen: 5, ellipsis: "…"},
+			want: "éééé…",
+		},
+		{
+			name: "textutils.truncate.6",
+			args: args{value: "this value is too long", maxLen: 2, ellipsis: "..."},
+			want: "th",
+		},
+		{
+			name: "textutils.truncate.7",
+			args: args{value: "this value is too long", maxLen: 0, ellipsis: "..."},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Truncate(tt.args.value, tt.args.maxLen, tt.args.ellipsis)
+
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, len([]rune(got)), tt.args.maxLen, "Result should not exceed maxLen characters")
+		})
+	}
+}
@@ -0,0 +1,58 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package validation
+
+import (
+	"regexp"
+	"strings"
+)
+
+var minecraftUUIDPattern = regexp.MustCompile("^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$")
+var playFabIDPattern = regexp.MustCompile("^[0-9A-F]{16}$")
+
+// NormaliseMinecraftUUID returns a Minecraft UUID in the dashed, lowercase form reported when players join. The bool
+// is false if the UUID isn't valid.
+func NormaliseMinecraftUUID(uuid string) (string, bool) {
+	uuid = strings.ToLower(strings.TrimSpace(uuid))
+
+	return uuid, minecraftUUIDPattern.MatchString(uuid)
+}
+
+// NormalisePlayFabID returns a PlayFabID in the uppercase form reported when players join. The bool is false if the
+// PlayFabID isn't 16 hex characters.
+func NormalisePlayFabID(playFabID string) (string, bool) {
+	playFabID = strings.ToUpper(strings.TrimSpace(playFabID))
+
+	return playFabID, playFabIDPattern.MatchString(playFabID)
+}
+
+// NormalisePlayerGameID normalises a player's game ID based on the game's PlayerGameIDField. Players are looked up by
+// an exact match on their game ID, so IDs entered by hand must be normalised before they are stored. IDs of fields
+// without any rules are only trimmed.
+func NormalisePlayerGameID(playerGameIDField string, playerGameID string) (string, bool) {
+	switch playerGameIDField {
+	case "MCUUID":
+		return NormaliseMinecraftUUID(playerGameID)
+	case "PlayFabID":
+		return NormalisePlayFabID(playerGameID)
+	}
+
+	playerGameID = strings.TrimSpace(playerGameID)
+
+	return playerGameID, playerGameID != ""
+}
@@ -0,0 +1,108 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package validation
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNormalisePlayerGameID(t *testing.T) {
+	type args struct {
+		playerGameIDField string
+		playerGameID      string
+	}
+
+	tests := []struct {
+		name      string
+		args      args
+		want      string
+		wantValid bool
+	}{
+		{
+			name: "validation.playerid.1",
+			args: args{
+				playerGameIDField: "MCUUID",
+				playerGameID:      " C7A4DC4E-5D5C-4A0B-9F3B-2F1A1C9B8E7D ",
+			},
+			want:      "c7a4dc4e-5d5c-4a0b-9f3b-2f1a1c9b8e7d",
+			wantValid: true,
+		},
+		{
+			name: "validation.playerid.2",
+			args: args{
+				playerGameIDField: "MCUUID",
+				playerGameID:      "c7a4dc4e5d5c4a0b9f3b2f1a1c9b8e7d",
+			},
+			wantValid: false,
+		},
+		{
+			name: "validation.playerid.3",
+			args: args{
+				playerGameIDField: "MCUUID",
+				playerGameID:      "c7a4dc4e-5d5c-4a0b-9f3b-2f1a1c9b8e7",
+			},
+			wantValid: false,
+		},
+		{
+			name: "validation.playerid.4",
+			args: args{
+				playerGameIDField: "PlayFabID",
+				playerGameID:      "abcdef1234567890",
+			},
+			want:      "ABCDEF1234567890",
+			wantValid: true,
+		},
+		{
+			name: "validation.playerid.5",
+			args: args{
+				playerGameIDField: "PlayFabID",
+				playerGameID:      "ABCDEF123456789",
+			},
+			wantValid: false,
+		},
+		{
+			name: "validation.playerid.6",
+			args: args{
+				playerGameIDField: "PlayFabID",
+				playerGameID:      "ABCDEF123456789G",
+			},
+			wantValid: false,
+		},
+		{
+			name: "validation.playerid.7",
+			args: args{
+				playerGameIDField: "OtherID",
+				playerGameID:      " Player1 ",
+			},
+			want:      "Player1",
+			wantValid: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, valid := NormalisePlayerGameID(tt.args.playerGameIDField, tt.args.playerGameID)
+
+			assert.Equal(t, tt.wantValid, valid, "Validity should match")
+
+			if tt.wantValid {
+				assert.Equal(t, tt.want, got, "Normalised IDs should match")
+			}
+		})
+	}
+}
@@ -27,26 +27,30 @@ import (
 )
 
 type ChatSendHandler func(msgBody *SendChatBody)
+type WhisperSendHandler func(msgBody *SendWhisperBody)
 
 type Client struct {
-	ID              int64
-	UserID          int64
-	Conn            net.Conn
-	Pool            *Pool
-	ChatSendHandler ChatSendHandler
-	log             log.Logger
+	ID                 int64
+	UserID             int64
+	Conn               net.Conn
+	Pool               *Pool
+	ChatSendHandler    ChatSendHandler
+	WhisperSendHandler WhisperSendHandler
+	log                log.Logger
 }
 
 var nextClientID int64 = 1
 
-func NewClient(userID int64, conn net.Conn, pool *Pool, log log.Logger, chatSendHandler ChatSendHandler) *Client {
+func NewClient(userID int64, conn net.Conn, pool *Pool, log log.Logger, chatSendHandler ChatSendHandler,
+	whisperSendHandler WhisperSendHandler) *Client {
 	client := &Client{
-		ID:              nextClientID,
-		UserID:          userID,
-		Conn:            conn,
-		Pool:            pool,
-		ChatSendHandler: chatSendHandler,
-		log:             log,
+		ID:                 nextClientID,
+		UserID:             userID,
+		Conn:               conn,
+		Pool:               pool,
+		ChatSendHandler:    chatSendHandler,
+		WhisperSendHandler: whisperSendHandler,
+		log:                log,
 	}
 
 	nextClientID++
@@ -60,6 +64,13 @@ type SendChatBody struct {
 	Message  string `json:"message"`
 }
 
+type SendWhisperBody struct {
+	ServerID int64 `json:"serverId"`
+	PlayerID int64 `json:"playerId"`
+	UserID   int64
+	Message  string `json:"message"`
+}
+
 func (c *Client) Read() {
 	defer func() {
 		c.Pool.Unregister <- c
@@ -125,6 +136,25 @@ func (c *Client) Read() {
 			c.ChatSendHandler(msgBody)
 		}
 
+		if msg.Type == "whisper" {
+			data, err := json.Marshal(msg.Body)
+			if err != nil {
+				c.log.Error("Could not marshal whisper message body (intermediary). Error: %v", err)
+				continue
+			}
+
+			msgBody := &SendWhisperBody{}
+
+			if err := json.Unmarshal(data, msgBody); err != nil {
+				c.log.Error("Could not unmarshal whisper message body (intermediary). Error: %v", err)
+				continue
+			}
+
+			msgBody.UserID = c.UserID
+
+			c.WhisperSendHandler(msgBody)
+		}
+
 		c.log.Info("Message received from client ID %d: %v", c.ID, msg)
 	}
 }
@@ -0,0 +1,125 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package refractor
+
+import (
+	"database/sql"
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+)
+
+const (
+	APPEAL_STATUS_OPEN         = "OPEN"
+	APPEAL_STATUS_UNDER_REVIEW = "UNDER_REVIEW"
+	APPEAL_STATUS_ACCEPTED     = "ACCEPTED"
+	APPEAL_STATUS_DENIED       = "DENIED"
+)
+
+// Appeal actions describe the events recorded in an appeal's history.
+const (
+	APPEAL_ACTION_OPENED   = "OPENED"
+	APPEAL_ACTION_ASSIGNED = "ASSIGNED"
+	APPEAL_ACTION_NOTE     = "NOTE"
+	APPEAL_ACTION_ACCEPTED = "ACCEPTED"
+	APPEAL_ACTION_DENIED   = "DENIED"
+)
+
+type Appeal struct {
+	AppealID      int64          `json:"id"`
+	InfractionID  int64          `json:"infractionId"`
+	PlayerID      int64          `json:"playerId"`
+	UserID        int64          `json:"userId"`
+	ReviewerID    int64          `json:"reviewerId,omitempty"`
+	Status        string         `json:"status"`
+	Statement     string         `json:"statement"`
+	DecisionNotes string         `json:"decisionNotes,omitempty"`
+	CreatedAt     int64          `json:"createdAt"`
+	UpdatedAt     int64          `json:"updatedAt"`
+	History       []*AppealEvent `json:"history,omitempty"` // not a database field
+}
+
+type DBAppeal struct {
+	AppealID      int64
+	InfractionID  int64
+	PlayerID      int64
+	UserID        int64
+	ReviewerID    sql.NullInt64
+	Status        string
+	Statement     string
+	DecisionNotes sql.NullString
+	CreatedAt     int64
+	UpdatedAt     int64
+}
+
+// Appeal builds an Appeal instance from the DBAppeal it was called upon.
+func (dba *DBAppeal) Appeal() *Appeal {
+	return &Appeal{
+		AppealID:      dba.AppealID,
+		InfractionID:  dba.InfractionID,
+		PlayerID:      dba.PlayerID,
+		UserID:        dba.UserID,
+		ReviewerID:    dba.ReviewerID.Int64,
+		Status:        dba.Status,
+		Statement:     dba.Statement,
+		DecisionNotes: dba.DecisionNotes.String,
+		CreatedAt:     dba.CreatedAt,
+		UpdatedAt:     dba.UpdatedAt,
+	}
+}
+
+// IsClosed returns true if a decision has been made on the appeal.
+func (a *Appeal) IsClosed() bool {
+	return a.Status == APPEAL_STATUS_ACCEPTED || a.Status == APPEAL_STATUS_DENIED
+}
+
+// AppealEvent is a single entry in an appeal's history. Status holds the status of the appeal after the event.
+type AppealEvent struct {
+	EventID   int64  `json:"id"`
+	AppealID  int64  `json:"appealId"`
+	UserID    int64  `json:"userId"`
+	Action    string `json:"action"`
+	Status    string `json:"status"`
+	Notes     string `json:"notes"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+type AppealRepository interface {
+	Create(appeal *DBAppeal) (*Appeal, error)
+	FindByID(id int64) (*Appeal, error)
+	FindMany(args FindArgs) ([]*Appeal, error)
+	Update(id int64, args UpdateArgs) (*Appeal, error)
+	Search(args FindArgs, limit int, offset int) (int, []*Appeal, error)
+	CreateEvent(event *AppealEvent) error
+	FindEvents(appealID int64) ([]*AppealEvent, error)
+}
+
+type AppealService interface {
+	CreateAppeal(body params.CreateAppealParams) (*Appeal, *ServiceResponse)
+	GetAppealByID(id int64) (*Appeal, *ServiceResponse)
+	AssignReviewer(id int64, body params.AssignAppealReviewerParams) (*Appeal, *ServiceResponse)
+	AddNote(id int64, body params.AppealNoteParams) (*Appeal, *ServiceResponse)
+	DecideAppeal(id int64, status string, body params.AppealNoteParams) (*Appeal, *ServiceResponse)
+}
+
+type AppealHandler interface {
+	CreateAppeal(c echo.Context) error
+	GetAppeal(c echo.Context) error
+	AssignReviewer(c echo.Context) error
+	AddNote(c echo.Context) error
+	DecideAppeal(status string) echo.HandlerFunc
+}
@@ -0,0 +1,68 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package refractor
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+	"io"
+)
+
+// Attachment holds the metadata of a piece of evidence attached to an infraction. The file itself lives in a
+// BlobStore under StorageKey.
+type Attachment struct {
+	AttachmentID int64  `json:"id"`
+	InfractionID int64  `json:"infractionId"`
+	UserID       int64  `json:"userId"`
+	FileName     string `json:"fileName"`
+	ContentType  string `json:"contentType"`
+	Size         int64  `json:"size"`
+	StorageKey   string `json:"-"`
+	Timestamp    int64  `json:"timestamp"`
+}
+
+type AttachmentRepository interface {
+	Create(attachment *Attachment) error
+	FindByID(id int64) (*Attachment, error)
+	FindManyByInfractionID(infractionID int64) ([]*Attachment, error)
+	CountByPlayerID(playerID int64) (map[int64]int, error)
+	Delete(id int64) error
+}
+
+// BlobStore stores the contents of uploaded files. Keys are generated by Refractor and are safe to use as file names.
+type BlobStore interface {
+	Put(key string, data io.Reader) (int64, error)
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+type AttachmentService interface {
+	UploadAttachment(infractionID int64, file io.Reader, body params.UploadAttachmentParams) (*Attachment, *ServiceResponse)
+	GetInfractionAttachments(infractionID int64) ([]*Attachment, *ServiceResponse)
+	GetAttachmentFile(infractionID int64, attachmentID int64) (*Attachment, io.ReadCloser, *ServiceResponse)
+	DeleteAttachment(infractionID int64, attachmentID int64, user params.UserMeta) *ServiceResponse
+	GetPlayerAttachmentCounts(playerID int64) (map[int64]int, *ServiceResponse)
+	OnInfractionPurge(infraction *Infraction)
+}
+
+type AttachmentHandler interface {
+	UploadAttachment(c echo.Context) error
+	GetInfractionAttachments(c echo.Context) error
+	DownloadAttachment(c echo.Context) error
+	DeleteAttachment(c echo.Context) error
+}
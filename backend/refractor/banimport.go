@@ -0,0 +1,70 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package refractor
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+	"io"
+)
+
+// Ban list formats which can be imported
+const (
+	BAN_IMPORT_FORMAT_MINECRAFT = "minecraft" // a Minecraft server's banned-players.json file
+	BAN_IMPORT_FORMAT_MORDHAU   = "mordhau"   // the output of Mordhau's BanList RCON command
+)
+
+// BanImportEntry is a single ban read from an imported ban list. Line is the line or array index the ban was read from
+// so that conflicts can be traced back to the original file. A Duration of 0 means the ban is permanent.
+type BanImportEntry struct {
+	Line         int    `json:"line"`
+	PlayerGameID string `json:"playerGameId"`
+	PlayerName   string `json:"playerName"`
+	Reason       string `json:"reason"`
+	Duration     int    `json:"duration"`
+	Timestamp    int64  `json:"timestamp"`
+}
+
+// BanImportConflict is an entry in an imported ban list which was not imported along with the reason why. PlayerID is
+// set if the entry belongs to a player who is already stored.
+type BanImportConflict struct {
+	Line         int    `json:"line"`
+	PlayerGameID string `json:"playerGameId"`
+	PlayerName   string `json:"playerName"`
+	PlayerID     int64  `json:"playerId,omitempty"`
+	Reason       string `json:"reason"`
+}
+
+// BanImportResult summarises an import. On a dry run, nothing is stored and Imported is the number of bans which would
+// have been imported.
+type BanImportResult struct {
+	Format    string               `json:"format"`
+	ServerID  int64                `json:"serverId"`
+	DryRun    bool                 `json:"dryRun"`
+	Total     int                  `json:"total"`
+	Imported  int                  `json:"imported"`
+	Conflicts []*BanImportConflict `json:"conflicts"`
+}
+
+type BanImportService interface {
+	ImportBans(banList io.Reader, body params.ImportBansParams) (*BanImportResult, *ServiceResponse)
+}
+
+type BanImportHandler interface {
+	ImportBans(c echo.Context) error
+}
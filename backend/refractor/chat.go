@@ -17,15 +17,56 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 
 package refractor
 
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+)
+
 type ChatReceiveBody struct {
 	ServerID     int64  `json:"serverId"`
 	PlayerGameID string `json:"playerGameID"`
 	Name         string `json:"name"`
+	Channel      string `json:"channel,omitempty"`
 	Message      string `json:"message"`
 	SentByUser   bool   `json:"sentByUser"`
 }
 
+// ChatMessage is a stored chat message. Messages sent by players have a PlayerID and messages sent by Refractor users
+// from the dashboard have a UserID.
+type ChatMessage struct {
+	MessageID  int64  `json:"id"`
+	ServerID   int64  `json:"serverId"`
+	PlayerID   int64  `json:"playerId,omitempty"`
+	UserID     int64  `json:"userId,omitempty"`
+	Name       string `json:"name"`
+	Channel    string `json:"channel,omitempty"`
+	Message    string `json:"message"`
+	Timestamp  int64  `json:"timestamp"`
+	SentByUser bool   `json:"sentByUser"`
+}
+
+type ChatRepository interface {
+	Create(message *ChatMessage) error
+	Search(args FindArgs, limit int, offset int) (int, []*ChatMessage, error)
+}
+
+// ChatSnapshotRepository stores copies of chat messages taken when an infraction is created. Snapshots can't be
+// changed once created so that they always show what was said at the time of the infraction.
+type ChatSnapshotRepository interface {
+	Create(infractionID int64, messages []*ChatMessage) error
+	FindByInfractionID(infractionID int64) ([]*ChatMessage, error)
+}
+
 type ChatService interface {
 	OnChatReceive(msgBody *ChatReceiveBody, serverID int64, gameConfig *GameConfig)
 	OnUserSendChat(msgBody *ChatSendBody)
+	OnUserWhisper(msgBody *WhisperSendBody)
+	WhisperPlayer(playerID int64, body params.WhisperPlayerParams) *ServiceResponse
+	OnInfractionCreate(infraction *Infraction)
+	GetInfractionChatSnapshot(infractionID int64) ([]*ChatMessage, *ServiceResponse)
+}
+
+type ChatHandler interface {
+	GetInfractionChatSnapshot(c echo.Context) error
+	WhisperPlayer(c echo.Context) error
 }
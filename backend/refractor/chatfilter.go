@@ -0,0 +1,83 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package refractor
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+)
+
+const (
+	FILTER_MATCH_WORD  = "WORD"
+	FILTER_MATCH_REGEX = "REGEX"
+)
+
+// FILTER_ACTION_ALERT only notifies staff. All other filter actions are infraction types.
+const FILTER_ACTION_ALERT = "ALERT"
+
+// ChatFilterRule matches chat messages against Pattern. WORD rules match the pattern as a whole word or phrase while
+// REGEX rules match the pattern as a regular expression. Matching is case insensitive. If Leetspeak is true, messages
+// are also checked after being normalised so that substitutions like "h3ll0" still match.
+//
+// When a rule matches, staff are alerted and an infraction of ActionType is created unless ActionType is
+// FILTER_ACTION_ALERT. ActionDuration is the length of a mute in minutes.
+type ChatFilterRule struct {
+	RuleID         int64  `json:"id"`
+	Pattern        string `json:"pattern"`
+	MatchType      string `json:"matchType"`
+	Leetspeak      bool   `json:"leetspeak"`
+	ActionType     string `json:"actionType"`
+	ActionDuration int    `json:"actionDuration"`
+	ActionReason   string `json:"actionReason"`
+	Enabled        bool   `json:"enabled"`
+}
+
+// ChatFilterAlert is the body of the websocket message sent to staff when a chat filter rule matches a message.
+// InfractionID is only set if the rule's action created an infraction.
+type ChatFilterAlert struct {
+	RuleID       int64  `json:"ruleId"`
+	ServerID     int64  `json:"serverId"`
+	PlayerID     int64  `json:"playerId,omitempty"`
+	Name         string `json:"name"`
+	Message      string `json:"message"`
+	ActionType   string `json:"actionType"`
+	InfractionID int64  `json:"infractionId,omitempty"`
+}
+
+type ChatFilterRuleRepository interface {
+	Create(rule *ChatFilterRule) error
+	FindByID(id int64) (*ChatFilterRule, error)
+	FindAll() ([]*ChatFilterRule, error)
+	Update(id int64, args UpdateArgs) (*ChatFilterRule, error)
+	Delete(id int64) error
+}
+
+type ChatFilterService interface {
+	CreateRule(body params.CreateChatFilterRuleParams) (*ChatFilterRule, *ServiceResponse)
+	GetAllRules() ([]*ChatFilterRule, *ServiceResponse)
+	UpdateRule(id int64, body params.UpdateChatFilterRuleParams) (*ChatFilterRule, *ServiceResponse)
+	DeleteRule(id int64) *ServiceResponse
+	OnChatReceive(msgBody *ChatReceiveBody, serverID int64, gameConfig *GameConfig)
+}
+
+type ChatFilterHandler interface {
+	CreateRule(c echo.Context) error
+	GetAllRules(c echo.Context) error
+	UpdateRule(c echo.Context) error
+	DeleteRule(c echo.Context) error
+}
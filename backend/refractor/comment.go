@@ -0,0 +1,59 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package refractor
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+)
+
+// Comment is a staff comment on an infraction. A comment can reply to another comment on the same infraction, which
+// is how threads are formed.
+type Comment struct {
+	CommentID    int64      `json:"id"`
+	InfractionID int64      `json:"infractionId"`
+	ParentID     int64      `json:"parentId,omitempty"`
+	UserID       int64      `json:"userId"`
+	Body         string     `json:"body"`
+	Timestamp    int64      `json:"timestamp"`
+	EditedAt     int64      `json:"editedAt,omitempty"`
+	AuthorName   string     `json:"authorName"` // not a database field
+	Replies      []*Comment `json:"replies"`    // not a database field
+}
+
+type CommentRepository interface {
+	Create(comment *Comment) error
+	FindByID(id int64) (*Comment, error)
+	FindManyByInfractionID(infractionID int64) ([]*Comment, error)
+	Update(id int64, args UpdateArgs) (*Comment, error)
+	Delete(id int64) error
+}
+
+type CommentService interface {
+	CreateComment(infractionID int64, body params.CreateCommentParams) (*Comment, *ServiceResponse)
+	GetInfractionComments(infractionID int64) ([]*Comment, *ServiceResponse)
+	UpdateComment(infractionID int64, commentID int64, body params.UpdateCommentParams) (*Comment, *ServiceResponse)
+	DeleteComment(infractionID int64, commentID int64, user params.UserMeta) *ServiceResponse
+}
+
+type CommentHandler interface {
+	CreateComment(c echo.Context) error
+	GetInfractionComments(c echo.Context) error
+	UpdateComment(c echo.Context) error
+	DeleteComment(c echo.Context) error
+}
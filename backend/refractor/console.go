@@ -0,0 +1,86 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package refractor
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+)
+
+// RCON console roles. Commands run by super admins are never restricted. Users with full access use the ADMIN rules
+// and everyone else with the RCON_CONSOLE permission uses the CONSOLE rules.
+const (
+	RCON_ROLE_ADMIN   = "ADMIN"
+	RCON_ROLE_CONSOLE = "CONSOLE"
+)
+
+const (
+	RCON_RULE_ALLOW = "ALLOW"
+	RCON_RULE_DENY  = "DENY"
+)
+
+// RCONCommandRule allows or denies console commands starting with Prefix for users of Role. Prefixes are matched
+// case insensitively against whole words, ignoring any leading slash and extra whitespace. A command is denied if it matches any DENY rule for the user's role. If the role has any ALLOW
+// rules, the command must also match one of them.
+type RCONCommandRule struct {
+	RuleID int64  `json:"id"`
+	Role   string `json:"role"`
+	Prefix string `json:"prefix"`
+	Type   string `json:"type"`
+}
+
+// RCONCommandLog is an audit record of a command sent through the RCON console. Commands which were denied by the
+// command rules are logged with Allowed set to false.
+type RCONCommandLog struct {
+	LogID     int64  `json:"id"`
+	UserID    int64  `json:"userId"`
+	ServerID  int64  `json:"serverId"`
+	Command   string `json:"command"`
+	Output    string `json:"output"`
+	Allowed   bool   `json:"allowed"`
+	Success   bool   `json:"success"`
+	Error     string `json:"error"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+type RCONCommandRuleRepository interface {
+	Create(rule *RCONCommandRule) error
+	FindAll() ([]*RCONCommandRule, error)
+	Delete(id int64) error
+}
+
+type RCONCommandLogRepository interface {
+	Create(log *RCONCommandLog) error
+	FindManyByServerID(serverID int64, limit int, offset int) (int, []*RCONCommandLog, error)
+}
+
+type RCONConsoleService interface {
+	ExecCommand(serverID int64, body params.RCONCommandParams) (*RCONCommandLog, *ServiceResponse)
+	CreateRule(body params.CreateRCONCommandRuleParams) (*RCONCommandRule, *ServiceResponse)
+	GetAllRules() ([]*RCONCommandRule, *ServiceResponse)
+	DeleteRule(id int64) *ServiceResponse
+	GetCommandLogs(serverID int64, body params.SearchParams) (int, []*RCONCommandLog, *ServiceResponse)
+}
+
+type RCONConsoleHandler interface {
+	ExecCommand(c echo.Context) error
+	CreateRule(c echo.Context) error
+	GetAllRules(c echo.Context) error
+	DeleteRule(c echo.Context) error
+	GetCommandLogs(c echo.Context) error
+}
@@ -0,0 +1,96 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package refractor
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+)
+
+// DiscordChannel is a Discord webhook which infractions are posted to as embeds. An empty InfractionType means
+// infractions of every type are posted and a ServerID of 0 means infractions from every server are posted.
+type DiscordChannel struct {
+	ChannelID      int64  `json:"id"`
+	Name           string `json:"name"`
+	WebhookURL     string `json:"webhookUrl"`
+	InfractionType string `json:"infractionType"`
+	ServerID       int64  `json:"serverId"`
+	Enabled        bool   `json:"enabled"`
+}
+
+// Matches returns true if the channel is enabled and accepts infractions of infractionType from serverID.
+func (dc *DiscordChannel) Matches(infractionType string, serverID int64) bool {
+	if !dc.Enabled {
+		return false
+	}
+
+	if dc.InfractionType != "" && dc.InfractionType != infractionType {
+		return false
+	}
+
+	return dc.ServerID == 0 || dc.ServerID == serverID
+}
+
+// DiscordMessage is the body of a message sent to a Discord webhook.
+// See https://discord.com/developers/docs/resources/webhook#execute-webhook
+type DiscordMessage struct {
+	Username string          `json:"username,omitempty"`
+	Embeds   []*DiscordEmbed `json:"embeds"`
+}
+
+type DiscordEmbed struct {
+	Title     string               `json:"title"`
+	URL       string               `json:"url,omitempty"`
+	Color     int                  `json:"color"`
+	Fields    []*DiscordEmbedField `json:"fields"`
+	Footer    *DiscordEmbedFooter  `json:"footer,omitempty"`
+	Timestamp string               `json:"timestamp,omitempty"`
+}
+
+type DiscordEmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+type DiscordEmbedFooter struct {
+	Text string `json:"text"`
+}
+
+type DiscordChannelRepository interface {
+	Create(channel *DiscordChannel) error
+	FindByID(id int64) (*DiscordChannel, error)
+	FindAll() ([]*DiscordChannel, error)
+	Update(id int64, args UpdateArgs) (*DiscordChannel, error)
+	Delete(id int64) error
+}
+
+type DiscordService interface {
+	CreateChannel(body params.CreateDiscordChannelParams) (*DiscordChannel, *ServiceResponse)
+	GetAllChannels() ([]*DiscordChannel, *ServiceResponse)
+	UpdateChannel(id int64, body params.UpdateDiscordChannelParams) (*DiscordChannel, *ServiceResponse)
+	DeleteChannel(id int64) *ServiceResponse
+	OnInfractionCreate(infraction *Infraction)
+}
+
+type DiscordHandler interface {
+	CreateChannel(c echo.Context) error
+	GetAllChannels(c echo.Context) error
+	UpdateChannel(c echo.Context) error
+	DeleteChannel(c echo.Context) error
+}
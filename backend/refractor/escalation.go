@@ -0,0 +1,61 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package refractor
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+)
+
+// EscalationPolicy describes a single rung of an escalation ladder. Once a player has received TriggerCount
+// infractions of TriggerType within TimeWindow minutes, an infraction of ActionType is automatically created for them.
+// A TimeWindow of 0 means all of a player's infractions are counted regardless of when they were created.
+type EscalationPolicy struct {
+	PolicyID       int64  `json:"id"`
+	Name           string `json:"name"`
+	TriggerType    string `json:"triggerType"`
+	TriggerCount   int    `json:"triggerCount"`
+	TimeWindow     int    `json:"timeWindow"`
+	ActionType     string `json:"actionType"`
+	ActionDuration int    `json:"actionDuration"`
+	ActionReason   string `json:"actionReason"`
+	Enabled        bool   `json:"enabled"`
+}
+
+type EscalationPolicyRepository interface {
+	Create(policy *EscalationPolicy) error
+	FindByID(id int64) (*EscalationPolicy, error)
+	FindAll() ([]*EscalationPolicy, error)
+	Update(id int64, args UpdateArgs) (*EscalationPolicy, error)
+	Delete(id int64) error
+}
+
+type EscalationService interface {
+	CreatePolicy(body params.CreateEscalationPolicyParams) (*EscalationPolicy, *ServiceResponse)
+	GetAllPolicies() ([]*EscalationPolicy, *ServiceResponse)
+	UpdatePolicy(id int64, body params.UpdateEscalationPolicyParams) (*EscalationPolicy, *ServiceResponse)
+	DeletePolicy(id int64) *ServiceResponse
+	OnInfractionCreate(infraction *Infraction)
+}
+
+type EscalationHandler interface {
+	CreatePolicy(c echo.Context) error
+	GetAllPolicies(c echo.Context) error
+	UpdatePolicy(c echo.Context) error
+	DeletePolicy(c echo.Context) error
+}
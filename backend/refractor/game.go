@@ -55,13 +55,20 @@ type CommandArgs struct {
 	PlayerID string
 	Reason   string
 	Duration int
+	Message  string
 }
 
+// GameCommands builds the commands sent to a game's servers. A command builder returns an empty string if the game does
+// not support the command.
 type GameCommands interface {
 	GetWarnCommand(args CommandArgs) string
 	GetMuteCommand(args CommandArgs) string
 	GetKickCommand(args CommandArgs) string
 	GetBanCommand(args CommandArgs) string
+	GetUnmuteCommand(args CommandArgs) string
+	GetUnbanCommand(args CommandArgs) string
+	GetBroadcastChatCommand(args CommandArgs) string
+	GetPrivateMessageCommand(args CommandArgs) string
 	GetPlayerListCommand() string
 }
 
@@ -21,6 +21,8 @@ import (
 	"database/sql"
 	"github.com/labstack/echo/v4"
 	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/broadcast"
+	"time"
 )
 
 const (
@@ -32,6 +34,24 @@ const (
 
 var InfractionTypes = []string{INFRACTION_TYPE_WARNING, INFRACTION_TYPE_MUTE, INFRACTION_TYPE_KICK, INFRACTION_TYPE_BAN}
 
+// Enforcement statuses describe the outcome of running an infraction's in-game command through RCON.
+const (
+	ENFORCEMENT_NONE        = "NONE"        // enforcement was not requested
+	ENFORCEMENT_SUCCESS     = "SUCCESS"     // the command was executed
+	ENFORCEMENT_FAILED      = "FAILED"      // the command could not be executed (e.g. the server is offline)
+	ENFORCEMENT_UNSUPPORTED = "UNSUPPORTED" // the game has no command for this infraction type
+)
+
+// Delivery statuses describe whether a warning has been shown to the player in-game. Warnings created while the
+// player is offline are queued and delivered the next time they join a server.
+const (
+	DELIVERY_NONE        = "NONE"        // not a warning, or created before in-game delivery existed
+	DELIVERY_DELIVERED   = "DELIVERED"   // the warning was sent to the player
+	DELIVERY_QUEUED      = "QUEUED"      // the player was offline so the warning will be sent when they next join
+	DELIVERY_FAILED      = "FAILED"      // the warning could not be sent. It is retried when the player next joins.
+	DELIVERY_UNSUPPORTED = "UNSUPPORTED" // the game has no command which can be used to send the warning
+)
+
 type Infraction struct {
 	InfractionID int64  `json:"id"`
 	PlayerID     int64  `json:"playerId"`
@@ -42,20 +62,57 @@ type Infraction struct {
 	Duration     int    `json:"duration"`
 	Timestamp    int64  `json:"timestamp"`
 	SystemAction bool   `json:"systemAction"`
-	StaffName    string `json:"staffName"`  // not a database field
-	PlayerName   string `json:"playerName"` // not a database field
+	Enforcement  string `json:"enforcement"`
+	Revoked      bool   `json:"revoked"`
+	RevokedBy    int64  `json:"revokedBy,omitempty"`
+	RevokedAt    int64  `json:"revokedAt,omitempty"`
+	RevokeReason string `json:"revokeReason,omitempty"`
+	Expired      bool   `json:"expired"`
+
+	// LinkedInfractionID and PolicyID are set on infractions created by an escalation policy. LinkedInfractionID
+	// holds the ID of the infraction which triggered the escalation.
+	LinkedInfractionID int64 `json:"linkedInfractionId,omitempty"`
+	PolicyID           int64 `json:"policyId,omitempty"`
+
+	// Deleted infractions are kept in the database so they can be restored. They are left out of player summaries,
+	// searches and recent infractions.
+	Deleted      bool   `json:"deleted"`
+	DeletedBy    int64  `json:"deletedBy,omitempty"`
+	DeletedAt    int64  `json:"deletedAt,omitempty"`
+	DeleteReason string `json:"deleteReason,omitempty"`
+
+	// Delivery holds one of the DELIVERY_* statuses. DeliveredAt is set once a warning has been sent to the player.
+	Delivery    string `json:"delivery"`
+	DeliveredAt int64  `json:"deliveredAt,omitempty"`
+
+	Active     bool   `json:"active"`     // not a database field
+	ExpiresAt  int64  `json:"expiresAt"`  // not a database field
+	StaffName  string `json:"staffName"`  // not a database field
+	PlayerName string `json:"playerName"` // not a database field
 }
 
 type DBInfraction struct {
-	InfractionID int64
-	PlayerID     int64
-	UserID       int64
-	ServerID     int64
-	Type         string
-	Reason       sql.NullString
-	Duration     sql.NullInt32
-	Timestamp    int64
-	SystemAction bool
+	InfractionID       int64
+	PlayerID           int64
+	UserID             int64
+	ServerID           int64
+	Type               string
+	Reason             sql.NullString
+	Duration           sql.NullInt32
+	Timestamp          int64
+	SystemAction       bool
+	Enforcement        string
+	RevokedBy          sql.NullInt64
+	RevokedAt          sql.NullInt64
+	RevokeReason       sql.NullString
+	Expired            bool
+	LinkedInfractionID sql.NullInt64
+	PolicyID           sql.NullInt64
+	DeletedBy          sql.NullInt64
+	DeletedAt          sql.NullInt64
+	DeleteReason       sql.NullString
+	Delivery           string
+	DeliveredAt        sql.NullInt64
 }
 
 // Infraction builds a Infraction instance from the DBInstance it was called upon.
@@ -70,9 +127,83 @@ func (dbi *DBInfraction) Infraction() *Infraction {
 		Type:         dbi.Type,
 		Timestamp:    dbi.Timestamp,
 		SystemAction: dbi.SystemAction,
+		Enforcement:  dbi.Enforcement,
+		Revoked:      dbi.RevokedAt.Valid,
+		RevokedBy:    dbi.RevokedBy.Int64,
+		RevokedAt:    dbi.RevokedAt.Int64,
+		RevokeReason: dbi.RevokeReason.String,
+		Expired:      dbi.Expired,
+		Active:       dbi.IsActive(time.Now().Unix()),
+		ExpiresAt:    dbi.ExpiresAt(),
+
+		LinkedInfractionID: dbi.LinkedInfractionID.Int64,
+		PolicyID:           dbi.PolicyID.Int64,
+
+		Deleted:      dbi.DeletedAt.Valid,
+		DeletedBy:    dbi.DeletedBy.Int64,
+		DeletedAt:    dbi.DeletedAt.Int64,
+		DeleteReason: dbi.DeleteReason.String,
+
+		Delivery:    dbi.Delivery,
+		DeliveredAt: dbi.DeliveredAt.Int64,
+	}
+}
+
+// ExpiresAt returns the unix timestamp at which a mute or ban ends. It returns 0 for permanent mutes and bans and for
+// infraction types which do not have a duration.
+func (dbi *DBInfraction) ExpiresAt() int64 {
+	if dbi.Type != INFRACTION_TYPE_MUTE && dbi.Type != INFRACTION_TYPE_BAN {
+		return 0
+	}
+
+	// A duration of 0 means the infraction is permanent
+	if dbi.Duration.Int32 <= 0 {
+		return 0
+	}
+
+	return dbi.Timestamp + int64(dbi.Duration.Int32)*60
+}
+
+// IsActive checks if a mute or ban is still in force at the provided unix timestamp.
+func (dbi *DBInfraction) IsActive(now int64) bool {
+	if dbi.Type != INFRACTION_TYPE_MUTE && dbi.Type != INFRACTION_TYPE_BAN {
+		return false
 	}
+
+	if dbi.Expired || dbi.RevokedAt.Valid || dbi.DeletedAt.Valid {
+		return false
+	}
+
+	expiresAt := dbi.ExpiresAt()
+
+	return expiresAt == 0 || now < expiresAt
 }
 
+// GetLongestActive returns the active infraction which will stay in force the longest, or nil if none are active.
+func GetLongestActive(infractions []*Infraction) *Infraction {
+	var longest *Infraction
+
+	for _, infraction := range infractions {
+		if !infraction.Active {
+			continue
+		}
+
+		// Permanent infractions have an ExpiresAt of 0 and always take precedence
+		if infraction.ExpiresAt == 0 {
+			return infraction
+		}
+
+		if longest == nil || infraction.ExpiresAt > longest.ExpiresAt {
+			longest = infraction
+		}
+	}
+
+	return longest
+}
+
+// InfractionSubscriber is notified of an infraction when it is created, updated, deleted or purged
+type InfractionSubscriber func(infraction *Infraction)
+
 type InfractionRepository interface {
 	Create(infraction *DBInfraction) (*Infraction, error)
 	FindByID(id int64) (*Infraction, error)
@@ -85,6 +216,10 @@ type InfractionRepository interface {
 	Delete(id int64) error
 	Search(args FindArgs, limit int, offset int) (int, []*Infraction, error)
 	GetRecent(count int) ([]*Infraction, error)
+	FindExpired(now int64) ([]*Infraction, error)
+	FindDeleted(limit int, offset int) (int, []*Infraction, error)
+	CreateRevision(revision *InfractionRevision) error
+	FindRevisions(infractionID int64) ([]*InfractionRevision, error)
 }
 
 type InfractionService interface {
@@ -92,11 +227,26 @@ type InfractionService interface {
 	CreateMute(userID int64, body params.CreateMuteParams) (*Infraction, *ServiceResponse)
 	CreateKick(userID int64, body params.CreateKickParams) (*Infraction, *ServiceResponse)
 	CreateBan(userID int64, body params.CreateBanParams) (*Infraction, *ServiceResponse)
-	DeleteInfraction(id int64, user params.UserMeta) *ServiceResponse
+	CreateOfflineBan(userID int64, body params.CreateOfflineBanParams) (*Infraction, *ServiceResponse)
+	CreateSystemInfraction(infraction *DBInfraction, enforce bool) (*Infraction, *ServiceResponse)
+	GetInfractionByID(id int64) (*Infraction, *ServiceResponse)
+	DeleteInfraction(id int64, body params.DeleteInfractionParams) *ServiceResponse
+	GetDeletedInfractions(body params.SearchParams) (int, []*Infraction, *ServiceResponse)
+	RestoreInfraction(id int64, user params.UserMeta) (*Infraction, *ServiceResponse)
+	PurgeInfraction(id int64, user params.UserMeta) *ServiceResponse
 	UpdateInfraction(id int64, body params.UpdateInfractionParams) (*Infraction, *ServiceResponse)
+	RevokeInfraction(id int64, body params.RevokeInfractionParams) (*Infraction, *ServiceResponse)
+	RevokeInfractionAsSystem(id int64, revokedBy int64, reason string) (*Infraction, *ServiceResponse)
+	GetInfractionHistory(id int64) ([]*InfractionRevision, *ServiceResponse)
 	GetPlayerInfractionsType(infractionType string, playerID int64) ([]*Infraction, *ServiceResponse)
 	GetPlayerInfractions(playerID int64) ([]*Infraction, *ServiceResponse)
 	GetRecentInfractions(count int) ([]*Infraction, *ServiceResponse)
+	ExpireInfractions() ([]*Infraction, *ServiceResponse)
+	OnPlayerJoin(serverID int64, player *Player)
+	SubscribeCreate(subscriber InfractionSubscriber)
+	SubscribeUpdate(subscriber InfractionSubscriber)
+	SubscribeDelete(subscriber InfractionSubscriber)
+	SubscribePurge(subscriber InfractionSubscriber)
 }
 
 type InfractionHandler interface {
@@ -104,8 +254,15 @@ type InfractionHandler interface {
 	CreateMute(c echo.Context) error
 	CreateKick(c echo.Context) error
 	CreateBan(c echo.Context) error
+	CreateOfflineBan(c echo.Context) error
 	DeleteInfraction(c echo.Context) error
+	GetDeletedInfractions(c echo.Context) error
+	RestoreInfraction(c echo.Context) error
+	PurgeInfraction(c echo.Context) error
 	UpdateInfraction(c echo.Context) error
+	RevokeInfraction(c echo.Context) error
+	GetInfractionHistory(c echo.Context) error
 	GetPlayerInfractions(infractionType string) echo.HandlerFunc
 	GetRecentInfractions(c echo.Context) error
+	OnPlayerJoin(fields broadcast.Fields, serverID int64, gameConfig *GameConfig)
 }
@@ -0,0 +1,59 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package refractor
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+)
+
+// PlayerNote is a piece of context about a player which doesn't warrant an infraction, such as a suspected alt
+// account or a verbal warning. Pinned notes are listed first. AdminOnly notes are only visible to admins.
+type PlayerNote struct {
+	NoteID     int64  `json:"id"`
+	PlayerID   int64  `json:"playerId"`
+	UserID     int64  `json:"userId"`
+	Body       string `json:"body"`
+	Pinned     bool   `json:"pinned"`
+	AdminOnly  bool   `json:"adminOnly"`
+	Timestamp  int64  `json:"timestamp"`
+	EditedAt   int64  `json:"editedAt,omitempty"`
+	AuthorName string `json:"authorName"` // not a database field
+}
+
+type NoteRepository interface {
+	Create(note *PlayerNote) error
+	FindByID(id int64) (*PlayerNote, error)
+	FindManyByPlayerID(playerID int64) ([]*PlayerNote, error)
+	Update(id int64, args UpdateArgs) (*PlayerNote, error)
+	Delete(id int64) error
+}
+
+type NoteService interface {
+	CreateNote(playerID int64, body params.CreateNoteParams) (*PlayerNote, *ServiceResponse)
+	GetPlayerNotes(playerID int64, user params.UserMeta) ([]*PlayerNote, *ServiceResponse)
+	UpdateNote(playerID int64, noteID int64, body params.UpdateNoteParams) (*PlayerNote, *ServiceResponse)
+	DeleteNote(playerID int64, noteID int64, user params.UserMeta) *ServiceResponse
+}
+
+type NoteHandler interface {
+	CreateNote(c echo.Context) error
+	GetPlayerNotes(c echo.Context) error
+	UpdateNote(c echo.Context) error
+	DeleteNote(c echo.Context) error
+}
@@ -20,39 +20,70 @@ package refractor
 import (
 	"database/sql"
 	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
 	"github.com/sniddunc/refractor/pkg/broadcast"
+	"time"
 )
 
+// Player is a player of one of the supported games. If the player is on the watchlist, Watched is true and the Watch*
+// fields describe who added them, when and why. A WatchExpiresAt of 0 means the watch never expires.
+//
+// Placeholder is true for players who were created by an offline ban before they ever joined one of our servers. Their
+// name is only a stand-in until they join and their real name is recorded.
 type Player struct {
-	PlayerID      int64    `json:"id"`
-	PlayFabID     string   `json:"playFabId"`
-	MCUUID        string   `json:"mcuuid"`
-	LastSeen      int64    `json:"lastSeen"`
-	CurrentName   string   `json:"currentName"`
-	PreviousNames []string `json:"previousNames,omitempty"`
-	Watched       bool     `json:"watched"`
+	PlayerID       int64    `json:"id"`
+	PlayFabID      string   `json:"playFabId"`
+	MCUUID         string   `json:"mcuuid"`
+	LastSeen       int64    `json:"lastSeen"`
+	CurrentName    string   `json:"currentName"`
+	PreviousNames  []string `json:"previousNames,omitempty"`
+	Watched        bool     `json:"watched"`
+	WatchReason    string   `json:"watchReason,omitempty"`
+	WatchedBy      int64    `json:"watchedBy,omitempty"`
+	WatchedAt      int64    `json:"watchedAt,omitempty"`
+	WatchExpiresAt int64    `json:"watchExpiresAt,omitempty"`
+	Placeholder    bool     `json:"placeholder"`
 }
 
 type DBPlayer struct {
-	PlayerID      int64
-	PlayFabID     sql.NullString
-	MCUUID        sql.NullString
-	LastSeen      int64
-	CurrentName   string
-	PreviousNames []string
-	Watched       bool `json:"watched"`
+	PlayerID       int64
+	PlayFabID      sql.NullString
+	MCUUID         sql.NullString
+	LastSeen       int64
+	CurrentName    string
+	PreviousNames  []string
+	Watched        bool `json:"watched"`
+	WatchReason    sql.NullString
+	WatchedBy      sql.NullInt64
+	WatchedAt      sql.NullInt64
+	WatchExpiresAt sql.NullInt64
+	Placeholder    bool
 }
 
+// Player converts a DBPlayer to a Player. Watches which have expired are left out so that an expired watch is treated
+// the same as the player not being watched.
 func (dbp DBPlayer) Player() *Player {
-	return &Player{
+	player := &Player{
 		PlayerID:      dbp.PlayerID,
 		PlayFabID:     dbp.PlayFabID.String,
 		MCUUID:        dbp.MCUUID.String,
 		LastSeen:      dbp.LastSeen,
 		CurrentName:   dbp.CurrentName,
 		PreviousNames: dbp.PreviousNames,
-		Watched:       dbp.Watched,
+		Placeholder:   dbp.Placeholder,
 	}
+
+	watchExpired := dbp.WatchExpiresAt.Valid && dbp.WatchExpiresAt.Int64 <= time.Now().Unix()
+
+	if dbp.Watched && !watchExpired {
+		player.Watched = true
+		player.WatchReason = dbp.WatchReason.String
+		player.WatchedBy = dbp.WatchedBy.Int64
+		player.WatchedAt = dbp.WatchedAt.Int64
+		player.WatchExpiresAt = dbp.WatchExpiresAt.Int64
+	}
+
+	return player
 }
 
 type PlayerUpdateSubscriber func(updated *Player)
@@ -65,6 +96,7 @@ type PlayerRepository interface {
 	FindOne(args FindArgs) (*Player, error)
 	Exists(args FindArgs) (bool, error)
 	UpdateName(player *Player, currentName string) error
+	ReplaceName(player *Player, currentName string) error
 	Update(id int64, args UpdateArgs) (*Player, error)
 	SearchByName(name string, limit int, offset int) (int, []*Player, error)
 }
@@ -73,8 +105,10 @@ type PlayerService interface {
 	CreatePlayer(newPlayer *DBPlayer) (*Player, *ServiceResponse)
 	GetPlayerByID(id int64) (*Player, *ServiceResponse)
 	GetPlayer(args FindArgs) (*Player, *ServiceResponse)
+	GetOrCreatePlaceholder(playerGameID string, name string, gameConfig *GameConfig) (*Player, *ServiceResponse)
 	GetRecentPlayers() ([]*Player, *ServiceResponse)
-	SetPlayerWatch(id int64, watch bool) *ServiceResponse
+	WatchPlayer(id int64, body params.WatchPlayerParams) *ServiceResponse
+	UnwatchPlayer(id int64) *ServiceResponse
 	OnPlayerJoin(serverID int64, playerGameID string, currentName string, gameConfig *GameConfig) (*Player, *ServiceResponse)
 	OnPlayerQuit(serverID int64, playerGameID string, gameConfig *GameConfig) (*Player, *ServiceResponse)
 	SubscribeUpdate(subscriber PlayerUpdateSubscriber)
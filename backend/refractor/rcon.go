@@ -37,7 +37,9 @@ type RCONService interface {
 	CreateClient(*Server) error
 	GetClients() map[int64]*RCONClient
 	DeleteClient(serverID int64)
+	ExecCommand(serverID int64, command string) (string, error)
 	SendChatMessage(msgBody *ChatSendBody)
+	SendPrivateMessage(serverID int64, playerGameID string, message string) error
 	SubscribeJoin(subscriber BroadcastSubscriber)
 	SubscribeQuit(subscriber BroadcastSubscriber)
 	SubscribeOnline(subscriber StatusSubscriber)
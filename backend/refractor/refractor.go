@@ -61,6 +61,12 @@ var (
 	// ErrNotFound is used when a record could not be found in storage
 	ErrNotFound = errors.New("record not found")
 
+	// ErrNoRCONClient is used when a command is sent to a server which does not have a connected RCON client
+	ErrNoRCONClient = errors.New("no RCON client is connected to that server")
+
+	// ErrUnsupportedCommand is used when a server's game does not support the command being sent to it
+	ErrUnsupportedCommand = errors.New("the server's game does not support this command")
+
 	// ErrInternalError is used when something goes wrong on our end
 	ErrInternalError = errors.New("something went wrong. Please try again later")
 
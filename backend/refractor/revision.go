@@ -0,0 +1,36 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package refractor
+
+// InfractionRevision records a single edit of an infraction: who made it, when, and how each edited field changed.
+type InfractionRevision struct {
+	RevisionID   int64               `json:"id"`
+	InfractionID int64               `json:"infractionId"`
+	UserID       int64               `json:"userId"`
+	Timestamp    int64               `json:"timestamp"`
+	Changes      []*InfractionChange `json:"changes"`
+	EditorName   string              `json:"editorName"` // not a database field
+}
+
+// InfractionChange holds the value of a field before and after an edit. Values are stored as text regardless of the
+// type of the field.
+type InfractionChange struct {
+	Field    string `json:"field"`
+	OldValue string `json:"oldValue"`
+	NewValue string `json:"newValue"`
+}
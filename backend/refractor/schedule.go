@@ -0,0 +1,87 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package refractor
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+	"time"
+)
+
+const (
+	SCHEDULE_ACTION_COMMAND      = "COMMAND"
+	SCHEDULE_ACTION_ANNOUNCEMENT = "ANNOUNCEMENT"
+)
+
+// Schedule runs an RCON command or sends a chat announcement whenever its cron expression matches. Payload holds the
+// command or the announcement message. A ServerID of 0 means the schedule runs on every server.
+type Schedule struct {
+	ScheduleID int64  `json:"id"`
+	Name       string `json:"name"`
+	Cron       string `json:"cron"`
+	ActionType string `json:"actionType"`
+	Payload    string `json:"payload"`
+	ServerID   int64  `json:"serverId"`
+	Enabled    bool   `json:"enabled"`
+	CreatedBy  int64  `json:"createdBy"`
+	CreatedAt  int64  `json:"createdAt"`
+	LastRunAt  int64  `json:"lastRunAt"`
+	NextRunAt  int64  `json:"nextRunAt"` // not a database field
+}
+
+// ScheduleRun is a record of a schedule being run on a server. Output holds the server's response to the command.
+type ScheduleRun struct {
+	RunID      int64  `json:"id"`
+	ScheduleID int64  `json:"scheduleId"`
+	ServerID   int64  `json:"serverId"`
+	Command    string `json:"command"`
+	Success    bool   `json:"success"`
+	Output     string `json:"output"`
+	Error      string `json:"error"`
+	RanAt      int64  `json:"ranAt"`
+}
+
+type ScheduleRepository interface {
+	Create(schedule *Schedule) error
+	FindByID(id int64) (*Schedule, error)
+	FindAll() ([]*Schedule, error)
+	Update(id int64, args UpdateArgs) (*Schedule, error)
+	Delete(id int64) error
+}
+
+type ScheduleRunRepository interface {
+	Create(run *ScheduleRun) error
+	FindManyByScheduleID(scheduleID int64, limit int, offset int) (int, []*ScheduleRun, error)
+}
+
+type ScheduleService interface {
+	CreateSchedule(body params.CreateScheduleParams) (*Schedule, *ServiceResponse)
+	GetAllSchedules() ([]*Schedule, *ServiceResponse)
+	UpdateSchedule(id int64, body params.UpdateScheduleParams) (*Schedule, *ServiceResponse)
+	DeleteSchedule(id int64) *ServiceResponse
+	GetScheduleRuns(id int64, body params.SearchParams) (int, []*ScheduleRun, *ServiceResponse)
+	RunDueSchedules(now time.Time)
+}
+
+type ScheduleHandler interface {
+	CreateSchedule(c echo.Context) error
+	GetAllSchedules(c echo.Context) error
+	UpdateSchedule(c echo.Context) error
+	DeleteSchedule(c echo.Context) error
+	GetScheduleRuns(c echo.Context) error
+}
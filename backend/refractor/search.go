@@ -25,9 +25,13 @@ import (
 type SearchService interface {
 	SearchPlayers(body params.SearchPlayersParams) (int, []*Player, *ServiceResponse)
 	SearchInfractions(body params.SearchInfractionsParams) (int, []*Infraction, *ServiceResponse)
+	SearchAppeals(body params.SearchAppealsParams) (int, []*Appeal, *ServiceResponse)
+	SearchChat(body params.SearchChatParams) (int, []*ChatMessage, *ServiceResponse)
 }
 
 type SearchHandler interface {
 	SearchPlayers(c echo.Context) error
 	SearchInfractions(c echo.Context) error
+	SearchAppeals(c echo.Context) error
+	SearchChat(c echo.Context) error
 }
@@ -0,0 +1,67 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package refractor
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/broadcast"
+)
+
+// PlayerSession is a single stay of a player on a server, from joining to quitting. EndedAt is 0 while the session is
+// still open. LastSeenAt is refreshed whenever a player list poll confirms that the player is still online, and is
+// used to close sessions which were left open when Refractor itself stopped.
+type PlayerSession struct {
+	SessionID  int64  `json:"id"`
+	PlayerID   int64  `json:"playerId"`
+	ServerID   int64  `json:"serverId"`
+	Name       string `json:"name"`
+	StartedAt  int64  `json:"startedAt"`
+	EndedAt    int64  `json:"endedAt,omitempty"`
+	LastSeenAt int64  `json:"-"`
+}
+
+// PlayerPlaytime holds how long a player has spent on servers, in seconds. Servers is keyed by server ID.
+type PlayerPlaytime struct {
+	Total   int64           `json:"total"`
+	Servers map[int64]int64 `json:"servers"`
+}
+
+type SessionRepository interface {
+	Create(session *PlayerSession) error
+	FindOpenByServerID(serverID int64) ([]*PlayerSession, error)
+	FindManyByPlayerID(playerID int64, limit int, offset int) (int, []*PlayerSession, error)
+	Update(id int64, args UpdateArgs) error
+	CloseAbandoned() (int64, error)
+	GetPlaytime(playerID int64) (map[int64]int64, error)
+}
+
+type SessionService interface {
+	GetPlayerSessions(playerID int64, body params.SearchParams) (int, []*PlayerSession, *ServiceResponse)
+	GetPlayerPlaytime(playerID int64) (*PlayerPlaytime, *ServiceResponse)
+	CloseAbandonedSessions()
+	OnPlayerJoin(fields broadcast.Fields, serverID int64, gameConfig *GameConfig)
+	OnPlayerQuit(fields broadcast.Fields, serverID int64, gameConfig *GameConfig)
+	OnServerOffline(serverID int64)
+	OnPlayerListUpdate(serverID int64, gameConfig *GameConfig, players []*Player)
+}
+
+type SessionHandler interface {
+	GetPlayerSessions(c echo.Context) error
+	GetPlayerPlaytime(c echo.Context) error
+}
@@ -0,0 +1,82 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package refractor
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/broadcast"
+)
+
+// Spam detection reasons describe which check caught a player spamming.
+const (
+	SPAM_REASON_FLOOD  = "FLOOD"
+	SPAM_REASON_REPEAT = "REPEAT"
+	SPAM_REASON_CAPS   = "CAPS"
+)
+
+// SpamSettings holds the spam detection thresholds for a server. A threshold of 0 disables its check.
+//
+// Flooding is detected when a player sends more than MessageLimit messages within MessageWindow seconds. Repeated
+// messages are detected when a player sends the same message RepeatLimit times in a row. Excessive caps are detected
+// when at least CapsPercent percent of the letters in a message with at least CapsMinLength letters are uppercase.
+//
+// The first detection results in a warning. Detections shortly after a warning result in a mute for MuteDuration
+// minutes instead.
+type SpamSettings struct {
+	ServerID      int64 `json:"serverId"`
+	Enabled       bool  `json:"enabled"`
+	MessageLimit  int   `json:"messageLimit"`
+	MessageWindow int   `json:"messageWindow"`
+	RepeatLimit   int   `json:"repeatLimit"`
+	CapsPercent   int   `json:"capsPercent"`
+	CapsMinLength int   `json:"capsMinLength"`
+	MuteDuration  int   `json:"muteDuration"`
+}
+
+// SpamDetection is the body of the websocket message sent to staff when a player is caught spamming. InfractionID is
+// only set if an infraction was created for the player.
+type SpamDetection struct {
+	ServerID     int64  `json:"serverId"`
+	PlayerID     int64  `json:"playerId,omitempty"`
+	Name         string `json:"name"`
+	Message      string `json:"message"`
+	Reason       string `json:"reason"`
+	Detail       string `json:"detail"`
+	ActionType   string `json:"actionType,omitempty"`
+	InfractionID int64  `json:"infractionId,omitempty"`
+}
+
+type SpamSettingsRepository interface {
+	Create(settings *SpamSettings) error
+	FindByServerID(serverID int64) (*SpamSettings, error)
+	Update(serverID int64, args UpdateArgs) (*SpamSettings, error)
+}
+
+type SpamService interface {
+	GetServerSettings(serverID int64) (*SpamSettings, *ServiceResponse)
+	UpdateServerSettings(serverID int64, body params.UpdateSpamSettingsParams) (*SpamSettings, *ServiceResponse)
+	OnChatReceive(msgBody *ChatReceiveBody, serverID int64, gameConfig *GameConfig)
+	OnPlayerQuit(fields broadcast.Fields, serverID int64, gameConfig *GameConfig)
+	OnServerOffline(serverID int64)
+}
+
+type SpamHandler interface {
+	GetServerSettings(c echo.Context) error
+	UpdateServerSettings(c echo.Context) error
+}
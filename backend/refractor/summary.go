@@ -17,18 +17,32 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 
 package refractor
 
-import "github.com/labstack/echo/v4"
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+)
 
 type PlayerSummary struct {
 	Warnings []*Infraction `json:"warnings"`
 	Mutes    []*Infraction `json:"mutes"`
 	Kicks    []*Infraction `json:"kicks"`
 	Bans     []*Infraction `json:"bans"`
+
+	// ActiveMute and ActiveBan hold the mute and ban which are currently in force, if any
+	ActiveMute *Infraction `json:"activeMute"`
+	ActiveBan  *Infraction `json:"activeBan"`
+
+	// AttachmentCounts holds the number of evidence attachments on each of the player's infractions, keyed by
+	// infraction ID. Infractions without attachments are left out.
+	AttachmentCounts map[int64]int `json:"attachmentCounts"`
+
+	// Notes holds the notes on the player which the requesting user is allowed to see
+	Notes []*PlayerNote `json:"notes"`
 	*Player
 }
 
 type SummaryService interface {
-	GetPlayerSummary(id int64) (*PlayerSummary, *ServiceResponse)
+	GetPlayerSummary(id int64, user params.UserMeta) (*PlayerSummary, *ServiceResponse)
 }
 
 type SummaryHandler interface {
@@ -0,0 +1,42 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package refractor
+
+import "github.com/sniddunc/refractor/pkg/broadcast"
+
+// WatchedPlayerJoin is sent to staff over websocket, and to watched player join subscribers, when a player on the
+// watchlist joins a server.
+type WatchedPlayerJoin struct {
+	PlayerID       int64  `json:"playerId"`
+	Name           string `json:"name"`
+	ServerID       int64  `json:"serverId"`
+	ServerName     string `json:"serverName"`
+	WatchReason    string `json:"watchReason"`
+	WatchedBy      int64  `json:"watchedBy"`
+	WatchedByName  string `json:"watchedByName"`
+	WatchedAt      int64  `json:"watchedAt"`
+	WatchExpiresAt int64  `json:"watchExpiresAt"`
+	JoinedAt       int64  `json:"joinedAt"`
+}
+
+type WatchedPlayerJoinSubscriber func(alert *WatchedPlayerJoin)
+
+type WatchlistService interface {
+	OnPlayerJoin(fields broadcast.Fields, serverID int64, gameConfig *GameConfig)
+	SubscribeWatchedPlayerJoin(subscriber WatchedPlayerJoinSubscriber)
+}
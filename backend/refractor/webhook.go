@@ -0,0 +1,154 @@
+/*
+This file is part of Refractor.
+
+Refractor is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package refractor
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/sniddunc/refractor/internal/params"
+	"github.com/sniddunc/refractor/pkg/broadcast"
+)
+
+// Events which webhooks can subscribe to
+const (
+	WEBHOOK_EVENT_INFRACTION_CREATE   = "infraction.create"
+	WEBHOOK_EVENT_INFRACTION_UPDATE   = "infraction.update"
+	WEBHOOK_EVENT_INFRACTION_DELETE   = "infraction.delete"
+	WEBHOOK_EVENT_PLAYER_JOIN         = "player.join"
+	WEBHOOK_EVENT_PLAYER_QUIT         = "player.quit"
+	WEBHOOK_EVENT_PLAYER_UPDATE       = "player.update"
+	WEBHOOK_EVENT_SERVER_ONLINE       = "server.online"
+	WEBHOOK_EVENT_SERVER_OFFLINE      = "server.offline"
+	WEBHOOK_EVENT_CHAT                = "chat.message"
+	WEBHOOK_EVENT_WATCHED_PLAYER_JOIN = "watched-player.join"
+)
+
+const (
+	WEBHOOK_DELIVERY_PENDING   = "PENDING"
+	WEBHOOK_DELIVERY_SUCCEEDED = "SUCCEEDED"
+	WEBHOOK_DELIVERY_FAILED    = "FAILED"
+)
+
+// Webhook is an HTTP endpoint which Refractor events are posted to. Only events listed in Events are sent. Each
+// payload is signed using Secret so that the receiver can check that it came from Refractor. Secret is never sent to
+// clients except in NewWebhook.
+type Webhook struct {
+	WebhookID int64    `json:"id"`
+	Name      string   `json:"name"`
+	URL       string   `json:"url"`
+	Secret    string   `json:"-"`
+	Events    []string `json:"events"`
+	Enabled   bool     `json:"enabled"`
+	CreatedAt int64    `json:"createdAt"`
+}
+
+// NewWebhook is sent back when a webhook is created. This is the only time the secret is shown, so that it can be
+// copied into the receiver.
+type NewWebhook struct {
+	*Webhook
+	Secret string `json:"secret"`
+}
+
+// SubscribedTo returns true if the webhook is enabled and subscribed to event.
+func (w *Webhook) SubscribedTo(event string) bool {
+	if !w.Enabled {
+		return false
+	}
+
+	for _, subscribed := range w.Events {
+		if subscribed == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WebhookDelivery is a record of an event being sent to a webhook. Attempts is the number of times the payload has
+// been sent so far. ResponseStatus and Error hold the result of the most recent attempt.
+type WebhookDelivery struct {
+	DeliveryID     int64  `json:"id"`
+	WebhookID      int64  `json:"webhookId"`
+	Event          string `json:"event"`
+	Payload        string `json:"payload"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	ResponseStatus int    `json:"responseStatus"`
+	Error          string `json:"error"`
+	CreatedAt      int64  `json:"createdAt"`
+	CompletedAt    int64  `json:"completedAt"`
+}
+
+// WebhookPayload is the JSON body posted to webhooks. Data holds the event specific body.
+type WebhookPayload struct {
+	Event     string      `json:"event"`
+	Timestamp int64       `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+// WebhookPlayerEvent is the data of player join and quit webhook payloads
+type WebhookPlayerEvent struct {
+	ServerID     int64  `json:"serverId"`
+	PlayerID     int64  `json:"playerId,omitempty"`
+	PlayerGameID string `json:"playerGameId"`
+	Name         string `json:"name"`
+}
+
+// WebhookServerEvent is the data of server online and offline webhook payloads
+type WebhookServerEvent struct {
+	ServerID int64 `json:"serverId"`
+}
+
+type WebhookRepository interface {
+	Create(webhook *Webhook) error
+	FindByID(id int64) (*Webhook, error)
+	FindAll() ([]*Webhook, error)
+	Update(id int64, args UpdateArgs) (*Webhook, error)
+	Delete(id int64) error
+}
+
+type WebhookDeliveryRepository interface {
+	Create(delivery *WebhookDelivery) error
+	Update(id int64, args UpdateArgs) error
+	FindManyByWebhookID(webhookID int64, limit int, offset int) (int, []*WebhookDelivery, error)
+}
+
+type WebhookService interface {
+	CreateWebhook(body params.CreateWebhookParams) (*Webhook, *ServiceResponse)
+	GetAllWebhooks() ([]*Webhook, *ServiceResponse)
+	UpdateWebhook(id int64, body params.UpdateWebhookParams) (*Webhook, *ServiceResponse)
+	DeleteWebhook(id int64) *ServiceResponse
+	GetWebhookDeliveries(id int64, body params.SearchParams) (int, []*WebhookDelivery, *ServiceResponse)
+	OnInfractionCreate(infraction *Infraction)
+	OnInfractionUpdate(infraction *Infraction)
+	OnInfractionDelete(infraction *Infraction)
+	OnPlayerJoin(fields broadcast.Fields, serverID int64, gameConfig *GameConfig)
+	OnPlayerQuit(fields broadcast.Fields, serverID int64, gameConfig *GameConfig)
+	OnPlayerUpdate(updated *Player)
+	OnServerOnline(serverID int64)
+	OnServerOffline(serverID int64)
+	OnChatReceive(msgBody *ChatReceiveBody, serverID int64, gameConfig *GameConfig)
+	OnWatchedPlayerJoin(alert *WatchedPlayerJoin)
+}
+
+type WebhookHandler interface {
+	CreateWebhook(c echo.Context) error
+	GetAllWebhooks(c echo.Context) error
+	UpdateWebhook(c echo.Context) error
+	DeleteWebhook(c echo.Context) error
+	GetWebhookDeliveries(c echo.Context) error
+}
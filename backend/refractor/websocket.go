@@ -31,6 +31,7 @@ type ChatSendSubscriber func(msgBody *ChatSendBody)
 
 type ChatSendBody struct {
 	ServerID int64  `json:"serverId"`
+	UserID   int64  `json:"userId"`
 	Message  string `json:"message"`
 	Sender   string `json:"sender"`
 
@@ -38,6 +39,16 @@ type ChatSendBody struct {
 	SentByUser bool `json:"sendByUser"`
 }
 
+type WhisperSendSubscriber func(msgBody *WhisperSendBody)
+
+// WhisperSendBody is a private message sent by a Refractor user to a single player
+type WhisperSendBody struct {
+	ServerID int64  `json:"serverId"`
+	PlayerID int64  `json:"playerId"`
+	UserID   int64  `json:"userId"`
+	Message  string `json:"message"`
+}
+
 type WebsocketService interface {
 	Broadcast(message *WebsocketMessage)
 	CreateClient(userID int64, conn net.Conn)
@@ -47,4 +58,5 @@ type WebsocketService interface {
 	OnServerOnline(serverID int64)
 	OnServerOffline(serverID int64)
 	SubscribeChatSend(subscriber ChatSendSubscriber)
+	SubscribeWhisperSend(subscriber WhisperSendSubscriber)
 }
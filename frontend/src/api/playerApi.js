@@ -31,8 +31,8 @@ export function getRecentPlayers() {
 	return axios.get('/api/v1/players/recent');
 }
 
-export function watchPlayer(playerID) {
-	return axios.post(`/api/v1/players/${playerID}/watch`);
+export function watchPlayer(playerID, data) {
+	return axios.post(`/api/v1/players/${playerID}/watch`, data);
 }
 
 export function unwatchPlayer(playerID) {
@@ -23,6 +23,10 @@ export const EDIT_OWN_INFRACTIONS = 'EDIT_OWN_INFRACTIONS';
 export const EDIT_ANY_INFRACTION = 'EDIT_ANY_INFRACTION';
 export const DELETE_OWN_INFRACTIONS = 'DELETE_OWN_INFRACTIONS';
 export const DELETE_ANY_INFRACTION = 'DELETE_ANY_INFRACTION';
+export const MANAGE_ESCALATION_POLICIES = 'MANAGE_ESCALATION_POLICIES';
+export const REVIEW_APPEALS = 'REVIEW_APPEALS';
+export const MANAGE_CHAT_FILTERS = 'MANAGE_CHAT_FILTERS';
+export const RCON_CONSOLE = 'RCON_CONSOLE';
 
 /* global BigInt */
 /* prettier-ignore */
@@ -37,6 +41,10 @@ export const flags = {
 	EDIT_ANY_INFRACTION: 		BigInt(0b0000000010000000000000000000000000000000000000000000000000000000),
 	DELETE_OWN_INFRACTIONS: 	BigInt(0b0000000001000000000000000000000000000000000000000000000000000000),
 	DELETE_ANY_INFRACTION: 		BigInt(0b0000000000100000000000000000000000000000000000000000000000000000),
+	MANAGE_ESCALATION_POLICIES:	BigInt(0b0000000000010000000000000000000000000000000000000000000000000000),
+	REVIEW_APPEALS:				BigInt(0b0000000000001000000000000000000000000000000000000000000000000000),
+	MANAGE_CHAT_FILTERS:		BigInt(0b0000000000000100000000000000000000000000000000000000000000000000),
+	RCON_CONSOLE:				BigInt(0b0000000000000010000000000000000000000000000000000000000000000000),
 };
 
 // hasPermissions takes in a BigInt userPerms variable and a BigInt flag and runs bitwise comparison on them
@@ -49,9 +49,10 @@ export const setRecentPlayers = (recentPlayers) => ({
 });
 
 export const WATCH_PLAYER = 'WATCH_PLAYER';
-export const watchPlayer = (playerId) => ({
+export const watchPlayer = (playerId, data) => ({
 	type: WATCH_PLAYER,
 	playerId: playerId,
+	payload: data,
 });
 
 export const UNWATCH_PLAYER = 'UNWATCH_PLAYER';
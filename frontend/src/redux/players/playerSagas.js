@@ -114,7 +114,7 @@ function* getRecentPlayersAsync(action) {
 
 function* watchPlayerAsync(action) {
 	try {
-		yield call(watchPlayer, action.playerId);
+		yield call(watchPlayer, action.playerId, action.payload);
 
 		yield put(setPlayerWatched(action.playerId, true));
 	} catch (err) {